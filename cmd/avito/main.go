@@ -9,6 +9,9 @@ import (
 
 func main() {
 	cfg := config.LoadConfig()
+	if err := cfg.Validate(); err != nil {
+		log.Fatalf("неверная конфигурация: %v", err)
+	}
 
 	if err := app.Run(cfg); err != nil {
 		log.Fatalf("ошибка при запуске приложения: %v", err)
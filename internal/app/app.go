@@ -1,14 +1,26 @@
 package app
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
 
 	"github.com/forzeyy/avito-internship-spring-service/internal/config"
 	"github.com/forzeyy/avito-internship-spring-service/internal/database"
 	"github.com/forzeyy/avito-internship-spring-service/internal/routes"
 	"github.com/labstack/echo/v4"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 )
 
+const shutdownTimeout = 10 * time.Second
+
 func Run(cfg *config.Config) error {
 	dsn := fmt.Sprintf(
 		"postgres://%s:%s@%s:%s/%s?sslmode=disable",
@@ -20,9 +32,88 @@ func Run(cfg *config.Config) error {
 	}
 	defer dbConn.Close()
 
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	if err := routes.BootstrapAdmin(ctx, dbConn, cfg); err != nil {
+		return err
+	}
+
 	e := echo.New()
-	routes.InitRoutes(e, dbConn, cfg)
-	e.Logger.Fatal(e.Start(":8080"))
+	if err := routes.InitRoutes(e, dbConn, cfg); err != nil {
+		return err
+	}
+
+	grpcServer, err := routes.NewGRPCServer(dbConn, cfg)
+	if err != nil {
+		return err
+	}
+
+	lis, err := net.Listen("tcp", ":"+cfg.GRPCPort)
+	if err != nil {
+		return fmt.Errorf("не удалось открыть порт gRPC: %v", err)
+	}
+
+	// фоновые задачи останавливаются отменой workerCtx после остановки серверов
+	workerCtx, cancelWorkers := context.WithCancel(ctx)
+	defer cancelWorkers()
+	var workers sync.WaitGroup
+	if closer := routes.NewReceptionCloser(dbConn, cfg); closer != nil {
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			closer.Run(workerCtx)
+		}()
+	}
+
+	metricsMux := http.NewServeMux()
+	metricsMux.Handle("/metrics", promhttp.Handler())
+	metricsServer := &http.Server{
+		Addr:    ":" + cfg.MetricsPort,
+		Handler: metricsMux,
+	}
+
+	// все серверы работают до первой ошибки любого из них или до сигнала остановки
+	errCh := make(chan error, 3)
+	go func() {
+		if err := e.Start(":8080"); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			errCh <- fmt.Errorf("ошибка HTTP сервера: %v", err)
+		}
+	}()
+	go func() {
+		log.Printf("gRPC сервер запущен на порту %s", cfg.GRPCPort)
+		if err := grpcServer.Serve(lis); err != nil {
+			errCh <- fmt.Errorf("ошибка gRPC сервера: %v", err)
+		}
+	}()
+
+	go func() {
+		log.Printf("сервер метрик запущен на порту %s", cfg.MetricsPort)
+		if err := metricsServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			errCh <- fmt.Errorf("ошибка сервера метрик: %v", err)
+		}
+	}()
+
+	var runErr error
+	select {
+	case <-ctx.Done():
+		log.Println("получен сигнал остановки")
+	case runErr = <-errCh:
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+
+	if err := e.Shutdown(shutdownCtx); err != nil {
+		log.Printf("не удалось корректно остановить HTTP сервер: %v", err)
+	}
+	grpcServer.GracefulStop()
+	if err := metricsServer.Shutdown(shutdownCtx); err != nil {
+		log.Printf("не удалось корректно остановить сервер метрик: %v", err)
+	}
+	// подключение к базе закрывается только после остановки фоновых задач
+	cancelWorkers()
+	workers.Wait()
 
-	return nil
+	return runErr
 }
@@ -2,19 +2,69 @@ package config
 
 import (
 	"fmt"
+	"net"
 	"os"
+	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
 )
 
+const (
+	EnvDevelopment = "development"
+	EnvStaging     = "staging"
+	EnvProduction  = "production"
+)
+
+const (
+	NotifierLog  = "log"
+	NotifierSMTP = "smtp"
+)
+
+const (
+	BarcodeUniquenessReception = "reception"
+	BarcodeUniquenessGlobal    = "global"
+)
+
+// minProductionSecretLen - минимальная длина JWT_SECRET в production (256 бит для HS256)
+const minProductionSecretLen = 32
+
 type Config struct {
+	Env        string
 	DBUser     string
 	DBPassword string
 	DBHost     string
 	DBPort     string
 	DBName     string
 	JWTSecret  string
-	Port       string
+	// JWTKeysDir - каталог с PEM ключами подписи; если не задан, токены подписываются общим секретом JWTSecret
+	JWTKeysDir   string
+	JWTActiveKID string
+	Port         string
+	GRPCPort     string
+	MetricsPort  string
+	// Notifier - способ доставки писем: log пишет их в NotifierFile или стандартный вывод, smtp отправляет через SMTP
+	Notifier     string
+	NotifierFile string
+	SMTPHost     string
+	SMTPPort     string
+	SMTPUsername string
+	SMTPPassword string
+	SMTPFrom     string
+	// ReceptionIdleTimeout - через сколько времени без новых товаров приемка закрывается автоматически (например, 12h).
+	// Пустое значение отключает автозакрытие
+	ReceptionIdleTimeout   string
+	ReceptionCloseInterval string
+	// BarcodeUniqueness - в каких пределах штрихкод посылки не может повторяться: reception (по умолчанию) -
+	// в одной приемке, global - во всех приемках всех ПВЗ
+	BarcodeUniqueness string
+	// AdminEmail и AdminPassword задают первого администратора; он создается при запуске, только пока в системе
+	// нет ни одного администратора
+	AdminEmail    string
+	AdminPassword string
+	// TrustedProxies - подсети обратных прокси через запятую (например, 10.0.0.0/8). Только от них учитывается
+	// X-Forwarded-For; если не задано, IP клиента берется из адреса соединения
+	TrustedProxies string
 }
 
 func LoadConfig() *Config {
@@ -23,11 +73,133 @@ func LoadConfig() *Config {
 	}
 
 	return &Config{
-		DBUser:     os.Getenv("DB_USER"),
-		DBPassword: os.Getenv("DB_PASSWORD"),
-		DBHost:     os.Getenv("DB_HOST"),
-		DBPort:     os.Getenv("DB_PORT"),
-		DBName:     os.Getenv("DB_NAME"),
-		JWTSecret:  os.Getenv("JWT_SECRET"),
+		Env:          os.Getenv("APP_ENV"),
+		DBUser:       os.Getenv("DB_USER"),
+		DBPassword:   os.Getenv("DB_PASSWORD"),
+		DBHost:       os.Getenv("DB_HOST"),
+		DBPort:       os.Getenv("DB_PORT"),
+		DBName:       os.Getenv("DB_NAME"),
+		JWTSecret:    os.Getenv("JWT_SECRET"),
+		JWTKeysDir:   os.Getenv("JWT_KEYS_DIR"),
+		JWTActiveKID: os.Getenv("JWT_ACTIVE_KID"),
+		GRPCPort:     getEnv("GRPC_PORT", "3000"),
+		MetricsPort:  getEnv("METRICS_PORT", "9000"),
+		Notifier:     getEnv("NOTIFIER", NotifierLog),
+		NotifierFile: os.Getenv("NOTIFIER_FILE"),
+		SMTPHost:     os.Getenv("SMTP_HOST"),
+		SMTPPort:     getEnv("SMTP_PORT", "587"),
+		SMTPUsername: os.Getenv("SMTP_USERNAME"),
+		SMTPPassword: os.Getenv("SMTP_PASSWORD"),
+		SMTPFrom:     os.Getenv("SMTP_FROM"),
+
+		ReceptionIdleTimeout:   os.Getenv("RECEPTION_IDLE_TIMEOUT"),
+		ReceptionCloseInterval: getEnv("RECEPTION_CLOSE_INTERVAL", "5m"),
+		BarcodeUniqueness:      getEnv("BARCODE_UNIQUENESS", BarcodeUniquenessReception),
+		AdminEmail:             os.Getenv("ADMIN_EMAIL"),
+		AdminPassword:          os.Getenv("ADMIN_PASSWORD"),
+		TrustedProxies:         os.Getenv("TRUSTED_PROXIES"),
+	}
+}
+
+// Validate проверяет, что с конфигурацией можно запускаться в выбранном окружении
+func (c *Config) Validate() error {
+	if c.JWTKeysDir != "" && c.JWTActiveKID == "" {
+		return fmt.Errorf("при заданном JWT_KEYS_DIR нужно указать JWT_ACTIVE_KID")
+	}
+
+	switch c.Notifier {
+	case NotifierLog:
+	case NotifierSMTP:
+		if c.SMTPHost == "" || c.SMTPFrom == "" {
+			return fmt.Errorf("для NOTIFIER=smtp нужно указать SMTP_HOST и SMTP_FROM")
+		}
+	default:
+		return fmt.Errorf("неизвестный способ доставки писем NOTIFIER=%q", c.Notifier)
+	}
+
+	if c.ReceptionIdleTimeout != "" {
+		if d, err := time.ParseDuration(c.ReceptionIdleTimeout); err != nil || d <= 0 {
+			return fmt.Errorf("RECEPTION_IDLE_TIMEOUT должен быть положительной длительностью, например 12h")
+		}
+		if d, err := time.ParseDuration(c.ReceptionCloseInterval); err != nil || d <= 0 {
+			return fmt.Errorf("RECEPTION_CLOSE_INTERVAL должен быть положительной длительностью, например 5m")
+		}
+	}
+
+	if (c.AdminEmail == "") != (c.AdminPassword == "") {
+		return fmt.Errorf("ADMIN_EMAIL и ADMIN_PASSWORD задаются вместе")
+	}
+
+	for _, cidr := range splitList(c.TrustedProxies) {
+		if _, _, err := net.ParseCIDR(cidr); err != nil {
+			return fmt.Errorf("TRUSTED_PROXIES должен быть списком подсетей через запятую, например 10.0.0.0/8: %q", cidr)
+		}
+	}
+
+	switch c.BarcodeUniqueness {
+	case "", BarcodeUniquenessReception, BarcodeUniquenessGlobal:
+	default:
+		return fmt.Errorf("неизвестная область уникальности штрихкода BARCODE_UNIQUENESS=%q", c.BarcodeUniqueness)
+	}
+
+	switch c.Env {
+	case "":
+		return fmt.Errorf("APP_ENV не задан: укажите development, staging или production")
+	case EnvDevelopment, EnvStaging:
+	case EnvProduction:
+		if c.JWTKeysDir == "" && len(c.JWTSecret) < minProductionSecretLen {
+			return fmt.Errorf("в production JWT_SECRET должен быть не короче %d символов", minProductionSecretLen)
+		}
+		// в письмах токены сброса пароля, им не место в логах
+		if c.Notifier != NotifierSMTP {
+			return fmt.Errorf("в production письма должны отправляться через SMTP (NOTIFIER=smtp)")
+		}
+	default:
+		return fmt.Errorf("неизвестное окружение APP_ENV=%q", c.Env)
+	}
+	return nil
+}
+
+// IsDevelopment сообщает, включены ли маршруты для локальной разработки, например /dummyLogin
+func (c *Config) IsDevelopment() bool {
+	return c.Env == EnvDevelopment
+}
+
+// ReceptionAutoClose возвращает срок бездействия приемки и период проверки; нулевой срок означает, что автозакрытие
+// выключено. Значения проверяются в Validate
+func (c *Config) ReceptionAutoClose() (idleTimeout, interval time.Duration) {
+	if c.ReceptionIdleTimeout == "" {
+		return 0, 0
+	}
+	idleTimeout, _ = time.ParseDuration(c.ReceptionIdleTimeout)
+	interval, _ = time.ParseDuration(c.ReceptionCloseInterval)
+	return idleTimeout, interval
+}
+
+// TrustedProxyNets возвращает подсети доверенных прокси. Значения проверяются в Validate
+func (c *Config) TrustedProxyNets() []*net.IPNet {
+	var nets []*net.IPNet
+	for _, cidr := range splitList(c.TrustedProxies) {
+		if _, ipNet, err := net.ParseCIDR(cidr); err == nil {
+			nets = append(nets, ipNet)
+		}
+	}
+	return nets
+}
+
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+func getEnv(key, fallback string) string {
+	if value, ok := os.LookupEnv(key); ok && value != "" {
+		return value
 	}
+	return fallback
 }
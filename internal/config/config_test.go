@@ -0,0 +1,83 @@
+package config_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/forzeyy/avito-internship-spring-service/internal/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidate(t *testing.T) {
+	strongSecret := strings.Repeat("s", 32)
+	smtp := func(cfg config.Config) config.Config {
+		cfg.Notifier, cfg.SMTPHost, cfg.SMTPFrom = config.NotifierSMTP, "smtp.local", "noreply@pvz.local"
+		return cfg
+	}
+
+	tests := []struct {
+		name    string
+		cfg     config.Config
+		wantErr string
+	}{
+		{"development со слабым секретом", config.Config{Env: config.EnvDevelopment, JWTSecret: "secrettt", Notifier: config.NotifierLog}, ""},
+		{"staging", config.Config{Env: config.EnvStaging, JWTSecret: "secrettt", Notifier: config.NotifierLog}, ""},
+		{"production с надежным секретом", smtp(config.Config{Env: config.EnvProduction, JWTSecret: strongSecret}), ""},
+		{"production без секрета", smtp(config.Config{Env: config.EnvProduction}), "в production JWT_SECRET должен быть не короче 32 символов"},
+		{"production со слабым секретом", smtp(config.Config{Env: config.EnvProduction, JWTSecret: "secrettt"}), "в production JWT_SECRET должен быть не короче 32 символов"},
+		{"production с ключами без секрета", smtp(config.Config{Env: config.EnvProduction, JWTKeysDir: "/keys", JWTActiveKID: "2025-01"}), ""},
+		{"ключи без активного kid", config.Config{Env: config.EnvDevelopment, JWTKeysDir: "/keys", Notifier: config.NotifierLog}, "при заданном JWT_KEYS_DIR нужно указать JWT_ACTIVE_KID"},
+		{"окружение не задано", config.Config{JWTSecret: strongSecret, Notifier: config.NotifierLog}, "APP_ENV не задан: укажите development, staging или production"},
+		{"неизвестное окружение", smtp(config.Config{Env: "prod", JWTSecret: strongSecret}), `неизвестное окружение APP_ENV="prod"`},
+		{"production с письмами в лог", config.Config{Env: config.EnvProduction, JWTSecret: strongSecret, Notifier: config.NotifierLog}, "в production письма должны отправляться через SMTP (NOTIFIER=smtp)"},
+		{"smtp без сервера", config.Config{Env: config.EnvDevelopment, Notifier: config.NotifierSMTP}, "для NOTIFIER=smtp нужно указать SMTP_HOST и SMTP_FROM"},
+		{"неизвестный способ доставки", config.Config{Env: config.EnvDevelopment, Notifier: "sms"}, `неизвестный способ доставки писем NOTIFIER="sms"`},
+		{"автозакрытие приемок", config.Config{Env: config.EnvDevelopment, Notifier: config.NotifierLog, ReceptionIdleTimeout: "12h", ReceptionCloseInterval: "5m"}, ""},
+		{"неверный срок автозакрытия", config.Config{Env: config.EnvDevelopment, Notifier: config.NotifierLog, ReceptionIdleTimeout: "12", ReceptionCloseInterval: "5m"}, "RECEPTION_IDLE_TIMEOUT должен быть положительной длительностью, например 12h"},
+		{"нулевой период проверки", config.Config{Env: config.EnvDevelopment, Notifier: config.NotifierLog, ReceptionIdleTimeout: "12h", ReceptionCloseInterval: "0s"}, "RECEPTION_CLOSE_INTERVAL должен быть положительной длительностью, например 5m"},
+		{"глобальная уникальность штрихкода", config.Config{Env: config.EnvDevelopment, Notifier: config.NotifierLog, BarcodeUniqueness: config.BarcodeUniquenessGlobal}, ""},
+		{"первый администратор", config.Config{Env: config.EnvDevelopment, Notifier: config.NotifierLog, AdminEmail: "admin@mail.ru", AdminPassword: "secret"}, ""},
+		{"администратор без пароля", config.Config{Env: config.EnvDevelopment, Notifier: config.NotifierLog, AdminEmail: "admin@mail.ru"}, "ADMIN_EMAIL и ADMIN_PASSWORD задаются вместе"},
+		{"доверенные прокси", config.Config{Env: config.EnvDevelopment, Notifier: config.NotifierLog, TrustedProxies: "10.0.0.0/8, 192.168.1.10/32"}, ""},
+		{"неверная подсеть прокси", config.Config{Env: config.EnvDevelopment, Notifier: config.NotifierLog, TrustedProxies: "10.0.0.0/8,proxy"}, `TRUSTED_PROXIES должен быть списком подсетей через запятую, например 10.0.0.0/8: "proxy"`},
+		{"неизвестная уникальность штрихкода", config.Config{Env: config.EnvDevelopment, Notifier: config.NotifierLog, BarcodeUniqueness: "pvz"}, `неизвестная область уникальности штрихкода BARCODE_UNIQUENESS="pvz"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestIsDevelopment(t *testing.T) {
+	assert.True(t, (&config.Config{Env: config.EnvDevelopment}).IsDevelopment())
+	assert.False(t, (&config.Config{Env: config.EnvStaging}).IsDevelopment())
+	assert.False(t, (&config.Config{Env: config.EnvProduction}).IsDevelopment())
+}
+
+func TestReceptionAutoClose(t *testing.T) {
+	idle, interval := (&config.Config{}).ReceptionAutoClose()
+	assert.Zero(t, idle)
+	assert.Zero(t, interval)
+
+	idle, interval = (&config.Config{ReceptionIdleTimeout: "12h", ReceptionCloseInterval: "5m"}).ReceptionAutoClose()
+	assert.Equal(t, 12*time.Hour, idle)
+	assert.Equal(t, 5*time.Minute, interval)
+}
+
+func TestTrustedProxyNets(t *testing.T) {
+	assert.Empty(t, (&config.Config{}).TrustedProxyNets())
+
+	nets := (&config.Config{TrustedProxies: "10.0.0.0/8, 192.168.1.10/32"}).TrustedProxyNets()
+	if assert.Len(t, nets, 2) {
+		assert.Equal(t, "10.0.0.0/8", nets[0].String())
+		assert.Equal(t, "192.168.1.10/32", nets[1].String())
+	}
+}
@@ -47,6 +47,10 @@ func (db *DB) Query(ctx context.Context, query string, args ...interface{}) (pgx
 	return rows, nil
 }
 
+func (db *DB) BeginTx(ctx context.Context, txOptions pgx.TxOptions) (pgx.Tx, error) {
+	return db.pool.BeginTx(ctx, txOptions)
+}
+
 func (db *DB) Close() {
 	db.pool.Close()
 }
@@ -4,8 +4,16 @@
 package dto
 
 import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"fmt"
+	"net/url"
+	"path"
+	"strings"
 	"time"
 
+	"github.com/getkin/kin-openapi/openapi3"
 	openapi_types "github.com/oapi-codegen/runtime/types"
 )
 
@@ -13,6 +21,53 @@ const (
 	BearerAuthScopes = "bearerAuth.Scopes"
 )
 
+// Defines values for AuditEntryAction.
+const (
+	AuditEntryActionProductAdd               AuditEntryAction = "product.add"
+	AuditEntryActionProductBatchAdd          AuditEntryAction = "product.batch_add"
+	AuditEntryActionProductDelete            AuditEntryAction = "product.delete"
+	AuditEntryActionPvzAssign                AuditEntryAction = "pvz.assign"
+	AuditEntryActionPvzCreate                AuditEntryAction = "pvz.create"
+	AuditEntryActionPvzUnassign              AuditEntryAction = "pvz.unassign"
+	AuditEntryActionReceptionAutoClose       AuditEntryAction = "reception.auto_close"
+	AuditEntryActionReceptionClose           AuditEntryAction = "reception.close"
+	AuditEntryActionReceptionOpen            AuditEntryAction = "reception.open"
+	AuditEntryActionRoleUpdate               AuditEntryAction = "role.update"
+	AuditEntryActionUserActivate             AuditEntryAction = "user.activate"
+	AuditEntryActionUserDeactivate           AuditEntryAction = "user.deactivate"
+	AuditEntryActionUserForcePasswordReset   AuditEntryAction = "user.force_password_reset"
+	AuditEntryActionUserLogin                AuditEntryAction = "user.login"
+	AuditEntryActionUserPasswordReset        AuditEntryAction = "user.password_reset"
+	AuditEntryActionUserPasswordResetRequest AuditEntryAction = "user.password_reset_request"
+	AuditEntryActionUserRegister             AuditEntryAction = "user.register"
+	AuditEntryActionUserRoleChange           AuditEntryAction = "user.role_change"
+	AuditEntryActionUserUnlock               AuditEntryAction = "user.unlock"
+)
+
+// Defines values for AuditEntryOutcome.
+const (
+	AuditEntryOutcomeFailure AuditEntryOutcome = "failure"
+	AuditEntryOutcomeSuccess AuditEntryOutcome = "success"
+)
+
+// Defines values for JWKAlg.
+const (
+	EdDSA JWKAlg = "EdDSA"
+	RS256 JWKAlg = "RS256"
+)
+
+// Defines values for JWKKty.
+const (
+	OKP JWKKty = "OKP"
+	RSA JWKKty = "RSA"
+)
+
+// Defines values for LoginLockoutScope.
+const (
+	Email LoginLockoutScope = "email"
+	Ip    LoginLockoutScope = "ip"
+)
+
 // Defines values for PVZCity.
 const (
 	Казань         PVZCity = "Казань"
@@ -27,22 +82,55 @@ const (
 	ProductTypeЭлектроника ProductType = "электроника"
 )
 
+// Defines values for ProductBatchItemResultStatus.
+const (
+	Created  ProductBatchItemResultStatus = "created"
+	Rejected ProductBatchItemResultStatus = "rejected"
+)
+
 // Defines values for ReceptionStatus.
 const (
-	Close      ReceptionStatus = "close"
-	InProgress ReceptionStatus = "in_progress"
+	ReceptionStatusClose      ReceptionStatus = "close"
+	ReceptionStatusInProgress ReceptionStatus = "in_progress"
 )
 
-// Defines values for UserRole.
+// Defines values for GetAuditParamsAction.
 const (
-	UserRoleEmployee  UserRole = "employee"
-	UserRoleModerator UserRole = "moderator"
+	GetAuditParamsActionProductAdd               GetAuditParamsAction = "product.add"
+	GetAuditParamsActionProductBatchAdd          GetAuditParamsAction = "product.batch_add"
+	GetAuditParamsActionProductDelete            GetAuditParamsAction = "product.delete"
+	GetAuditParamsActionPvzAssign                GetAuditParamsAction = "pvz.assign"
+	GetAuditParamsActionPvzCreate                GetAuditParamsAction = "pvz.create"
+	GetAuditParamsActionPvzUnassign              GetAuditParamsAction = "pvz.unassign"
+	GetAuditParamsActionReceptionAutoClose       GetAuditParamsAction = "reception.auto_close"
+	GetAuditParamsActionReceptionClose           GetAuditParamsAction = "reception.close"
+	GetAuditParamsActionReceptionOpen            GetAuditParamsAction = "reception.open"
+	GetAuditParamsActionRoleUpdate               GetAuditParamsAction = "role.update"
+	GetAuditParamsActionUserActivate             GetAuditParamsAction = "user.activate"
+	GetAuditParamsActionUserDeactivate           GetAuditParamsAction = "user.deactivate"
+	GetAuditParamsActionUserForcePasswordReset   GetAuditParamsAction = "user.force_password_reset"
+	GetAuditParamsActionUserLogin                GetAuditParamsAction = "user.login"
+	GetAuditParamsActionUserPasswordReset        GetAuditParamsAction = "user.password_reset"
+	GetAuditParamsActionUserPasswordResetRequest GetAuditParamsAction = "user.password_reset_request"
+	GetAuditParamsActionUserRegister             GetAuditParamsAction = "user.register"
+	GetAuditParamsActionUserRoleChange           GetAuditParamsAction = "user.role_change"
+	GetAuditParamsActionUserUnlock               GetAuditParamsAction = "user.unlock"
+)
+
+// Defines values for GetAuditParamsOutcome.
+const (
+	GetAuditParamsOutcomeFailure GetAuditParamsOutcome = "failure"
+	GetAuditParamsOutcomeSuccess GetAuditParamsOutcome = "success"
 )
 
 // Defines values for PostDummyLoginJSONBodyRole.
 const (
-	PostDummyLoginJSONBodyRoleEmployee  PostDummyLoginJSONBodyRole = "employee"
-	PostDummyLoginJSONBodyRoleModerator PostDummyLoginJSONBodyRole = "moderator"
+	PostDummyLoginJSONBodyRoleAdmin           PostDummyLoginJSONBodyRole = "admin"
+	PostDummyLoginJSONBodyRoleAuditor         PostDummyLoginJSONBodyRole = "auditor"
+	PostDummyLoginJSONBodyRoleEmployee        PostDummyLoginJSONBodyRole = "employee"
+	PostDummyLoginJSONBodyRoleModerator       PostDummyLoginJSONBodyRole = "moderator"
+	PostDummyLoginJSONBodyRoleRegionalManager PostDummyLoginJSONBodyRole = "regional_manager"
+	PostDummyLoginJSONBodyRoleSeniorEmployee  PostDummyLoginJSONBodyRole = "senior_employee"
 )
 
 // Defines values for PostProductsJSONBodyType.
@@ -52,17 +140,93 @@ const (
 	PostProductsJSONBodyTypeЭлектроника PostProductsJSONBodyType = "электроника"
 )
 
+// Defines values for GetReceptionsParamsStatus.
+const (
+	GetReceptionsParamsStatusClose      GetReceptionsParamsStatus = "close"
+	GetReceptionsParamsStatusInProgress GetReceptionsParamsStatus = "in_progress"
+)
+
 // Defines values for PostRegisterJSONBodyRole.
 const (
-	Employee  PostRegisterJSONBodyRole = "employee"
-	Moderator PostRegisterJSONBodyRole = "moderator"
+	PostRegisterJSONBodyRoleEmployee PostRegisterJSONBodyRole = "employee"
 )
 
+// Defines values for GetUsersParamsStatus.
+const (
+	Active      GetUsersParamsStatus = "active"
+	Deactivated GetUsersParamsStatus = "deactivated"
+)
+
+// AuditEntry defines model for AuditEntry.
+type AuditEntry struct {
+	Action    AuditEntryAction    `json:"action"`
+	ActorId   *openapi_types.UUID `json:"actorId,omitempty"`
+	ActorRole *string             `json:"actorRole,omitempty"`
+	CreatedAt time.Time           `json:"createdAt"`
+
+	// Details Подробности действия, например новый набор прав роли
+	Details     *map[string]interface{} `json:"details,omitempty"`
+	Error       *string                 `json:"error,omitempty"`
+	Id          openapi_types.UUID      `json:"id"`
+	Outcome     AuditEntryOutcome       `json:"outcome"`
+	ProductId   *openapi_types.UUID     `json:"productId,omitempty"`
+	PvzId       *openapi_types.UUID     `json:"pvzId,omitempty"`
+	ReceptionId *openapi_types.UUID     `json:"receptionId,omitempty"`
+	RequestId   *string                 `json:"requestId,omitempty"`
+
+	// Role Роль, права которой изменены
+	Role   *string             `json:"role,omitempty"`
+	UserId *openapi_types.UUID `json:"userId,omitempty"`
+}
+
+// AuditEntryAction defines model for AuditEntry.Action.
+type AuditEntryAction string
+
+// AuditEntryOutcome defines model for AuditEntry.Outcome.
+type AuditEntryOutcome string
+
 // Error defines model for Error.
 type Error struct {
 	Message string `json:"message"`
 }
 
+// JWK defines model for JWK.
+type JWK struct {
+	Alg JWKAlg  `json:"alg"`
+	Crv *string `json:"crv,omitempty"`
+	E   *string `json:"e,omitempty"`
+	Kid string  `json:"kid"`
+	Kty JWKKty  `json:"kty"`
+	N   *string `json:"n,omitempty"`
+	Use string  `json:"use"`
+	X   *string `json:"x,omitempty"`
+}
+
+// JWKAlg defines model for JWK.Alg.
+type JWKAlg string
+
+// JWKKty defines model for JWK.Kty.
+type JWKKty string
+
+// JWKSet defines model for JWKSet.
+type JWKSet struct {
+	Keys []JWK `json:"keys"`
+}
+
+// LoginLockout defines model for LoginLockout.
+type LoginLockout struct {
+	FailedCount int `json:"failedCount"`
+
+	// Key Email или IP адрес, для которого заблокирован вход
+	Key          string            `json:"key"`
+	LastFailedAt time.Time         `json:"lastFailedAt"`
+	LockedUntil  time.Time         `json:"lockedUntil"`
+	Scope        LoginLockoutScope `json:"scope"`
+}
+
+// LoginLockoutScope defines model for LoginLockout.Scope.
+type LoginLockoutScope string
+
 // PVZ defines model for PVZ.
 type PVZ struct {
 	City             PVZCity             `json:"city"`
@@ -73,8 +237,38 @@ type PVZ struct {
 // PVZCity defines model for PVZ.City.
 type PVZCity string
 
+// PVZAssignment defines model for PVZAssignment.
+type PVZAssignment struct {
+	AssignedAt time.Time           `json:"assignedAt"`
+	AssignedBy *openapi_types.UUID `json:"assignedBy,omitempty"`
+	Email      openapi_types.Email `json:"email"`
+	PvzId      openapi_types.UUID  `json:"pvzId"`
+	UserId     openapi_types.UUID  `json:"userId"`
+}
+
+// PVZDetails defines model for PVZDetails.
+type PVZDetails struct {
+	History       []ReceptionHistoryItem `json:"history"`
+	OpenReception *Reception             `json:"openReception,omitempty"`
+	Pvz           PVZ                    `json:"pvz"`
+}
+
+// PVZWithReceptions defines model for PVZWithReceptions.
+type PVZWithReceptions struct {
+	Pvz        PVZ                     `json:"pvz"`
+	Receptions []ReceptionWithProducts `json:"receptions"`
+}
+
+// Permission defines model for Permission.
+type Permission struct {
+	Description string `json:"description"`
+	Name        string `json:"name"`
+}
+
 // Product defines model for Product.
 type Product struct {
+	// Barcode Штрихкод посылки; у товаров, принятых без него, отсутствует
+	Barcode     *string             `json:"barcode,omitempty"`
 	DateTime    *time.Time          `json:"dateTime,omitempty"`
 	Id          *openapi_types.UUID `json:"id,omitempty"`
 	ReceptionId openapi_types.UUID  `json:"receptionId"`
@@ -84,29 +278,142 @@ type Product struct {
 // ProductType defines model for Product.Type.
 type ProductType string
 
+// ProductBatchItemResult defines model for ProductBatchItemResult.
+type ProductBatchItemResult struct {
+	// Error Причина отказа для статуса rejected
+	Error *string `json:"error,omitempty"`
+
+	// Index Позиция товара в запросе
+	Index   int                          `json:"index"`
+	Product *Product                     `json:"product,omitempty"`
+	Status  ProductBatchItemResultStatus `json:"status"`
+}
+
+// ProductBatchItemResultStatus defines model for ProductBatchItemResult.Status.
+type ProductBatchItemResultStatus string
+
+// ProductBatchResult defines model for ProductBatchResult.
+type ProductBatchResult struct {
+	Created     int                      `json:"created"`
+	Items       []ProductBatchItemResult `json:"items"`
+	ReceptionId openapi_types.UUID       `json:"receptionId"`
+	Rejected    int                      `json:"rejected"`
+}
+
+// ProductLocation Где и когда была принята посылка
+type ProductLocation struct {
+	// City Город ПВЗ
+	City    string             `json:"city"`
+	Product Product            `json:"product"`
+	PvzId   openapi_types.UUID `json:"pvzId"`
+
+	// ReceptionStatus Статус приемки, in_progress или close
+	ReceptionStatus string `json:"receptionStatus"`
+}
+
 // Reception defines model for Reception.
 type Reception struct {
-	DateTime time.Time           `json:"dateTime"`
-	Id       *openapi_types.UUID `json:"id,omitempty"`
-	PvzId    openapi_types.UUID  `json:"pvzId"`
-	Status   ReceptionStatus     `json:"status"`
+	// AutoClosed Приемка закрыта автоматически, потому что в нее долго не добавляли товары
+	AutoClosed *bool               `json:"autoClosed,omitempty"`
+	ClosedAt   *time.Time          `json:"closedAt,omitempty"`
+	DateTime   time.Time           `json:"dateTime"`
+	Id         *openapi_types.UUID `json:"id,omitempty"`
+	PvzId      openapi_types.UUID  `json:"pvzId"`
+	Status     ReceptionStatus     `json:"status"`
+
+	// Summary Итог приемки, сохраненный при ее закрытии
+	Summary *ReceptionSummary `json:"summary,omitempty"`
 }
 
 // ReceptionStatus defines model for Reception.Status.
 type ReceptionStatus string
 
+// ReceptionHistoryItem defines model for ReceptionHistoryItem.
+type ReceptionHistoryItem struct {
+	ProductCount int       `json:"productCount"`
+	Reception    Reception `json:"reception"`
+}
+
+// ReceptionSummary Итог приемки, сохраненный при ее закрытии
+type ReceptionSummary struct {
+	// DurationSeconds Время от открытия до закрытия приемки в секундах
+	DurationSeconds int        `json:"durationSeconds"`
+	FirstScanAt     *time.Time `json:"firstScanAt,omitempty"`
+	LastScanAt      *time.Time `json:"lastScanAt,omitempty"`
+	ProductCount    int        `json:"productCount"`
+
+	// ProductsByType Количество товаров каждого типа
+	ProductsByType map[string]int `json:"productsByType"`
+}
+
+// ReceptionWithProducts defines model for ReceptionWithProducts.
+type ReceptionWithProducts struct {
+	Products  []Product `json:"products"`
+	Reception Reception `json:"reception"`
+}
+
+// RefreshTokenRequest defines model for RefreshTokenRequest.
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refreshToken"`
+}
+
+// Role defines model for Role.
+type Role struct {
+	Description string   `json:"description"`
+	Name        string   `json:"name"`
+	Permissions []string `json:"permissions"`
+}
+
 // Token defines model for Token.
 type Token = string
 
+// TokenPair defines model for TokenPair.
+type TokenPair struct {
+	AccessToken  string `json:"accessToken"`
+	RefreshToken string `json:"refreshToken"`
+}
+
 // User defines model for User.
 type User struct {
-	Email openapi_types.Email `json:"email"`
-	Id    *openapi_types.UUID `json:"id,omitempty"`
-	Role  UserRole            `json:"role"`
+	CreatedAt *time.Time `json:"createdAt,omitempty"`
+
+	// DeactivatedAt Задано у деактивированной учетной записи
+	DeactivatedAt *time.Time          `json:"deactivatedAt,omitempty"`
+	Email         openapi_types.Email `json:"email"`
+	Id            *openapi_types.UUID `json:"id,omitempty"`
+
+	// PasswordResetRequired Вход запрещен до смены пароля
+	PasswordResetRequired *bool  `json:"passwordResetRequired,omitempty"`
+	Role                  string `json:"role"`
 }
 
-// UserRole defines model for User.Role.
-type UserRole string
+// GetAuditParams defines parameters for GetAudit.
+type GetAuditParams struct {
+	// ActorId Пользователь, выполнивший действие
+	ActorId     *openapi_types.UUID    `form:"actorId,omitempty" json:"actorId,omitempty"`
+	Action      *GetAuditParamsAction  `form:"action,omitempty" json:"action,omitempty"`
+	PvzId       *openapi_types.UUID    `form:"pvzId,omitempty" json:"pvzId,omitempty"`
+	ReceptionId *openapi_types.UUID    `form:"receptionId,omitempty" json:"receptionId,omitempty"`
+	Outcome     *GetAuditParamsOutcome `form:"outcome,omitempty" json:"outcome,omitempty"`
+
+	// From Начало диапазона времени действия
+	From *time.Time `form:"from,omitempty" json:"from,omitempty"`
+
+	// To Конец диапазона времени действия
+	To *time.Time `form:"to,omitempty" json:"to,omitempty"`
+
+	// Page Номер страницы
+	Page *int `form:"page,omitempty" json:"page,omitempty"`
+
+	// Limit Количество элементов на странице
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// GetAuditParamsAction defines parameters for GetAudit.
+type GetAuditParamsAction string
+
+// GetAuditParamsOutcome defines parameters for GetAudit.
+type GetAuditParamsOutcome string
 
 // PostDummyLoginJSONBody defines parameters for PostDummyLogin.
 type PostDummyLoginJSONBody struct {
@@ -122,15 +429,37 @@ type PostLoginJSONBody struct {
 	Password string              `json:"password"`
 }
 
+// PostPasswordForgotJSONBody defines parameters for PostPasswordForgot.
+type PostPasswordForgotJSONBody struct {
+	Email openapi_types.Email `json:"email"`
+}
+
+// PostPasswordResetJSONBody defines parameters for PostPasswordReset.
+type PostPasswordResetJSONBody struct {
+	Password string `json:"password"`
+	Token    string `json:"token"`
+}
+
 // PostProductsJSONBody defines parameters for PostProducts.
 type PostProductsJSONBody struct {
-	PvzId openapi_types.UUID       `json:"pvzId"`
-	Type  PostProductsJSONBodyType `json:"type"`
+	// Barcode Штрихкод посылки. Повторно принять посылку с тем же штрихкодом нельзя в пределах приемки или, при BARCODE_UNIQUENESS=global, во всех ПВЗ
+	Barcode *string                  `json:"barcode,omitempty"`
+	PvzId   openapi_types.UUID       `json:"pvzId"`
+	Type    PostProductsJSONBodyType `json:"type"`
 }
 
 // PostProductsJSONBodyType defines parameters for PostProducts.
 type PostProductsJSONBodyType string
 
+// PostProductsBatchJSONBody defines parameters for PostProductsBatch.
+type PostProductsBatchJSONBody struct {
+	Items []struct {
+		Barcode *string `json:"barcode,omitempty"`
+		Type    string  `json:"type"`
+	} `json:"items"`
+	PvzId openapi_types.UUID `json:"pvzId"`
+}
+
 // GetPvzParams defines parameters for GetPvz.
 type GetPvzParams struct {
 	// StartDate Начальная дата диапазона
@@ -144,8 +473,52 @@ type GetPvzParams struct {
 
 	// Limit Количество элементов на странице
 	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Cursor Курсор для keyset-пагинации. Пустое значение запрашивает первую страницу, дальше передается значение заголовка X-Next-Cursor. При наличии курсора параметр page игнорируется
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
+}
+
+// GetPvzPvzIdParams defines parameters for GetPvzPvzId.
+type GetPvzPvzIdParams struct {
+	// Page Номер страницы истории приемок
+	Page *int `form:"page,omitempty" json:"page,omitempty"`
+
+	// Limit Количество приемок истории на странице
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// PostPvzPvzIdEmployeesJSONBody defines parameters for PostPvzPvzIdEmployees.
+type PostPvzPvzIdEmployeesJSONBody struct {
+	UserId openapi_types.UUID `json:"userId"`
+}
+
+// GetReceptionsParams defines parameters for GetReceptions.
+type GetReceptionsParams struct {
+	PvzId  *openapi_types.UUID        `form:"pvzId,omitempty" json:"pvzId,omitempty"`
+	Status *GetReceptionsParamsStatus `form:"status,omitempty" json:"status,omitempty"`
+
+	// CreatedFrom Начало диапазона даты создания приемки
+	CreatedFrom *time.Time `form:"createdFrom,omitempty" json:"createdFrom,omitempty"`
+
+	// CreatedTo Конец диапазона даты создания приемки
+	CreatedTo *time.Time `form:"createdTo,omitempty" json:"createdTo,omitempty"`
+
+	// ClosedFrom Начало диапазона даты закрытия приемки
+	ClosedFrom *time.Time `form:"closedFrom,omitempty" json:"closedFrom,omitempty"`
+
+	// ClosedTo Конец диапазона даты закрытия приемки
+	ClosedTo *time.Time `form:"closedTo,omitempty" json:"closedTo,omitempty"`
+
+	// Page Номер страницы
+	Page *int `form:"page,omitempty" json:"page,omitempty"`
+
+	// Limit Количество элементов на странице
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
 }
 
+// GetReceptionsParamsStatus defines parameters for GetReceptions.
+type GetReceptionsParamsStatus string
+
 // PostReceptionsJSONBody defines parameters for PostReceptions.
 type PostReceptionsJSONBody struct {
 	PvzId openapi_types.UUID `json:"pvzId"`
@@ -153,28 +526,283 @@ type PostReceptionsJSONBody struct {
 
 // PostRegisterJSONBody defines parameters for PostRegister.
 type PostRegisterJSONBody struct {
-	Email    openapi_types.Email      `json:"email"`
-	Password string                   `json:"password"`
-	Role     PostRegisterJSONBodyRole `json:"role"`
+	Email    openapi_types.Email `json:"email"`
+	Password string              `json:"password"`
+
+	// Role Остальные роли назначает администратор
+	Role PostRegisterJSONBodyRole `json:"role"`
 }
 
 // PostRegisterJSONBodyRole defines parameters for PostRegister.
 type PostRegisterJSONBodyRole string
 
+// PutRolesRolePermissionsJSONBody defines parameters for PutRolesRolePermissions.
+type PutRolesRolePermissionsJSONBody struct {
+	Permissions []string `json:"permissions"`
+}
+
+// PostTokenJSONBody defines parameters for PostToken.
+type PostTokenJSONBody struct {
+	Email    openapi_types.Email `json:"email"`
+	Password string              `json:"password"`
+}
+
+// GetUsersParams defines parameters for GetUsers.
+type GetUsersParams struct {
+	// Email Часть email без учета регистра
+	Email  *string               `form:"email,omitempty" json:"email,omitempty"`
+	Role   *string               `form:"role,omitempty" json:"role,omitempty"`
+	Status *GetUsersParamsStatus `form:"status,omitempty" json:"status,omitempty"`
+	Page   *int                  `form:"page,omitempty" json:"page,omitempty"`
+	Limit  *int                  `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// GetUsersParamsStatus defines parameters for GetUsers.
+type GetUsersParamsStatus string
+
+// PostUsersUnlockJSONBody defines parameters for PostUsersUnlock.
+type PostUsersUnlockJSONBody struct {
+	Email openapi_types.Email `json:"email"`
+}
+
+// PutUsersUserIdRoleJSONBody defines parameters for PutUsersUserIdRole.
+type PutUsersUserIdRoleJSONBody struct {
+	Role string `json:"role"`
+}
+
 // PostDummyLoginJSONRequestBody defines body for PostDummyLogin for application/json ContentType.
 type PostDummyLoginJSONRequestBody PostDummyLoginJSONBody
 
 // PostLoginJSONRequestBody defines body for PostLogin for application/json ContentType.
 type PostLoginJSONRequestBody PostLoginJSONBody
 
+// PostLogoutJSONRequestBody defines body for PostLogout for application/json ContentType.
+type PostLogoutJSONRequestBody = RefreshTokenRequest
+
+// PostPasswordForgotJSONRequestBody defines body for PostPasswordForgot for application/json ContentType.
+type PostPasswordForgotJSONRequestBody PostPasswordForgotJSONBody
+
+// PostPasswordResetJSONRequestBody defines body for PostPasswordReset for application/json ContentType.
+type PostPasswordResetJSONRequestBody PostPasswordResetJSONBody
+
 // PostProductsJSONRequestBody defines body for PostProducts for application/json ContentType.
 type PostProductsJSONRequestBody PostProductsJSONBody
 
+// PostProductsBatchJSONRequestBody defines body for PostProductsBatch for application/json ContentType.
+type PostProductsBatchJSONRequestBody PostProductsBatchJSONBody
+
 // PostPvzJSONRequestBody defines body for PostPvz for application/json ContentType.
 type PostPvzJSONRequestBody = PVZ
 
+// PostPvzPvzIdEmployeesJSONRequestBody defines body for PostPvzPvzIdEmployees for application/json ContentType.
+type PostPvzPvzIdEmployeesJSONRequestBody PostPvzPvzIdEmployeesJSONBody
+
 // PostReceptionsJSONRequestBody defines body for PostReceptions for application/json ContentType.
 type PostReceptionsJSONRequestBody PostReceptionsJSONBody
 
 // PostRegisterJSONRequestBody defines body for PostRegister for application/json ContentType.
 type PostRegisterJSONRequestBody PostRegisterJSONBody
+
+// PutRolesRolePermissionsJSONRequestBody defines body for PutRolesRolePermissions for application/json ContentType.
+type PutRolesRolePermissionsJSONRequestBody PutRolesRolePermissionsJSONBody
+
+// PostTokenJSONRequestBody defines body for PostToken for application/json ContentType.
+type PostTokenJSONRequestBody PostTokenJSONBody
+
+// PostTokenRefreshJSONRequestBody defines body for PostTokenRefresh for application/json ContentType.
+type PostTokenRefreshJSONRequestBody = RefreshTokenRequest
+
+// PostUsersUnlockJSONRequestBody defines body for PostUsersUnlock for application/json ContentType.
+type PostUsersUnlockJSONRequestBody PostUsersUnlockJSONBody
+
+// PutUsersUserIdRoleJSONRequestBody defines body for PutUsersUserIdRole for application/json ContentType.
+type PutUsersUserIdRoleJSONRequestBody PutUsersUserIdRoleJSONBody
+
+// Base64 encoded, gzipped, json marshaled Swagger object
+var swaggerSpec = []string{
+
+	"H4sIAAAAAAAC/+xd/3MTR5b/V6bm9odN1diGJLtVIbU/QIBbEirx2ZBcJce5BmkwCpJGOzMCjMtV/rIE",
+	"ciZwxeUuW6lLCJsf7n6UjQVCtsS/0P0fbb33ume6Z3qkkWxkE1y1G6zRaKb79Xuf97VfL9slv9bw6149",
+	"Cu1Ty3ZYuu7VXPzzdLNcic7Vo2AJPjUCv+EFUcXD79xSVPHr8JdXb9bsU1/ZjZt3pkuB50ae7eAHNwwr",
+	"i3XxoVmPPwZeyWvAr6f9hqdfKFX90NOuuM3IX5CXG4FfbpaiabdcVj5ddaPS9QX9WtmrejiQZugF04G3",
+	"WAkjL5Cfq/5ipS4/NOtVv3QjvtWvegul6259Mf512YPJ3nST56U/X/ODkrfQcMPwlh+UFwIv9CL5nX51",
+	"IfD+0vTCnG9h5n7Vm242yvD0K44dLTU8+5QdRkGlvmivOEB3P7hQBsJf84OaG9mn7GazUrbz7p3zqx7c",
+	"nfmW1qp8OtKeBS+eiio1z/TAshe5lSqtf7lcgQVyq7MKX0RB04PbwlJQaRCD2OwJ67Mdvsr6bIv1WJ+v",
+	"8XXWsdgOa7OX+GGbdfgjx2I91mKv+CrrsD3W5qtwoc+2+SZ7Sd9tsT5chVtabNvCR+6yTjJS/+rXXimC",
+	"kXpB4AfGaVeK0c5vRiW/5qkMHjZLJS8Mbce+5laqzcC8QIIDCy5R4+adgnfGIlH4fmQ0ujv7reCK1FL9",
+	"AhTlD5yYyKxlsS7r83UgPevDSnTYC1gf1oP/803Tu4G1Cw1TjLMSeGWgMN4ikCVZApVVrxjW+pxcax2h",
+	"al4Yuosm3k+9Vd5oevbHX3ySfbJbXVT5Ym7+3T/80Xbsc+Wz86eNPFEKbhpXwSyYNyrmNbsRLemvPW07",
+	"9mefzBpfWTc+ohmaX3l7OJXg7TQ2eoyDZMih2bwXZcl2w1vCfyuRV8M/fhd41+xT9j/NJBpoRqifGSD8",
+	"SvxwNwjcpeyQ4IGmEVwEfL/ol274TcM4QHq98kd+sx4p067UI2/RC5DQ3lJWNs7V3EoVuH+XdawLsxZr",
+	"Iai1+ZoDWLbLH+mS8oz1LfYCUWuX9VmXdfDyNmuxnsW2+V1ARZPwVN0wOo9DHAWaQYd55cv1qFIt/qOw",
+	"5Dc0iPNgkrZjVxoGpkpRn35M1HI0oqbmoA/OtF6zn3+ZXaZSRed39r+gO1gXSGg7NnsKlGRdvj7FnrA2",
+	"XweVwbb4Bl9lz+D7H1kL6d/jD4wSUimKo2A7BC6wwVnQygWpm6IWziZn7qfRMqp5dQOzktU0Gi/I35xZ",
+	"KjRDWnT1TskG+1BX46oAekP8eycei0KIHDKeTawTnYbXK2HkB0uFsWdOqto/0w8vRF4tC0aODeZrfG/h",
+	"hwo6DrsfZMJAHNuJp5NDhi8q0fX4ZQZqFH23YnKEo5MORjFLplA4FMhpXsrrjFPzglolDAWp9TlpUG3Q",
+	"b3VXmHK33VoDLB945anYWxnMkvhj3ao1jo9mmx3cVTco+WWTufX/fB3MXX4XNAfbsdgrNI832S7oiw8t",
+	"vmGBPgHEI+UhDLMO6/FHfJ1v8rsW22Jt9sJCc+wZ6zsW6CC+xjfwv+tsm28AOhrNeTfyLlVqXnFkqbwe",
+	"W5UuJFDPv2O7rA3YjtPusQ7rEuj30W14znbkxy2+wbaNCJ9aRfxWH9qAVTwDPiXI/ZwXNquGRY0djLSz",
+	"gwt6D5YIbGewB7qkiKSVgKvS4ut8g6+xlhV48GrPSJdKvezdNr2E9dkL1uHfgOOkskjLYttkdQCbgLps",
+	"247BwmkkvDoQB8RtYCpEbtQM1UUSRjnSVExh6CLQhOKnDVuAPOLLVxuttxinCgFWznob0H50B0xQxTDK",
+	"FFnURzsmwspZDSDYRb/kSvxLsct/gdBY4HN30TDdAT7ZQphpaYCCHxUIatlOmvLCKMu8gYzeHYs9YY/Z",
+	"D3a+YzwCz43hHM/HbJoa4dNE6OSU22wPYNaxKvWFRuAvBl4YSgNfRpyGGCtirE5stiB9suMxrZtmOaSM",
+	"vmbkfwQjKOchjBh8i4S9y1f5Ji1fi20jHuzBbBGJ2mg0dxxaWvwO9Mo9+BPhAjRHG9AJoinotvTk5y14",
+	"HqAWEiUBGtXtv+r7Vc9FswapNlpE6XWpoOK8k0U2hR9sMSmjBxE2azU3WBrG0fFCz4v702wUEyHho7AA",
+	"36jWadbKI9Yc4OUGY1iuebhlO/oLB457PiFbirX/hhz2LCOgfI31+V2MSGHUCeJO7KW4zSL2VeWgg0HB",
+	"lInYJB9u3iv59bIJIh6DO8/2wJHv83Whu+UT4eqOdOm1q9pYQaBA67Iu32A9QFp+16iBr1WCMJovufWR",
+	"nHx39N8MZwRxR3hm6ZKww8zBXdNvUyT8kSKyhDoY2e2nLFgLzaHniC7P6NsOe4WaJsUxZqyVEYbUoJ3M",
+	"+g5kQc07yZOdkY2IgVbDgUpZ3vSuBV54/ZJ/A/xSyjNkJhcoNw2P+ml3G98pAsnj+WOZLxqxk6dTP8dl",
+	"yPEnDR6b/mjTTPIoIr6ZdSuBKQ0GKYH8n45GbfVpznDaXw69INc4Hi2vIzNa4mcpsf4Bgp0Ivn3wSdEH",
+	"AxQE2d1WA5twB3tp8Q1EgHXxkRwS1uFriMvFxjRCVKqoSSBybXNe6EVzMdkNeuAumbLSj2rzb0HrkALg",
+	"a5T94JtgUBGq7fJHRntIZlmSsINXa1T9JW+4aSkni4+4YgLH0Cs1g0q0NA8AIiINnht4welmdD35dF6S",
+	"5eMvLtkOpXdxmPhtMo7rUdSwV1bQ67zmmyxoDLDCcq/FruxGnCfaRb2MChE9AHQ3VNWIfKGpAnh3JUKy",
+	"XHVLN7x62Qq94GalBNS56QUU6rFPTp+YPiHDbW6jYp+y38NLsKLRdZz4zPQtr1qdulH3b9Vnvr51I5z+",
+	"OiToWfQi41wwkMBX2XPWAV2fcLMwLbpslz/k94TXhH+jDQ3pyG3Whl8LO6SNKTELrWX0gsAQJ2ubLPRX",
+	"+DLi/5b6oz1pU3clQ6Hh3UHV2YbXss60RQa//pgOvo5/Cw+R9sYqSlyf7Vl/hoQUXKab+6wLS7EBj7WR",
+	"jKQpwT62/9mLvvCq1U+Ach/fuhF+HPqEPWHDr4fEVu+eOAH/lPx6JGLUbqNRrZC/OSMpTYqsQF4HkkPI",
+	"aKlF+SlJ8UqKt2Epti3+V7gsfJq2Bc8gEZCWJHhFG2xL2B6CwPGySXaloAgsH19FY43wVlkD+BafPONC",
+	"7YPCQBmqYXEE8mDg1rzIC0L71FfGSM0uf8BeCMaHdaUEKzASLOkuiA3b5vdZByarZcUxelOBB/2l6QXg",
+	"WJLijKsAHIXqQ+Psy3mPIv2YPOm4omNyFR15yxI7gvtdXz22tO/HJUn5LL8UKZBYcTIy8hNr8XusBWlS",
+	"4P8OGgstFBmMoW6TX0YKJlM3kiMh1wK/Zp7vwJzdssmj6bE2/+bghhb5BzGwn1hflMnAC4Vv3OHf8M2c",
+	"1zbcRX3Zyt41F8OrJx27VqlXarCKJx1DsHK5kJ9HQXsyjtbJ1wMi6cPLhbRqpVaJzON774Rj19zbYoAn",
+	"TgwZ7pV9qq9CLp9SG5f1RlYckwUdq+7nmKTuIctDyI5vAG8Js0HWPLVB46PFxTfBUoDXvH+AipiqZsx6",
+	"uC10pLCFlHwCjeK9CYzie6oUAxMzY4lr9i+qXdXy/erKyhXNNvifhN4atVO1TKiAf8/Xhcrusn5sOOzF",
+	"9mIrLvDYfocshXKzVlvCihP0xfzQZHAqsyEvSnsLxbFOz84unPv08z+VvZte1W9APYCDAdo+pYzw/h4l",
+	"/foYg9pgz6XZDRf3MDR7H77g62RMIj49I5MSXoI/amNq8FHGFpz1w+hsMpu4huyMX14aacVTEQfpBcVF",
+	"JrEPFHr1ih8sKFdqfhmG5Ac2lV5AEGqh5tbdRVT2aJbhl265VqkPzzjl+U/abVC2uPIajV5y4U1c/ita",
+	"6W1+H9iTP4rj97BWwPWU4jsqsp8yuZGF0dVH4WlbWISzJry8OL4nbesWyUs1LSpZJjxY/hulvkUYb8ND",
+	"NfIR8S/edB6TULOO679Oq5lgVMZV+lCEPyCXtGaJWJXuSu2RlwORozZft2Y/m79kzUQ0TmDokxNn6LYS",
+	"msKPIqyFHyan236VY6D1iMNj/MGg2FpLJicRyttsK4FycPa3SEr1qBRM6d0PJjClp2gP3mddWvc91hMA",
+	"gNbzBmZC7kntBX7vK8ygYGxCFEOirWjx7ygJxPqWl1tyyVppMPpPE0/Tm7L+96MYiWSB6CAognvGx6LB",
+	"Mf9swL4QbLyfE59b42s0b2ApZHt+X2Lv0TEfJyD3cxk8QkZMeWjIDYlBmXDTY75JPAkciaD4AuN+AtPW",
+	"WJvfNWMepf9oIViH+EzqiJlrfrDoRwPMxJ8l/IpQIC0jug0iI2lJ6XDw26x9l9QYg9SgCE1b7O8JGUDS",
+	"eigp5MiKDRa6+0rQYp20wDPHEmdlL4ayoEQc8vke0cBf4ZieJyZBHN80GpyzgjjniTYTV/pGvT6eMn/X",
+	"sKL/zddwIXKQ6IGFpXrfsrZKd4weoz4Az0PW5KwrgfZ+rK1lAL7LN46QjB+yzlGGI2VSqEcKyMcKMs5k",
+	"5GgdXSEN0UE/qHKRLCCptZRhJF6bN64UcFAsMR83nuAjdlk79USEKg2RclWirjNa/CFZFo6FxWKpPQxd",
+	"erhU2/BMi7UV8vE1lP89Mv1yvc1ZNSV3YLI/wIp37KhYKjYSSdh92vfvGxdLLvODVATiaAhwYmaOpTt/",
+	"FVDVSzilpziEuvS90lxEviFyaYrwCEnQ6wLykjKzym2TiAIm7ysUBXyqZ+QQuN/IiNpjgSu66uIPMSPZ",
+	"VncP/h5WFa2BfnK5b0FY5hSFdUQYTS26yTfH46Kdg8KKcYvzIS/L+rHTEU9PluY/0O4nJxllZs+CoJ3F",
+	"7+uPJ63QI6OAvQDZ2Ja2FAjeLlSSZYrNUFDlpgDrzOm5jz47e27h8qcX/uXyuU/Pzc//abHqX3Wr4Nuj",
+	"ySAs17hIt+FGkRfAdP/9qxNTH5ye+tKdujO9MHVl+aTzx/dXfre/ysoJlvXToMbD6INzR+I6MIP0/V2W",
+	"PmiVtUcH9Y8GDiV2F25jWcXIATKK4hFhrQN7RdTDS5KjcQ4fTIaSqbIRqnQyyadS9iJQoMW6VLlhAgGK",
+	"6mtoMiI6f6/zV+KHKZtEqMIE8BtwWxs43zBnQbJrQkk+JH0KxWcwhT/AYhUaRKkoT1WcSwM0dlixfkgk",
+	"EokLvsERv5y21A0xZLQLg6Uv616A3JBHk/Wmfbbn0E2JPOyJoh2B6fyR0Gd7NAyxltnV4Zt5S8l68law",
+	"qjdxpg/RR0YWQBbqIDazF5hLbvFvE9MbCa5vF4KHKptC8vUj7mc5MCWZ3VKTq0RzlUABGDfVONbc2xfo",
+	"pX8QiV/x8WS21raoZsrZcpq3xeZQdIi6/8kEQE8SDuqjx0e1U2h4HyuU36hCGVEPxDyCD2tnDI+0YhCR",
+	"EsAcrNLvsfbE1MXSlACRmWX470p+7eZjHS2JbhToSIZF1bmKvR5HcvL0LvWfkTs3cfziEUisHbnB1lRB",
+	"GaPu0hmaxEeAhpnaQCx7gZrVpOqlRDfq8KIWwaShayLFLekthUV82ye5pD88OFLrRrOL3joqrjeM4v0J",
+	"jOKJurWziCma2C+dOJwHjugafzAyFPUx8tFNiyVFgFIv5xspeFiOuyqtECJgLagBGygmviu2YLfxo0W3",
+	"L8DuqQXxIItSkgBUBCC7/CEoUK1InbIe6VodWdbdFuEsfTtmL70XrZUBjLM4HIkZs3JmhfCiodydDxrD",
+	"7J8rheKUiccqiXXsrR68cTEJwU9WkoYFpXAvaQvD5Cwcfd+y5o9Kp0qXm9EQ5teERUnyuxhikvsiemo9",
+	"knyv2Lih7PM0OPHjGzc37yhWTNZouHknK/J5pdH8gbBKYJZUu5gpR84prA0jN4jOUm37gZVD3xt7OF69",
+	"fFCDeYtKoE+qJdDvnRg+Wrda9W+dqzWipc/datPLac34I1TGIi+vSs6GpmpeNIUr+UwYwxhWwZ1IG6K8",
+	"D4WV7OVY4CQ4tnAvy7Y0zWUVM/gNqXXawNZpyN5QpyLvbcuSMQq8GF/0DMkvM0z/OvWpdzua+qgZhH6Q",
+	"bJmiEmuyBWAfVzLbJN3aQhZa56sWcAdAwjNRHqEVy/5bPWfRSvhO+/Bt9kznp5EzUjIrcN1zywhIy7ZG",
+	"WIONoDKQ9JREIgj2bqXlMg2m25YoFsGWn6kVymtlRDKV8c1eZsTsnYGrsnKkkq3q1MewrVN1uHLrXzc2",
+	"NtDc/yu8jD8gKsnwqcje74jNdZkQtpWBgjZ7ieG2/CzdzTv7iD0O74o22YDc518aV1WSFcMRO8dxt30l",
+	"lJ8mVCSMJ+qOvhujcfPOzDJGdFeGGF+zIuxbwOkSd47vcI1iu8gNwKiC9F3UfdY9JMtGH0RmiIdh5Fx5",
+	"jcX0SjfJQaKf8v6P2jatycSUgBQGt3KfKkzRWnHombTVQHetL0pZVf5sS+KIX4kadE2q0tgxgxuUKWSk",
+	"tXAZqPQQUrBx10U3jObUvi0TAJnXKRBqd5phrr3uxmf3lVC/pg5VVR7rzDcvV/XYUsQ+3Slr31mrH7RG",
+	"Xm2ToT/khZmWU+hmtIg9k/KnjMwb4sTFRJ4iuiDzs0lHwMOT+OM47nHVUUcKZ1oW4s49SriUPxpRRjPB",
+	"VkOiNJtuzo/BpnV8KgJ78cL5zxxrH3HYWMTlTuOwiHNwLr75zVDgReNESr/5kWNEGUmAXfNvXyL1IIze",
+	"pxnW7TgmAoudqgng5BVVQ09z6snzjhqfMewx68blaFip3FFstuRqqglnqjxP/DDJo+IVraDPBKdgbj9P",
+	"gEmJBJpnHqvraYv9oNzQoQHQ47JZWh0in8nErWnfC7gYYjsGf2hJgJAomu1QkG8GHApeHER937iHNYjf",
+	"HXrJnA5pRgjLMGKG247W5pvc/YLU1uMRSWGSJNEb2O2ZFeOeZrG8dXhdhLIqnvPN8VwXTTm2TUvRGhnO",
+	"cw2ZmWWSwlRhjLHkJI1Tl+UhKxOKgRqeG5/z8rqrWQwQILzINAS8PXLxdDR3J66fVBzo4sLxM18vLBzY",
+	"f3s04dAPq8kz7ZX0qJnrX1cbPtHQ3dQ2r1i7+TEa52FKT9hhSXYl07M8L7FNfYTPT6SR3oEM9dIBNdYr",
+	"RtUhneDzhgrLO2Gi7mekl46bFR7lJNZoh3KNt0lZyZIchwzHzG8Pq9HQ06sDajXM5RgjJsoH1W5oGvKA",
+	"+jHsZ1/WYTuXo2S+NO3VOhaXNzi1JUuSUWUI51BPbKb1aGu/JS+yS8awdNbYBcmJiTyzrLSFXilmMM9p",
+	"jaSHe4x64+kjntbWj6ssIOhaEuO4P++hRXYMu2+UCA5r7VtPp2Uxu/JjVKnF7esHppfn5F1HqxNq7unx",
+	"P+t9iqn9cF8mI1txIXdLpjF3gHxoaguzmwhlO9lewVecURuyOvvp/Xtw9gMe0pOzGy+nIdzRrCj9YDI7",
+	"FHNoou5VFC3GSEOb+uelW1L9ojVJLNYcFJhncCgJb5iI/wZ8PKrrJkSvfXSysyMB8S8SOOB//G4S+huh",
+	"pRQu4cwy/LOS7iDWaJpyo39L9YNvm5pisp6MhWK7lu9Yl71A/fAK06CrxJTYXhN3uIiDgNM93KfkvnpF",
+	"2bx3Qjs9MJttbBLbwX/0XmcF7DHgopG3mh+I/3cgh7oNPr9tss22SSJz7BHi0kT1QdacbPtdmVWaJKpD",
+	"h8FtgY+iD0QsNG+bufiLbLtoMhQnqeSo+7XMdcQ94/hGwjd4wEEOwI2RlyRYa9Gst+I9cPT05K2FwTXu",
+	"o5nXkjR11haV/j5nbUeePcw3REt4PBFAbLbPFAtvw8jpRDQwA0xHdul95jEfnu3NbKzcSI5aPD5oYKSD",
+	"BvA0zP0eaHHc//+4///h9P+HF0nMkMcMvpJVa4bTABHsZgSo5IOeqeu8sd36KR3nsvhFLxdBRgo1UCdm",
+	"0RGU/weVACmnYWsvjTvWx4/MNixlbfPjMDuhdGRIDOKe4VCXzMtgMmu41zs+kOAh7p7OgV9BtKN2xMKk",
+	"APOnzEJnOPD45Ib87tOOcl5qukdjh6+lASDbrvpn3TqX5ewmLKCd0lnTQrYrglqmgSGDy3jDsKYf/4cd",
+	"lsAcFAC4BamHRHO10qd65bbaEKZDvouXd2YkuYoj/25AkQsesEmHcsdnTY9yHOZ+6gF+A8cNUhRx5Oy9",
+	"SfslIaHjZMVYVftFCCy7B4oMP9hEOZ4VgIbuWcGVcAaOsPWb0XA8uShvnAQf4sls4o2j82P6GIm4puQN",
+	"44Hv1Zhg0vU+e0pGJ31KhjilI250s6U3oxER2zEySsQ04uTjgVklZJrLdOObfNqOsciXVCS/F1cWDHE9",
+	"iLLxmSXkQ90/3h+5T4jEeFYhoQCchLxOFw9m7aF5My73q5XwAyFzhLr311KffuLQsotvYcvTYns9xqoT",
+	"MDwXPIRKeVRlLzl3RtrGAwKrT9WTldLxIgp8PPyQvHJslJwEuFIHQfUovArLJE/sob26HdmoPvfcJkWK",
+	"Ticn5/92pWlQwC4nWHcsabmSdkiJluSoLdo8ilbplggpUBwNexQmEWL4qC72GCCRxNta+jY9GaJMXkel",
+	"fy+Uk+LHRZHExx54AgedcKmxTBwudEY8PE4JAipHx8mwpgSgTPaGmoW3KarL1+PzMdSW07BYRZDorOe+",
+	"7Vg0IHlwjEdvPR59z9oTAqBrflDyFmTKcmHY8ZWDoQg7DwB19hLEiDNcB4BTw3DlPEwmfVLlbxdfnhoz",
+	"iXoF51u4V/YYT7L2jTgDC0IsHUkQmY00J6THRhRZcmyu23tcwM5QT9NapZMekrYfhY0QpbTPVJ2nwMYc",
+	"JVEmBxQHEciTZB4ctttHYfUEAOwX49m6xxZQjFgiS7qaVwzHN98eDHsaF8YlBZt5xkMx7FpZ+ccAK/bG",
+	"VVOzAAA=",
+}
+
+// GetSwagger returns the content of the embedded swagger specification file
+// or error if failed to decode
+func decodeSpec() ([]byte, error) {
+	zipped, err := base64.StdEncoding.DecodeString(strings.Join(swaggerSpec, ""))
+	if err != nil {
+		return nil, fmt.Errorf("error base64 decoding spec: %w", err)
+	}
+	zr, err := gzip.NewReader(bytes.NewReader(zipped))
+	if err != nil {
+		return nil, fmt.Errorf("error decompressing spec: %w", err)
+	}
+	var buf bytes.Buffer
+	_, err = buf.ReadFrom(zr)
+	if err != nil {
+		return nil, fmt.Errorf("error decompressing spec: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+var rawSpec = decodeSpecCached()
+
+// a naive cached of a decoded swagger spec
+func decodeSpecCached() func() ([]byte, error) {
+	data, err := decodeSpec()
+	return func() ([]byte, error) {
+		return data, err
+	}
+}
+
+// Constructs a synthetic filesystem for resolving external references when loading openapi specifications.
+func PathToRawSpec(pathToFile string) map[string]func() ([]byte, error) {
+	res := make(map[string]func() ([]byte, error))
+	if len(pathToFile) > 0 {
+		res[pathToFile] = rawSpec
+	}
+
+	return res
+}
+
+// GetSwagger returns the Swagger specification corresponding to the generated code
+// in this file. The external references of Swagger specification are resolved.
+// The logic of resolving external references is tightly connected to "import-mapping" feature.
+// Externally referenced files must be embedded in the corresponding golang packages.
+// Urls can be supported but this task was out of the scope.
+func GetSwagger() (swagger *openapi3.T, err error) {
+	resolvePath := PathToRawSpec("")
+
+	loader := openapi3.NewLoader()
+	loader.IsExternalRefsAllowed = true
+	loader.ReadFromURIFunc = func(loader *openapi3.Loader, url *url.URL) ([]byte, error) {
+		pathToFile := url.String()
+		pathToFile = path.Clean(pathToFile)
+		getSpec, ok := resolvePath[pathToFile]
+		if !ok {
+			err1 := fmt.Errorf("path not found: %s", pathToFile)
+			return nil, err1
+		}
+		return getSpec()
+	}
+	var specData []byte
+	specData, err = rawSpec()
+	if err != nil {
+		return
+	}
+	swagger, err = loader.LoadFromData(specData)
+	if err != nil {
+		return
+	}
+	return
+}
@@ -0,0 +1,92 @@
+package grpcserver
+
+import (
+	"context"
+	"log"
+	"strings"
+
+	"github.com/forzeyy/avito-internship-spring-service/internal/apperrors"
+	"github.com/forzeyy/avito-internship-spring-service/internal/models"
+	"github.com/forzeyy/avito-internship-spring-service/internal/pb/pvz_v1"
+	"github.com/forzeyy/avito-internship-spring-service/internal/reqctx"
+	"github.com/forzeyy/avito-internship-spring-service/internal/utils"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+type AccessChecker interface {
+	CheckAccess(ctx context.Context, userID, role string) error
+}
+
+type PermissionChecker interface {
+	HasPermission(ctx context.Context, role, permission string) (bool, error)
+}
+
+// methodPermissions - права, которые нужны для вызова методов; метод без записи вызвать нельзя
+var methodPermissions = map[string]string{
+	pvz_v1.PVZService_GetPVZList_FullMethodName: models.PermissionPVZRead,
+}
+
+// AuthInterceptor проверяет access токен так же, как HTTP API: подпись ключом из набора, статус пользователя
+// и право роли на вызываемый метод. Токен передается в метаданных authorization: "Bearer <token>"
+func AuthInterceptor(keys *utils.KeySet, access AccessChecker, permissions PermissionChecker) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		permission, ok := methodPermissions[info.FullMethod]
+		if !ok {
+			return nil, status.Error(codes.PermissionDenied, "доступ запрещен")
+		}
+
+		userID, role, err := parseBearer(ctx, keys)
+		if err != nil {
+			log.Printf("Ошибка проверки JWT в gRPC: %v", err)
+			return nil, status.Error(codes.Unauthenticated, "invalid or expired jwt")
+		}
+
+		if err := access.CheckAccess(ctx, userID, role); err != nil {
+			if apperrors.KindOf(err) == apperrors.KindInternal {
+				return nil, status.Error(codes.Internal, err.Error())
+			}
+			return nil, status.Error(codes.Unauthenticated, err.Error())
+		}
+
+		allowed, err := permissions.HasPermission(ctx, role, permission)
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		if !allowed {
+			return nil, status.Error(codes.PermissionDenied, "доступ запрещен")
+		}
+
+		if id, err := uuid.Parse(userID); err == nil {
+			ctx = reqctx.WithUser(ctx, reqctx.User{ID: id, Role: role})
+		}
+		return handler(ctx, req)
+	}
+}
+
+func parseBearer(ctx context.Context, keys *utils.KeySet) (userID, role string, err error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	values := md.Get("authorization")
+	if len(values) == 0 {
+		return "", "", status.Error(codes.Unauthenticated, "нет токена")
+	}
+	raw, ok := strings.CutPrefix(values[0], "Bearer ")
+	if !ok {
+		return "", "", status.Error(codes.Unauthenticated, "нет токена")
+	}
+
+	claims := jwt.MapClaims{}
+	if _, err := jwt.ParseWithClaims(raw, claims, keys.Keyfunc); err != nil {
+		return "", "", err
+	}
+	userID, _ = claims["sub"].(string)
+	role, _ = claims["role"].(string)
+	if userID == "" || role == "" {
+		return "", "", status.Error(codes.Unauthenticated, "в токене нет пользователя или роли")
+	}
+	return userID, role, nil
+}
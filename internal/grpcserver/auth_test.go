@@ -0,0 +1,100 @@
+package grpcserver_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/forzeyy/avito-internship-spring-service/internal/apperrors"
+	"github.com/forzeyy/avito-internship-spring-service/internal/grpcserver"
+	"github.com/forzeyy/avito-internship-spring-service/internal/pb/pvz_v1"
+	"github.com/forzeyy/avito-internship-spring-service/internal/reqctx"
+	"github.com/forzeyy/avito-internship-spring-service/internal/utils"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+type accessCheckerFunc func(ctx context.Context, userID, role string) error
+
+func (f accessCheckerFunc) CheckAccess(ctx context.Context, userID, role string) error {
+	return f(ctx, userID, role)
+}
+
+type permissionCheckerFunc func(ctx context.Context, role, permission string) (bool, error)
+
+func (f permissionCheckerFunc) HasPermission(ctx context.Context, role, permission string) (bool, error) {
+	return f(ctx, role, permission)
+}
+
+func TestAuthInterceptor(t *testing.T) {
+	keys := utils.NewHMACKeySet("secrettt")
+	otherKeys := utils.NewHMACKeySet("another-secret")
+	userID := uuid.New()
+	revoked := uuid.New()
+
+	token := func(keys *utils.KeySet, id uuid.UUID, role string) string {
+		signed, err := utils.GenerateAccessToken(keys, id.String(), "user@mail.ru", role)
+		assert.NoError(t, err)
+		return "Bearer " + signed
+	}
+
+	access := accessCheckerFunc(func(ctx context.Context, id, role string) error {
+		if id == revoked.String() {
+			return apperrors.Unauthorized("доступ отозван, войдите заново")
+		}
+		return nil
+	})
+	permissions := permissionCheckerFunc(func(ctx context.Context, role, permission string) (bool, error) {
+		if role == "broken" {
+			return false, errors.New("база недоступна")
+		}
+		return role == "moderator" && permission == "pvz:read", nil
+	})
+	interceptor := grpcserver.AuthInterceptor(keys, access, permissions)
+
+	tests := []struct {
+		name          string
+		authorization string
+		method        string
+		code          codes.Code
+	}{
+		{name: "право выдано", authorization: token(keys, userID, "moderator"), code: codes.OK},
+		{name: "нет токена", code: codes.Unauthenticated},
+		{name: "не bearer", authorization: "Basic abc", code: codes.Unauthenticated},
+		{name: "чужой ключ", authorization: token(otherKeys, userID, "moderator"), code: codes.Unauthenticated},
+		{name: "доступ отозван", authorization: token(keys, revoked, "moderator"), code: codes.Unauthenticated},
+		{name: "права нет", authorization: token(keys, userID, "employee"), code: codes.PermissionDenied},
+		{name: "ошибка проверки права", authorization: token(keys, userID, "broken"), code: codes.Internal},
+		{name: "неизвестный метод", authorization: token(keys, userID, "moderator"), method: "/pvz.v1.PVZService/Unknown", code: codes.PermissionDenied},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.authorization != "" {
+				ctx = metadata.NewIncomingContext(ctx, metadata.Pairs("authorization", tt.authorization))
+			}
+			method := tt.method
+			if method == "" {
+				method = pvz_v1.PVZService_GetPVZList_FullMethodName
+			}
+
+			var caller reqctx.User
+			handler := func(ctx context.Context, req any) (any, error) {
+				caller, _ = reqctx.UserFromContext(ctx)
+				return "ok", nil
+			}
+			_, err := interceptor(ctx, &pvz_v1.GetPVZListRequest{}, &grpc.UnaryServerInfo{FullMethod: method}, handler)
+
+			assert.Equal(t, tt.code, status.Code(err))
+			if tt.code == codes.OK {
+				assert.Equal(t, userID, caller.ID)
+				assert.Equal(t, "moderator", caller.Role)
+			}
+		})
+	}
+}
@@ -0,0 +1,57 @@
+package grpcserver
+
+import (
+	"context"
+
+	"github.com/forzeyy/avito-internship-spring-service/internal/apperrors"
+	"github.com/forzeyy/avito-internship-spring-service/internal/pb/pvz_v1"
+	"github.com/forzeyy/avito-internship-spring-service/internal/services"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	defaultPageSize = 30
+	maxPageSize     = 100
+)
+
+type PVZServer struct {
+	pvz_v1.UnimplementedPVZServiceServer
+	pvzSvc services.PVZService
+}
+
+func NewPVZServer(pvzSvc services.PVZService) *PVZServer {
+	return &PVZServer{pvzSvc: pvzSvc}
+}
+
+func (ps *PVZServer) GetPVZList(ctx context.Context, req *pvz_v1.GetPVZListRequest) (*pvz_v1.GetPVZListResponse, error) {
+	pageSize := int(req.GetPageSize())
+	switch {
+	case pageSize == 0:
+		pageSize = defaultPageSize
+	case pageSize < 0 || pageSize > maxPageSize:
+		return nil, status.Errorf(codes.InvalidArgument, "page_size должен быть от 1 до %d", maxPageSize)
+	}
+
+	pvzs, nextPageToken, err := ps.pvzSvc.ListPVZs(ctx, req.GetPageToken(), pageSize)
+	if err != nil {
+		if apperrors.KindOf(err) == apperrors.KindValidation {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	resp := &pvz_v1.GetPVZListResponse{
+		Pvzs:          make([]*pvz_v1.PVZ, 0, len(pvzs)),
+		NextPageToken: nextPageToken,
+	}
+	for _, pvz := range pvzs {
+		resp.Pvzs = append(resp.Pvzs, &pvz_v1.PVZ{
+			Id:               pvz.ID.String(),
+			RegistrationDate: timestamppb.New(pvz.RegDate),
+			City:             pvz.City,
+		})
+	}
+	return resp, nil
+}
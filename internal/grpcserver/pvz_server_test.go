@@ -0,0 +1,81 @@
+package grpcserver_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/forzeyy/avito-internship-spring-service/internal/apperrors"
+	"github.com/forzeyy/avito-internship-spring-service/internal/grpcserver"
+	"github.com/forzeyy/avito-internship-spring-service/internal/mocks"
+	"github.com/forzeyy/avito-internship-spring-service/internal/models"
+	"github.com/forzeyy/avito-internship-spring-service/internal/pb/pvz_v1"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestGetPVZList_Success(t *testing.T) {
+	mockSvc := new(mocks.PVZService)
+	server := grpcserver.NewPVZServer(mockSvc)
+
+	pvzID := uuid.New()
+	regDate := time.Now().UTC()
+
+	mockSvc.On("ListPVZs", mock.Anything, "", 30).Return([]models.PVZ{
+		{ID: pvzID, City: "Москва", RegDate: regDate},
+	}, "next", nil)
+
+	resp, err := server.GetPVZList(context.Background(), &pvz_v1.GetPVZListRequest{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "next", resp.NextPageToken)
+	assert.Len(t, resp.Pvzs, 1)
+	assert.Equal(t, pvzID.String(), resp.Pvzs[0].Id)
+	assert.Equal(t, "Москва", resp.Pvzs[0].City)
+	assert.True(t, regDate.Equal(resp.Pvzs[0].RegistrationDate.AsTime()))
+	mockSvc.AssertExpectations(t)
+}
+
+func TestGetPVZList_ServiceError(t *testing.T) {
+	mockSvc := new(mocks.PVZService)
+	server := grpcserver.NewPVZServer(mockSvc)
+
+	mockSvc.On("ListPVZs", mock.Anything, "", 30).Return(nil, "", errors.New("ошибка получения"))
+
+	resp, err := server.GetPVZList(context.Background(), &pvz_v1.GetPVZListRequest{})
+
+	assert.Nil(t, resp)
+	assert.Equal(t, codes.Internal, status.Code(err))
+}
+
+func TestGetPVZList_Page(t *testing.T) {
+	mockSvc := new(mocks.PVZService)
+	server := grpcserver.NewPVZServer(mockSvc)
+
+	mockSvc.On("ListPVZs", mock.Anything, "cursor", 5).Return([]models.PVZ{}, "", nil)
+
+	resp, err := server.GetPVZList(context.Background(), &pvz_v1.GetPVZListRequest{PageSize: 5, PageToken: "cursor"})
+
+	assert.NoError(t, err)
+	assert.Empty(t, resp.Pvzs)
+	assert.Empty(t, resp.NextPageToken)
+	mockSvc.AssertExpectations(t)
+}
+
+func TestGetPVZList_InvalidPage(t *testing.T) {
+	mockSvc := new(mocks.PVZService)
+	server := grpcserver.NewPVZServer(mockSvc)
+
+	mockSvc.On("ListPVZs", mock.Anything, "broken", 30).Return(nil, "", apperrors.Validation("неверный курсор"))
+
+	_, err := server.GetPVZList(context.Background(), &pvz_v1.GetPVZListRequest{PageSize: 101})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = server.GetPVZList(context.Background(), &pvz_v1.GetPVZListRequest{PageToken: "broken"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	mockSvc.AssertNotCalled(t, "ListPVZs", mock.Anything, mock.Anything, 101)
+}
@@ -0,0 +1,100 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/forzeyy/avito-internship-spring-service/internal/apperrors"
+	"github.com/forzeyy/avito-internship-spring-service/internal/dto"
+	"github.com/forzeyy/avito-internship-spring-service/internal/models"
+	"github.com/forzeyy/avito-internship-spring-service/internal/services"
+	"github.com/labstack/echo/v4"
+	"github.com/oapi-codegen/runtime/types"
+)
+
+type AuditHandler struct {
+	auditSvc services.AuditService
+}
+
+func NewAuditHandler(auditSvc services.AuditService) *AuditHandler {
+	return &AuditHandler{auditSvc: auditSvc}
+}
+
+type GetAuditEntriesRequest struct {
+	ActorID     string     `query:"actorId"`
+	Action      string     `query:"action"`
+	PVZID       string     `query:"pvzId"`
+	ReceptionID string     `query:"receptionId"`
+	Outcome     string     `query:"outcome"`
+	From        *time.Time `query:"from"`
+	To          *time.Time `query:"to"`
+	Page        *int       `query:"page"`
+	Limit       *int       `query:"limit"`
+}
+
+func (ah *AuditHandler) GetAuditEntries(c echo.Context) error {
+	var request GetAuditEntriesRequest
+	if err := c.Bind(&request); err != nil {
+		return apperrors.Validation("невалидный запрос")
+	}
+
+	params := services.AuditListParams{
+		ActorID:     request.ActorID,
+		Action:      request.Action,
+		PVZID:       request.PVZID,
+		ReceptionID: request.ReceptionID,
+		Outcome:     request.Outcome,
+		From:        request.From,
+		To:          request.To,
+		Page:        1,
+		Limit:       30,
+	}
+	if request.Page != nil {
+		params.Page = *request.Page
+	}
+	if request.Limit != nil {
+		params.Limit = *request.Limit
+	}
+
+	entries, err := ah.auditSvc.GetEntries(c.Request().Context(), params)
+	if err != nil {
+		return err
+	}
+
+	dtoEntries := make([]dto.AuditEntry, 0, len(entries))
+	for _, entry := range entries {
+		dtoEntries = append(dtoEntries, toDTOAuditEntry(&entry))
+	}
+
+	return c.JSON(http.StatusOK, dtoEntries)
+}
+
+func toDTOAuditEntry(entry *models.AuditEntry) dto.AuditEntry {
+	result := dto.AuditEntry{
+		Id:          (types.UUID)(entry.ID),
+		ActorId:     (*types.UUID)(entry.ActorID),
+		Action:      dto.AuditEntryAction(entry.Action),
+		PvzId:       (*types.UUID)(entry.Target.PVZID),
+		ReceptionId: (*types.UUID)(entry.Target.ReceptionID),
+		ProductId:   (*types.UUID)(entry.Target.ProductID),
+		UserId:      (*types.UUID)(entry.Target.UserID),
+		Outcome:     dto.AuditEntryOutcome(entry.Outcome),
+		CreatedAt:   entry.CreatedAt,
+	}
+	if entry.ActorRole != "" {
+		result.ActorRole = &entry.ActorRole
+	}
+	if entry.Target.Role != "" {
+		result.Role = &entry.Target.Role
+	}
+	if entry.Details != nil {
+		result.Details = &entry.Details
+	}
+	if entry.RequestID != "" {
+		result.RequestId = &entry.RequestID
+	}
+	if entry.Error != "" {
+		result.Error = &entry.Error
+	}
+	return result
+}
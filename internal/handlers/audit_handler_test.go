@@ -0,0 +1,72 @@
+package handlers_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/forzeyy/avito-internship-spring-service/internal/apperrors"
+	"github.com/forzeyy/avito-internship-spring-service/internal/handlers"
+	"github.com/forzeyy/avito-internship-spring-service/internal/mocks"
+	"github.com/forzeyy/avito-internship-spring-service/internal/models"
+	"github.com/forzeyy/avito-internship-spring-service/internal/services"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetAuditEntries_Success(t *testing.T) {
+	e := echo.New()
+	mockSvc := new(mocks.AuditService)
+	handler := handlers.NewAuditHandler(mockSvc)
+
+	actorID, pvzID := uuid.New(), uuid.New()
+	mockSvc.On("GetEntries", mock.Anything, services.AuditListParams{
+		PVZID:   pvzID.String(),
+		Outcome: models.AuditOutcomeSuccess,
+		Page:    1,
+		Limit:   30,
+	}).Return([]models.AuditEntry{{
+		ID:        uuid.New(),
+		ActorID:   &actorID,
+		ActorRole: "employee",
+		Action:    models.AuditActionReceptionOpen,
+		Target:    models.AuditTarget{PVZID: &pvzID},
+		RequestID: "req-1",
+		Outcome:   models.AuditOutcomeSuccess,
+		CreatedAt: time.Now(),
+	}}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/audit?pvzId="+pvzID.String()+"&outcome=success", nil)
+	rec := httptest.NewRecorder()
+	ctx := e.NewContext(req, rec)
+
+	err := handler.GetAuditEntries(ctx)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"action":"reception.open"`)
+	assert.Contains(t, rec.Body.String(), `"requestId":"req-1"`)
+	assert.NotContains(t, rec.Body.String(), `"error"`)
+	mockSvc.AssertExpectations(t)
+}
+
+func TestGetAuditEntries_ServiceError(t *testing.T) {
+	e := echo.New()
+	mockSvc := new(mocks.AuditService)
+	handler := handlers.NewAuditHandler(mockSvc)
+
+	mockSvc.On("GetEntries", mock.Anything, mock.Anything).Return(nil, apperrors.Validation("неверный формат actor_id"))
+
+	req := httptest.NewRequest(http.MethodGet, "/audit?actorId=bad", nil)
+	rec := httptest.NewRecorder()
+	ctx := e.NewContext(req, rec)
+
+	err := handler.GetAuditEntries(ctx)
+	handlers.ErrorHandler(err, ctx)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), "неверный формат actor_id")
+}
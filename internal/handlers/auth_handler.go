@@ -3,7 +3,9 @@ package handlers
 import (
 	"net/http"
 
+	"github.com/forzeyy/avito-internship-spring-service/internal/apperrors"
 	"github.com/forzeyy/avito-internship-spring-service/internal/dto"
+	"github.com/forzeyy/avito-internship-spring-service/internal/models"
 	"github.com/forzeyy/avito-internship-spring-service/internal/services"
 	"github.com/labstack/echo/v4"
 )
@@ -22,52 +24,168 @@ func NewAuthHandler(userSvc services.UserService) *AuthHandler {
 func (ah *AuthHandler) RegisterUser(c echo.Context) error {
 	var request dto.PostRegisterJSONRequestBody
 	if err := c.Bind(&request); err != nil {
-		return c.JSON(http.StatusBadRequest, dto.Error{
-			Message: "невалидный запрос",
-		})
+		return apperrors.Validation("невалидный запрос")
 	}
 
 	err := ah.userSvc.RegisterUser(c.Request().Context(), string(request.Email), request.Password, string(request.Role))
 	if err != nil {
-		return c.JSON(http.StatusBadRequest, dto.Error{
-			Message: err.Error(),
-		})
+		return err
 	}
 	return c.NoContent(http.StatusCreated)
 }
 
 // POST /login
+// Ответ остается строкой с access токеном, как до появления refresh токенов; пару выдает POST /token
 func (ah *AuthHandler) LoginUser(c echo.Context) error {
 	var request dto.PostLoginJSONRequestBody
 	if err := c.Bind(&request); err != nil {
-		return c.JSON(http.StatusBadRequest, dto.Error{
-			Message: "невалидный запрос",
-		})
+		return apperrors.Validation("невалидный запрос")
+	}
+
+	tokens, err := ah.login(c, string(request.Email), request.Password)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, dto.Token(tokens.AccessToken))
+}
+
+// POST /token
+func (ah *AuthHandler) IssueTokens(c echo.Context) error {
+	var request dto.PostTokenJSONRequestBody
+	if err := c.Bind(&request); err != nil {
+		return apperrors.Validation("невалидный запрос")
+	}
+
+	tokens, err := ah.login(c, string(request.Email), request.Password)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, toDTOTokenPair(tokens))
+}
+
+func (ah *AuthHandler) login(c echo.Context, email, password string) (*models.TokenPair, error) {
+	tokens, err := ah.userSvc.LoginUser(c.Request().Context(), email, password, c.RealIP())
+	if err != nil {
+		// статус учетной записи проверяется только после пароля, поэтому его можно сообщить.
+		// Остальные ошибки не раскрывают, что именно не так: email или пароль
+		switch apperrors.KindOf(err) {
+		case apperrors.KindInternal, apperrors.KindTooManyRequests, apperrors.KindForbidden:
+			return nil, err
+		}
+		return nil, apperrors.Unauthorized("неверные учетные данные")
+	}
+	return tokens, nil
+}
+
+// POST /token/refresh
+func (ah *AuthHandler) RefreshTokens(c echo.Context) error {
+	var request dto.PostTokenRefreshJSONRequestBody
+	if err := c.Bind(&request); err != nil {
+		return apperrors.Validation("невалидный запрос")
 	}
 
-	token, err := ah.userSvc.LoginUser(c.Request().Context(), string(request.Email), request.Password)
+	tokens, err := ah.userSvc.RefreshTokens(c.Request().Context(), request.RefreshToken)
 	if err != nil {
-		return c.JSON(http.StatusUnauthorized, dto.Error{
-			Message: "неверные учетные данные",
+		return err
+	}
+	return c.JSON(http.StatusOK, toDTOTokenPair(tokens))
+}
+
+// POST /logout
+func (ah *AuthHandler) Logout(c echo.Context) error {
+	var request dto.PostLogoutJSONRequestBody
+	if err := c.Bind(&request); err != nil {
+		return apperrors.Validation("невалидный запрос")
+	}
+
+	if err := ah.userSvc.Logout(c.Request().Context(), request.RefreshToken); err != nil {
+		return err
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+// POST /password/forgot
+func (ah *AuthHandler) ForgotPassword(c echo.Context) error {
+	var request dto.PostPasswordForgotJSONRequestBody
+	if err := c.Bind(&request); err != nil {
+		return apperrors.Validation("невалидный запрос")
+	}
+
+	if err := ah.userSvc.ForgotPassword(c.Request().Context(), string(request.Email), c.RealIP()); err != nil {
+		return err
+	}
+	return c.NoContent(http.StatusAccepted)
+}
+
+// POST /password/reset
+func (ah *AuthHandler) ResetPassword(c echo.Context) error {
+	var request dto.PostPasswordResetJSONRequestBody
+	if err := c.Bind(&request); err != nil {
+		return apperrors.Validation("невалидный запрос")
+	}
+
+	if err := ah.userSvc.ResetPassword(c.Request().Context(), request.Token, request.Password); err != nil {
+		return err
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+// GET /users/lockouts
+func (ah *AuthHandler) GetLockouts(c echo.Context) error {
+	lockouts, err := ah.userSvc.GetLockouts(c.Request().Context())
+	if err != nil {
+		return err
+	}
+
+	dtoLockouts := make([]dto.LoginLockout, 0, len(lockouts))
+	for _, lockout := range lockouts {
+		dtoLockouts = append(dtoLockouts, dto.LoginLockout{
+			Scope:        dto.LoginLockoutScope(lockout.Scope),
+			Key:          lockout.Key,
+			FailedCount:  lockout.FailedCount,
+			LastFailedAt: lockout.LastFailedAt,
+			LockedUntil:  *lockout.LockedUntil,
 		})
 	}
-	return c.JSON(http.StatusOK, dto.Token(token))
+	return c.JSON(http.StatusOK, dtoLockouts)
+}
+
+// POST /users/unlock
+func (ah *AuthHandler) UnlockUser(c echo.Context) error {
+	var request dto.PostUsersUnlockJSONRequestBody
+	if err := c.Bind(&request); err != nil {
+		return apperrors.Validation("невалидный запрос")
+	}
+
+	if err := ah.userSvc.UnlockUser(c.Request().Context(), string(request.Email)); err != nil {
+		return err
+	}
+	return c.NoContent(http.StatusNoContent)
 }
 
 // POST /dummyLogin
 func (ah *AuthHandler) DummyLogin(c echo.Context) error {
 	var request dto.PostDummyLoginJSONRequestBody
 	if err := c.Bind(&request); err != nil {
-		return c.JSON(http.StatusBadRequest, dto.Error{
-			Message: "невалидный запрос",
-		})
+		return apperrors.Validation("невалидный запрос")
 	}
 
 	token, err := ah.userSvc.DummyLogin(c.Request().Context(), string(request.Role))
 	if err != nil {
-		return c.JSON(http.StatusBadRequest, dto.Error{
-			Message: err.Error(),
-		})
+		return err
 	}
 	return c.JSON(http.StatusOK, dto.Token(token))
 }
+
+func toDTOTokenPair(tokens *models.TokenPair) dto.TokenPair {
+	return dto.TokenPair{
+		AccessToken:  tokens.AccessToken,
+		RefreshToken: tokens.RefreshToken,
+	}
+}
+
+// userIDFromContext возвращает id пользователя, который middleware.WithRole кладет в контекст из claim sub
+func userIDFromContext(c echo.Context) string {
+	userID, _ := c.Get("userID").(string)
+	return userID
+}
@@ -2,13 +2,15 @@ package handlers_test
 
 import (
 	"bytes"
-	"errors"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 
+	"github.com/forzeyy/avito-internship-spring-service/internal/apperrors"
 	"github.com/forzeyy/avito-internship-spring-service/internal/handlers"
 	"github.com/forzeyy/avito-internship-spring-service/internal/mocks"
+	"github.com/forzeyy/avito-internship-spring-service/internal/models"
+	"github.com/forzeyy/avito-internship-spring-service/internal/services"
 	"github.com/labstack/echo/v4"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -45,7 +47,10 @@ func TestLoginUser_Success(t *testing.T) {
 	rec := httptest.NewRecorder()
 	c := e.NewContext(req, rec)
 
-	mockUserService.On("LoginUser", mock.Anything, "user@example.com", "secret").Return("mock_token", nil)
+	mockUserService.On("LoginUser", mock.Anything, "user@example.com", "secret", mock.Anything).Return(&models.TokenPair{
+		AccessToken:  "mock_token",
+		RefreshToken: "mock_refresh",
+	}, nil)
 
 	err := handler.LoginUser(c)
 
@@ -55,6 +60,51 @@ func TestLoginUser_Success(t *testing.T) {
 	mockUserService.AssertExpectations(t)
 }
 
+func TestIssueTokens_Success(t *testing.T) {
+	e := echo.New()
+	mockUserService := new(mocks.UserService)
+	handler := handlers.NewAuthHandler(mockUserService)
+
+	payload := `{"email":"user@example.com","password":"secret"}`
+	req := httptest.NewRequest(http.MethodPost, "/token", bytes.NewBufferString(payload))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	mockUserService.On("LoginUser", mock.Anything, "user@example.com", "secret", mock.Anything).Return(&models.TokenPair{
+		AccessToken:  "mock_token",
+		RefreshToken: "mock_refresh",
+	}, nil)
+
+	err := handler.IssueTokens(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"accessToken":"mock_token","refreshToken":"mock_refresh"}`, rec.Body.String())
+	mockUserService.AssertExpectations(t)
+}
+
+func TestIssueTokens_Fail(t *testing.T) {
+	e := echo.New()
+	mockUserService := new(mocks.UserService)
+	handler := handlers.NewAuthHandler(mockUserService)
+
+	payload := `{"email":"user@example.com","password":"wrong"}`
+	req := httptest.NewRequest(http.MethodPost, "/token", bytes.NewBufferString(payload))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	mockUserService.On("LoginUser", mock.Anything, "user@example.com", "wrong", mock.Anything).Return(nil, apperrors.NotFound("пользователь с таким email не найден"))
+
+	err := handler.IssueTokens(c)
+	handlers.ErrorHandler(err, c)
+
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	assert.JSONEq(t, `{"message":"неверные учетные данные"}`, rec.Body.String())
+	mockUserService.AssertExpectations(t)
+}
+
 func TestLoginUser_Fail(t *testing.T) {
 	e := echo.New()
 	mockUserService := new(mocks.UserService)
@@ -66,15 +116,79 @@ func TestLoginUser_Fail(t *testing.T) {
 	rec := httptest.NewRecorder()
 	c := e.NewContext(req, rec)
 
-	mockUserService.On("LoginUser", mock.Anything, "user@example.com", "wrong").Return("", errors.New("invalid"))
+	mockUserService.On("LoginUser", mock.Anything, "user@example.com", "wrong", mock.Anything).Return(nil, apperrors.Validation("неверный пароль"))
 
 	err := handler.LoginUser(c)
+	handlers.ErrorHandler(err, c)
 
-	assert.NoError(t, err)
 	assert.Equal(t, http.StatusUnauthorized, rec.Code)
 	mockUserService.AssertExpectations(t)
 }
 
+func TestLoginUser_Throttled(t *testing.T) {
+	e := echo.New()
+	mockUserService := new(mocks.UserService)
+	handler := handlers.NewAuthHandler(mockUserService)
+
+	payload := `{"email":"user@example.com","password":"guess"}`
+	req := httptest.NewRequest(http.MethodPost, "/login", bytes.NewBufferString(payload))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	req.RemoteAddr = "203.0.113.7:5555"
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	mockUserService.On("LoginUser", mock.Anything, "user@example.com", "guess", "203.0.113.7").
+		Return(nil, apperrors.TooManyRequests("слишком много неудачных попыток входа, повторите через 4 с"))
+
+	err := handler.LoginUser(c)
+	handlers.ErrorHandler(err, c)
+
+	assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+	assert.Contains(t, rec.Body.String(), "повторите через 4 с")
+	mockUserService.AssertExpectations(t)
+}
+
+func TestLoginUser_Deactivated(t *testing.T) {
+	e := echo.New()
+	mockUserService := new(mocks.UserService)
+	handler := handlers.NewAuthHandler(mockUserService)
+
+	payload := `{"email":"user@example.com","password":"secret"}`
+	req := httptest.NewRequest(http.MethodPost, "/login", bytes.NewBufferString(payload))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	mockUserService.On("LoginUser", mock.Anything, "user@example.com", "secret", mock.Anything).Return(nil, services.ErrUserDeactivated)
+
+	err := handler.LoginUser(c)
+	handlers.ErrorHandler(err, c)
+
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+	assert.Contains(t, rec.Body.String(), "учетная запись деактивирована")
+	mockUserService.AssertExpectations(t)
+}
+
+func TestUnlockUser(t *testing.T) {
+	e := echo.New()
+	mockUserService := new(mocks.UserService)
+	handler := handlers.NewAuthHandler(mockUserService)
+
+	payload := `{"email":"user@example.com"}`
+	req := httptest.NewRequest(http.MethodPost, "/users/unlock", bytes.NewBufferString(payload))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	mockUserService.On("UnlockUser", mock.Anything, "user@example.com").Return(nil)
+
+	err := handler.UnlockUser(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	mockUserService.AssertExpectations(t)
+}
+
 func TestDummyLogin(t *testing.T) {
 	e := echo.New()
 	mockUserService := new(mocks.UserService)
@@ -95,3 +209,103 @@ func TestDummyLogin(t *testing.T) {
 	assert.JSONEq(t, `"dummy_token"`, rec.Body.String())
 	mockUserService.AssertExpectations(t)
 }
+
+func TestRefreshTokens_Success(t *testing.T) {
+	e := echo.New()
+	mockUserService := new(mocks.UserService)
+	handler := handlers.NewAuthHandler(mockUserService)
+
+	req := httptest.NewRequest(http.MethodPost, "/token/refresh", bytes.NewBufferString(`{"refreshToken":"old"}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	mockUserService.On("RefreshTokens", mock.Anything, "old").Return(&models.TokenPair{
+		AccessToken:  "access",
+		RefreshToken: "new",
+	}, nil)
+
+	err := handler.RefreshTokens(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"accessToken":"access","refreshToken":"new"}`, rec.Body.String())
+	mockUserService.AssertExpectations(t)
+}
+
+func TestRefreshTokens_Reused(t *testing.T) {
+	e := echo.New()
+	mockUserService := new(mocks.UserService)
+	handler := handlers.NewAuthHandler(mockUserService)
+
+	req := httptest.NewRequest(http.MethodPost, "/token/refresh", bytes.NewBufferString(`{"refreshToken":"old"}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	mockUserService.On("RefreshTokens", mock.Anything, "old").Return(nil, services.ErrRefreshTokenReused)
+
+	err := handler.RefreshTokens(c)
+	handlers.ErrorHandler(err, c)
+
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	mockUserService.AssertExpectations(t)
+}
+
+func TestLogout(t *testing.T) {
+	e := echo.New()
+	mockUserService := new(mocks.UserService)
+	handler := handlers.NewAuthHandler(mockUserService)
+
+	req := httptest.NewRequest(http.MethodPost, "/logout", bytes.NewBufferString(`{"refreshToken":"token"}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	mockUserService.On("Logout", mock.Anything, "token").Return(nil)
+
+	err := handler.Logout(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	mockUserService.AssertExpectations(t)
+}
+
+func TestForgotPassword(t *testing.T) {
+	e := echo.New()
+	mockUserService := new(mocks.UserService)
+	handler := handlers.NewAuthHandler(mockUserService)
+
+	req := httptest.NewRequest(http.MethodPost, "/password/forgot", bytes.NewBufferString(`{"email":"user@example.com"}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	mockUserService.On("ForgotPassword", mock.Anything, "user@example.com", mock.Anything).Return(nil)
+
+	err := handler.ForgotPassword(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusAccepted, rec.Code)
+	mockUserService.AssertExpectations(t)
+}
+
+func TestResetPassword_InvalidToken(t *testing.T) {
+	e := echo.New()
+	mockUserService := new(mocks.UserService)
+	handler := handlers.NewAuthHandler(mockUserService)
+
+	req := httptest.NewRequest(http.MethodPost, "/password/reset", bytes.NewBufferString(`{"token":"used","password":"new_secret"}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	mockUserService.On("ResetPassword", mock.Anything, "used", "new_secret").Return(services.ErrInvalidResetToken)
+
+	err := handler.ResetPassword(c)
+	handlers.ErrorHandler(err, c)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), "ссылка для сброса пароля недействительна")
+	mockUserService.AssertExpectations(t)
+}
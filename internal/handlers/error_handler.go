@@ -0,0 +1,58 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/forzeyy/avito-internship-spring-service/internal/apperrors"
+	"github.com/forzeyy/avito-internship-spring-service/internal/dto"
+	"github.com/labstack/echo/v4"
+)
+
+// ErrorHandler переводит ошибки обработчиков в ответ с подходящим статусом.
+// Ошибки без класса считаются внутренними: клиент получает 500 без подробностей, а причина пишется в лог
+func ErrorHandler(err error, c echo.Context) {
+	if c.Response().Committed {
+		return
+	}
+
+	status, message := errorResponse(err)
+	if status == http.StatusInternalServerError {
+		log.Printf("внутренняя ошибка при обработке %s %s: %v", c.Request().Method, c.Path(), err)
+	}
+
+	if c.Request().Method == http.MethodHead {
+		err = c.NoContent(status)
+	} else {
+		err = c.JSON(status, dto.Error{Message: message})
+	}
+	if err != nil {
+		log.Printf("не удалось отправить ответ с ошибкой: %v", err)
+	}
+}
+
+func errorResponse(err error) (int, string) {
+	var httpErr *echo.HTTPError
+	if errors.As(err, &httpErr) {
+		return httpErr.Code, fmt.Sprint(httpErr.Message)
+	}
+
+	switch apperrors.KindOf(err) {
+	case apperrors.KindValidation:
+		return http.StatusBadRequest, err.Error()
+	case apperrors.KindNotFound:
+		return http.StatusNotFound, err.Error()
+	case apperrors.KindConflict:
+		return http.StatusConflict, err.Error()
+	case apperrors.KindForbidden:
+		return http.StatusForbidden, err.Error()
+	case apperrors.KindUnauthorized:
+		return http.StatusUnauthorized, err.Error()
+	case apperrors.KindTooManyRequests:
+		return http.StatusTooManyRequests, err.Error()
+	default:
+		return http.StatusInternalServerError, "внутренняя ошибка сервера"
+	}
+}
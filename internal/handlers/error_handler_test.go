@@ -0,0 +1,48 @@
+package handlers_test
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/forzeyy/avito-internship-spring-service/internal/apperrors"
+	"github.com/forzeyy/avito-internship-spring-service/internal/handlers"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestErrorHandler(t *testing.T) {
+	tests := []struct {
+		name    string
+		err     error
+		code    int
+		message string
+	}{
+		{"validation", apperrors.Validation("неверный формат pvz_id"), http.StatusBadRequest, "неверный формат pvz_id"},
+		{"unauthorized", apperrors.Unauthorized("недействительный refresh токен"), http.StatusUnauthorized, "недействительный refresh токен"},
+		{"too many requests", apperrors.TooManyRequests("слишком много попыток"), http.StatusTooManyRequests, "слишком много попыток"},
+		{"forbidden", apperrors.Forbidden("доступ запрещен"), http.StatusForbidden, "доступ запрещен"},
+		{"not found", apperrors.NotFound("ПВЗ не найден"), http.StatusNotFound, "ПВЗ не найден"},
+		{"conflict", apperrors.Conflict("в ПВЗ уже есть незакрытая приемка"), http.StatusConflict, "в ПВЗ уже есть незакрытая приемка"},
+		{"wrapped", fmt.Errorf("транзакция: %w", apperrors.Conflict("нет товаров для удаления")), http.StatusConflict, "нет товаров для удаления"},
+		{"echo error", echo.NewHTTPError(http.StatusUnauthorized, "не авторизован"), http.StatusUnauthorized, "не авторизован"},
+		{"internal", errors.New("connection refused"), http.StatusInternalServerError, "внутренняя ошибка сервера"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			rec := httptest.NewRecorder()
+			ctx := e.NewContext(req, rec)
+
+			handlers.ErrorHandler(tt.err, ctx)
+
+			assert.Equal(t, tt.code, rec.Code)
+			assert.Contains(t, rec.Body.String(), tt.message)
+			assert.NotContains(t, rec.Body.String(), "connection refused")
+		})
+	}
+}
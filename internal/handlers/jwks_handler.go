@@ -0,0 +1,62 @@
+package handlers
+
+import (
+	"crypto/ed25519"
+	"crypto/rsa"
+	"encoding/base64"
+	"math/big"
+	"net/http"
+
+	"github.com/forzeyy/avito-internship-spring-service/internal/dto"
+	"github.com/forzeyy/avito-internship-spring-service/internal/utils"
+	"github.com/labstack/echo/v4"
+)
+
+type JWKSHandler struct {
+	keys *utils.KeySet
+}
+
+func NewJWKSHandler(keys *utils.KeySet) *JWKSHandler {
+	return &JWKSHandler{keys: keys}
+}
+
+// GetJWKS отдает публичные ключи, чтобы другие сервисы могли проверять наши токены без общего секрета.
+// Общий секрет HS256 в ответ не попадает
+func (jh *JWKSHandler) GetJWKS(c echo.Context) error {
+	jwks := dto.JWKSet{Keys: []dto.JWK{}}
+	for _, key := range jh.keys.VerificationKeys() {
+		if jwk, ok := toDTOJWK(key); ok {
+			jwks.Keys = append(jwks.Keys, jwk)
+		}
+	}
+
+	// ключи меняются только при перезапуске, кэш на несколько минут снимает нагрузку с клиентов
+	c.Response().Header().Set("Cache-Control", "public, max-age=300")
+	return c.JSON(http.StatusOK, jwks)
+}
+
+func toDTOJWK(key *utils.SigningKey) (dto.JWK, bool) {
+	jwk := dto.JWK{
+		Kid: key.ID,
+		Use: "sig",
+		Alg: dto.JWKAlg(key.Method.Alg()),
+	}
+
+	switch public := key.PublicKey.(type) {
+	case *rsa.PublicKey:
+		n := base64.RawURLEncoding.EncodeToString(public.N.Bytes())
+		e := base64.RawURLEncoding.EncodeToString(big.NewInt(int64(public.E)).Bytes())
+		jwk.Kty = dto.RSA
+		jwk.N = &n
+		jwk.E = &e
+	case ed25519.PublicKey:
+		crv := "Ed25519"
+		x := base64.RawURLEncoding.EncodeToString(public)
+		jwk.Kty = dto.OKP
+		jwk.Crv = &crv
+		jwk.X = &x
+	default:
+		return dto.JWK{}, false
+	}
+	return jwk, true
+}
@@ -0,0 +1,60 @@
+package handlers_test
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/forzeyy/avito-internship-spring-service/internal/dto"
+	"github.com/forzeyy/avito-internship-spring-service/internal/handlers"
+	"github.com/forzeyy/avito-internship-spring-service/internal/utils"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetJWKS_PublishesPublicKeys(t *testing.T) {
+	dir := t.TempDir()
+	_, private, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKCS8PrivateKey(private)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "k1.pem"), pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), 0o600))
+
+	keys, err := utils.LoadKeySet(dir, "k1")
+	require.NoError(t, err)
+	handler := handlers.NewJWKSHandler(keys)
+
+	e := echo.New()
+	rec := httptest.NewRecorder()
+	ctx := e.NewContext(httptest.NewRequest(http.MethodGet, "/.well-known/jwks.json", nil), rec)
+
+	assert.NoError(t, handler.GetJWKS(ctx))
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var jwks dto.JWKSet
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &jwks))
+	require.Len(t, jwks.Keys, 1)
+	assert.Equal(t, "k1", jwks.Keys[0].Kid)
+	assert.Equal(t, dto.OKP, jwks.Keys[0].Kty)
+	assert.Equal(t, dto.EdDSA, jwks.Keys[0].Alg)
+	assert.NotEmpty(t, *jwks.Keys[0].X)
+}
+
+func TestGetJWKS_HidesSharedSecret(t *testing.T) {
+	handler := handlers.NewJWKSHandler(utils.NewHMACKeySet("secrettt"))
+
+	e := echo.New()
+	rec := httptest.NewRecorder()
+	ctx := e.NewContext(httptest.NewRequest(http.MethodGet, "/.well-known/jwks.json", nil), rec)
+
+	assert.NoError(t, handler.GetJWKS(ctx))
+	assert.JSONEq(t, `{"keys":[]}`, rec.Body.String())
+}
@@ -3,7 +3,9 @@ package handlers
 import (
 	"net/http"
 
+	"github.com/forzeyy/avito-internship-spring-service/internal/apperrors"
 	"github.com/forzeyy/avito-internship-spring-service/internal/dto"
+	"github.com/forzeyy/avito-internship-spring-service/internal/models"
 	"github.com/forzeyy/avito-internship-spring-service/internal/services"
 	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
@@ -23,45 +25,114 @@ func NewProductHandler(prodSvc services.ProductService) *ProductHandler {
 func (ph *ProductHandler) AddProduct(c echo.Context) error {
 	var request dto.PostProductsJSONRequestBody
 	if err := c.Bind(&request); err != nil {
-		return c.JSON(http.StatusBadRequest, dto.Error{
-			Message: "невалидный запрос",
-		})
+		return apperrors.Validation("невалидный запрос")
 	}
 
 	if request.PvzId.String() == "" || request.Type == "" {
-		return c.JSON(http.StatusBadRequest, dto.Error{
-			Message: "запрос должен содержать pvzId и type",
-		})
+		return apperrors.Validation("запрос должен содержать pvzId и type")
 	}
 
-	product, err := ph.prodSvc.AddProduct(c.Request().Context(), string(request.Type), request.PvzId.String())
+	var barcode string
+	if request.Barcode != nil {
+		barcode = *request.Barcode
+	}
+
+	product, err := ph.prodSvc.AddProduct(c.Request().Context(), string(request.Type), barcode, request.PvzId.String(), userIDFromContext(c))
 	if err != nil {
-		return c.JSON(http.StatusBadRequest, dto.Error{
-			Message: err.Error(),
-		})
+		return err
 	}
-	return c.JSON(http.StatusCreated, dto.Product{
-		Id:          (*types.UUID)(&product.ID),
-		Type:        dto.ProductType(product.Type),
-		ReceptionId: (types.UUID)(product.ReceptionID),
-		DateTime:    &product.DateTime,
-	})
+	return c.JSON(http.StatusCreated, toDTOProduct(product))
+}
+
+// POST /products/batch
+func (ph *ProductHandler) AddProducts(c echo.Context) error {
+	var request dto.PostProductsBatchJSONRequestBody
+	if err := c.Bind(&request); err != nil {
+		return apperrors.Validation("невалидный запрос")
+	}
+
+	items := make([]models.NewProduct, 0, len(request.Items))
+	for _, item := range request.Items {
+		newProduct := models.NewProduct{Type: item.Type}
+		if item.Barcode != nil {
+			newProduct.Barcode = *item.Barcode
+		}
+		items = append(items, newProduct)
+	}
+
+	batch, err := ph.prodSvc.AddProducts(c.Request().Context(), items, request.PvzId.String(), userIDFromContext(c))
+	if err != nil {
+		return err
+	}
+
+	response := dto.ProductBatchResult{
+		ReceptionId: batch.ReceptionID,
+		Items:       make([]dto.ProductBatchItemResult, 0, len(batch.Items)),
+	}
+	for _, item := range batch.Items {
+		result := dto.ProductBatchItemResult{Index: item.Index}
+		if item.Product == nil {
+			response.Rejected++
+			result.Status = dto.Rejected
+			result.Error = &item.Error
+		} else {
+			response.Created++
+			result.Status = dto.Created
+			product := toDTOProduct(item.Product)
+			result.Product = &product
+		}
+		response.Items = append(response.Items, result)
+	}
+	return c.JSON(http.StatusCreated, response)
 }
 
 func (ph *ProductHandler) DeleteLastProduct(c echo.Context) error {
 	pvzId := c.Param("pvzId")
 	if _, err := uuid.Parse(pvzId); err != nil {
-		return c.JSON(http.StatusBadRequest, dto.Error{
-			Message: "неверный формат pvz_id",
-		})
+		return apperrors.Validation("неверный формат pvz_id")
 	}
 
-	err := ph.prodSvc.DeleteLastProduct(c.Request().Context(), pvzId)
+	err := ph.prodSvc.DeleteLastProduct(c.Request().Context(), pvzId, userIDFromContext(c))
 	if err != nil {
-		return c.JSON(http.StatusBadRequest, dto.Error{
-			Message: err.Error(),
-		})
+		return err
 	}
 
 	return c.NoContent(http.StatusOK)
 }
+
+// DELETE /products/:productId
+func (ph *ProductHandler) DeleteProduct(c echo.Context) error {
+	if err := ph.prodSvc.DeleteProduct(c.Request().Context(), c.Param("productId"), userIDFromContext(c)); err != nil {
+		return err
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+// GET /products/by-barcode/:code
+func (ph *ProductHandler) GetProductsByBarcode(c echo.Context) error {
+	locations, err := ph.prodSvc.GetProductsByBarcode(c.Request().Context(), c.Param("code"))
+	if err != nil {
+		return err
+	}
+
+	response := make([]dto.ProductLocation, 0, len(locations))
+	for _, location := range locations {
+		response = append(response, dto.ProductLocation{
+			Product:         toDTOProduct(&location.Product),
+			PvzId:           location.PVZID,
+			City:            location.City,
+			ReceptionStatus: location.ReceptionStatus,
+		})
+	}
+	return c.JSON(http.StatusOK, response)
+}
+
+func toDTOProduct(product *models.Product) dto.Product {
+	return dto.Product{
+		Id:          (*types.UUID)(&product.ID),
+		Type:        dto.ProductType(product.Type),
+		ReceptionId: (types.UUID)(product.ReceptionID),
+		DateTime:    &product.DateTime,
+		Barcode:     product.Barcode,
+	}
+}
@@ -11,6 +11,8 @@ import (
 	"github.com/forzeyy/avito-internship-spring-service/internal/handlers"
 	"github.com/forzeyy/avito-internship-spring-service/internal/mocks"
 	"github.com/forzeyy/avito-internship-spring-service/internal/models"
+	"github.com/forzeyy/avito-internship-spring-service/internal/repos"
+	"github.com/forzeyy/avito-internship-spring-service/internal/services"
 	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
 	"github.com/stretchr/testify/assert"
@@ -32,8 +34,9 @@ func TestAddProduct_Success(t *testing.T) {
 	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
 	rec := httptest.NewRecorder()
 	ctx := e.NewContext(req, rec)
+	ctx.Set("userID", "11111111-1111-1111-1111-111111111111")
 
-	mockService.On("AddProduct", mock.Anything, "одежда", pvzID.String()).Return(&models.Product{
+	mockService.On("AddProduct", mock.Anything, "одежда", "", pvzID.String(), "11111111-1111-1111-1111-111111111111").Return(&models.Product{
 		ID:          uuid.New(),
 		Type:        "одежда",
 		ReceptionID: receptionID,
@@ -60,8 +63,8 @@ func TestAddProduct_BindFail(t *testing.T) {
 	ctx := e.NewContext(req, rec)
 
 	err := handler.AddProduct(ctx)
+	handlers.ErrorHandler(err, ctx)
 
-	assert.NoError(t, err)
 	assert.Equal(t, http.StatusBadRequest, rec.Code)
 	assert.Contains(t, rec.Body.String(), "невалидный запрос")
 }
@@ -79,13 +82,48 @@ func TestAddProduct_ServiceError(t *testing.T) {
 	rec := httptest.NewRecorder()
 	ctx := e.NewContext(req, rec)
 
-	mockService.On("AddProduct", mock.Anything, "обувь", pvzID.String()).Return(&models.Product{}, errors.New("ошибка добавления"))
+	mockService.On("AddProduct", mock.Anything, "обувь", "", pvzID.String(), "").Return(&models.Product{}, errors.New("ошибка добавления"))
 
 	err := handler.AddProduct(ctx)
+	handlers.ErrorHandler(err, ctx)
+
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	assert.Contains(t, rec.Body.String(), "внутренняя ошибка сервера")
+}
+
+func TestAddProducts_Success(t *testing.T) {
+	e := echo.New()
+	mockService := new(mocks.ProductService)
+	handler := handlers.NewProductHandler(mockService)
+
+	pvzID := uuid.New()
+	receptionID := uuid.New()
+	product := &models.Product{ID: uuid.New(), Type: "обувь", ReceptionID: receptionID, DateTime: time.Now()}
+
+	payload := `{"pvzId":"` + pvzID.String() + `","items":[{"type":"обувь","barcode":"4601234567890"},{"type":"еда"}]}`
+
+	req := httptest.NewRequest(http.MethodPost, "/products/batch", bytes.NewBufferString(payload))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	ctx := e.NewContext(req, rec)
+	ctx.Set("userID", "11111111-1111-1111-1111-111111111111")
+
+	mockService.On("AddProducts", mock.Anything, []models.NewProduct{{Type: "обувь", Barcode: "4601234567890"}, {Type: "еда"}}, pvzID.String(), "11111111-1111-1111-1111-111111111111").Return(&models.ProductBatch{
+		ReceptionID: receptionID,
+		Items: []models.ProductBatchItem{
+			{Index: 0, Product: product},
+			{Index: 1, Error: "недопустимый тип товара"},
+		},
+	}, nil)
+
+	err := handler.AddProducts(ctx)
 
 	assert.NoError(t, err)
-	assert.Equal(t, http.StatusBadRequest, rec.Code)
-	assert.Contains(t, rec.Body.String(), "ошибка добавления")
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"created":1`)
+	assert.Contains(t, rec.Body.String(), `"rejected":1`)
+	assert.Contains(t, rec.Body.String(), `{"error":"недопустимый тип товара","index":1,"status":"rejected"}`)
+	mockService.AssertExpectations(t)
 }
 
 func TestDeleteLastProduct_Success(t *testing.T) {
@@ -100,8 +138,9 @@ func TestDeleteLastProduct_Success(t *testing.T) {
 	ctx := e.NewContext(req, rec)
 	ctx.SetParamNames("pvzId")
 	ctx.SetParamValues(pvzID)
+	ctx.Set("userID", "11111111-1111-1111-1111-111111111111")
 
-	mockService.On("DeleteLastProduct", mock.Anything, pvzID).Return(nil)
+	mockService.On("DeleteLastProduct", mock.Anything, pvzID, "11111111-1111-1111-1111-111111111111").Return(nil)
 
 	err := handler.DeleteLastProduct(ctx)
 
@@ -124,8 +163,8 @@ func TestDeleteLastProduct_InvalidUUID(t *testing.T) {
 	ctx.SetParamValues(invalidID)
 
 	err := handler.DeleteLastProduct(ctx)
+	handlers.ErrorHandler(err, ctx)
 
-	assert.NoError(t, err)
 	assert.Equal(t, http.StatusBadRequest, rec.Code)
 	assert.Contains(t, rec.Body.String(), "неверный формат pvz_id")
 }
@@ -143,11 +182,107 @@ func TestDeleteLastProduct_ServiceError(t *testing.T) {
 	ctx.SetParamNames("pvzId")
 	ctx.SetParamValues(pvzID)
 
-	mockService.On("DeleteLastProduct", mock.Anything, pvzID).Return(errors.New("ошибка удаления"))
+	mockService.On("DeleteLastProduct", mock.Anything, pvzID, "").Return(errors.New("ошибка удаления"))
 
 	err := handler.DeleteLastProduct(ctx)
+	handlers.ErrorHandler(err, ctx)
+
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	assert.Contains(t, rec.Body.String(), "внутренняя ошибка сервера")
+}
+
+func TestDeleteProduct_ReceptionClosed(t *testing.T) {
+	e := echo.New()
+	mockService := new(mocks.ProductService)
+	handler := handlers.NewProductHandler(mockService)
+
+	productID := uuid.New()
+
+	req := httptest.NewRequest(http.MethodDelete, "/products/"+productID.String(), nil)
+	rec := httptest.NewRecorder()
+	ctx := e.NewContext(req, rec)
+	ctx.SetParamNames("productId")
+	ctx.SetParamValues(productID.String())
+	ctx.Set("userID", "11111111-1111-1111-1111-111111111111")
+
+	mockService.On("DeleteProduct", mock.Anything, productID.String(), "11111111-1111-1111-1111-111111111111").Return(services.ErrReceptionClosed)
+
+	err := handler.DeleteProduct(ctx)
+	handlers.ErrorHandler(err, ctx)
+
+	assert.Equal(t, http.StatusConflict, rec.Code)
+	mockService.AssertExpectations(t)
+}
+
+func TestAddProduct_DuplicateBarcode(t *testing.T) {
+	e := echo.New()
+	mockService := new(mocks.ProductService)
+	handler := handlers.NewProductHandler(mockService)
+
+	pvzID := uuid.New()
+	payload := `{"type":"обувь","pvzId":"` + pvzID.String() + `","barcode":"4601234567890"}`
+
+	req := httptest.NewRequest(http.MethodPost, "/products", bytes.NewBufferString(payload))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	ctx := e.NewContext(req, rec)
+
+	mockService.On("AddProduct", mock.Anything, "обувь", "4601234567890", pvzID.String(), "").Return(nil, repos.ErrDuplicateBarcode)
+
+	err := handler.AddProduct(ctx)
+	handlers.ErrorHandler(err, ctx)
+
+	assert.Equal(t, http.StatusConflict, rec.Code)
+	assert.Contains(t, rec.Body.String(), "товар с таким штрихкодом уже принят")
+	mockService.AssertExpectations(t)
+}
+
+func TestGetProductsByBarcode_Success(t *testing.T) {
+	e := echo.New()
+	mockService := new(mocks.ProductService)
+	handler := handlers.NewProductHandler(mockService)
+
+	barcode := "4601234567890"
+	pvzID := uuid.New()
+
+	req := httptest.NewRequest(http.MethodGet, "/products/by-barcode/"+barcode, nil)
+	rec := httptest.NewRecorder()
+	ctx := e.NewContext(req, rec)
+	ctx.SetParamNames("code")
+	ctx.SetParamValues(barcode)
+
+	mockService.On("GetProductsByBarcode", mock.Anything, barcode).Return([]models.ProductLocation{{
+		Product:         models.Product{ID: uuid.New(), Type: "одежда", ReceptionID: uuid.New(), DateTime: time.Now(), Barcode: &barcode},
+		PVZID:           pvzID,
+		City:            "Москва",
+		ReceptionStatus: "close",
+	}}, nil)
+
+	err := handler.GetProductsByBarcode(ctx)
 
 	assert.NoError(t, err)
-	assert.Equal(t, http.StatusBadRequest, rec.Code)
-	assert.Contains(t, rec.Body.String(), "ошибка удаления")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"barcode":"4601234567890"`)
+	assert.Contains(t, rec.Body.String(), `"pvzId":"`+pvzID.String()+`"`)
+	assert.Contains(t, rec.Body.String(), `"city":"Москва"`)
+	mockService.AssertExpectations(t)
+}
+
+func TestGetProductsByBarcode_NotFound(t *testing.T) {
+	e := echo.New()
+	mockService := new(mocks.ProductService)
+	handler := handlers.NewProductHandler(mockService)
+
+	req := httptest.NewRequest(http.MethodGet, "/products/by-barcode/unknown", nil)
+	rec := httptest.NewRecorder()
+	ctx := e.NewContext(req, rec)
+	ctx.SetParamNames("code")
+	ctx.SetParamValues("unknown")
+
+	mockService.On("GetProductsByBarcode", mock.Anything, "unknown").Return(nil, repos.ErrProductNotFound)
+
+	err := handler.GetProductsByBarcode(ctx)
+	handlers.ErrorHandler(err, ctx)
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
 }
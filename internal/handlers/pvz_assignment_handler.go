@@ -0,0 +1,66 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/forzeyy/avito-internship-spring-service/internal/apperrors"
+	"github.com/forzeyy/avito-internship-spring-service/internal/dto"
+	"github.com/forzeyy/avito-internship-spring-service/internal/models"
+	"github.com/forzeyy/avito-internship-spring-service/internal/services"
+	"github.com/labstack/echo/v4"
+	"github.com/oapi-codegen/runtime/types"
+)
+
+type PVZAssignmentHandler struct {
+	assignmentSvc services.PVZAssignmentService
+}
+
+func NewPVZAssignmentHandler(assignmentSvc services.PVZAssignmentService) *PVZAssignmentHandler {
+	return &PVZAssignmentHandler{assignmentSvc: assignmentSvc}
+}
+
+// GET /pvz/{pvzId}/employees
+func (ah *PVZAssignmentHandler) GetAssignments(c echo.Context) error {
+	assignments, err := ah.assignmentSvc.GetAssignments(c.Request().Context(), c.Param("pvzId"))
+	if err != nil {
+		return err
+	}
+
+	dtoAssignments := make([]dto.PVZAssignment, 0, len(assignments))
+	for _, assignment := range assignments {
+		dtoAssignments = append(dtoAssignments, toDTOPVZAssignment(&assignment))
+	}
+	return c.JSON(http.StatusOK, dtoAssignments)
+}
+
+// POST /pvz/{pvzId}/employees
+func (ah *PVZAssignmentHandler) AssignEmployee(c echo.Context) error {
+	var request dto.PostPvzPvzIdEmployeesJSONRequestBody
+	if err := c.Bind(&request); err != nil {
+		return apperrors.Validation("невалидный запрос")
+	}
+
+	assignment, err := ah.assignmentSvc.AssignEmployee(c.Request().Context(), c.Param("pvzId"), request.UserId.String())
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusCreated, toDTOPVZAssignment(assignment))
+}
+
+// DELETE /pvz/{pvzId}/employees/{userId}
+func (ah *PVZAssignmentHandler) UnassignEmployee(c echo.Context) error {
+	if err := ah.assignmentSvc.UnassignEmployee(c.Request().Context(), c.Param("pvzId"), c.Param("userId")); err != nil {
+		return err
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+func toDTOPVZAssignment(assignment *models.PVZAssignment) dto.PVZAssignment {
+	return dto.PVZAssignment{
+		PvzId:      (types.UUID)(assignment.PVZID),
+		UserId:     (types.UUID)(assignment.UserID),
+		Email:      types.Email(assignment.Email),
+		AssignedBy: (*types.UUID)(assignment.AssignedBy),
+		AssignedAt: assignment.AssignedAt,
+	}
+}
@@ -2,8 +2,11 @@ package handlers
 
 import (
 	"net/http"
+	"time"
 
+	"github.com/forzeyy/avito-internship-spring-service/internal/apperrors"
 	"github.com/forzeyy/avito-internship-spring-service/internal/dto"
+	"github.com/forzeyy/avito-internship-spring-service/internal/models"
 	"github.com/forzeyy/avito-internship-spring-service/internal/services"
 	"github.com/labstack/echo/v4"
 	"github.com/oapi-codegen/runtime/types"
@@ -19,6 +22,8 @@ func NewPVZHandler(pvzSvc services.PVZService) *PVZHandler {
 	}
 }
 
+const HeaderNextCursor = "X-Next-Cursor"
+
 type CreatePVZRequest struct {
 	City dto.PVZCity `json:"city"`
 }
@@ -26,16 +31,12 @@ type CreatePVZRequest struct {
 func (ph *PVZHandler) CreatePVZ(c echo.Context) error {
 	var request CreatePVZRequest
 	if err := c.Bind(&request); err != nil {
-		return c.JSON(http.StatusBadRequest, dto.Error{
-			Message: "невалидный запрос",
-		})
+		return apperrors.Validation("невалидный запрос")
 	}
 
 	pvz, err := ph.pvzSvc.CreatePVZ(c.Request().Context(), string(request.City))
 	if err != nil {
-		return c.JSON(http.StatusBadRequest, dto.Error{
-			Message: err.Error(),
-		})
+		return err
 	}
 
 	return c.JSON(http.StatusCreated, dto.PVZ{
@@ -45,12 +46,19 @@ func (ph *PVZHandler) CreatePVZ(c echo.Context) error {
 	})
 }
 
+// dto.GetPvzParams размечен тегами form, которые echo не использует для query-параметров
+type GetPVZsRequest struct {
+	StartDate *time.Time `query:"startDate"`
+	EndDate   *time.Time `query:"endDate"`
+	Page      *int       `query:"page"`
+	Limit     *int       `query:"limit"`
+	Cursor    *string    `query:"cursor"`
+}
+
 func (ph *PVZHandler) GetPVZs(c echo.Context) error {
-	var params dto.GetPvzParams
+	var params GetPVZsRequest
 	if err := c.Bind(&params); err != nil {
-		return c.JSON(http.StatusBadRequest, dto.Error{
-			Message: "невалидный запрос",
-		})
+		return apperrors.Validation("невалидный запрос")
 	}
 
 	page := 1
@@ -63,21 +71,118 @@ func (ph *PVZHandler) GetPVZs(c echo.Context) error {
 		limit = *params.Limit
 	}
 
-	pvzs, err := ph.pvzSvc.GetPVZs(c.Request().Context(), params.StartDate, params.EndDate, page, limit)
+	var (
+		pvzs []models.PVZWithReceptions
+		err  error
+	)
+	if params.Cursor != nil {
+		var nextCursor string
+		pvzs, nextCursor, err = ph.pvzSvc.GetPVZsByCursor(c.Request().Context(), params.StartDate, params.EndDate, *params.Cursor, limit)
+		if nextCursor != "" {
+			c.Response().Header().Set(HeaderNextCursor, nextCursor)
+		}
+	} else {
+		pvzs, err = ph.pvzSvc.GetPVZs(c.Request().Context(), params.StartDate, params.EndDate, page, limit)
+	}
 	if err != nil {
-		return c.JSON(http.StatusBadRequest, dto.Error{
-			Message: err.Error(),
-		})
+		return err
 	}
 
-	var dtoPvzs []dto.PVZ
+	dtoPvzs := make([]dto.PVZWithReceptions, 0, len(pvzs))
 	for _, pvz := range pvzs {
-		dtoPvzs = append(dtoPvzs, dto.PVZ{
-			Id:               (*types.UUID)(&pvz.ID),
-			City:             dto.PVZCity(pvz.City),
-			RegistrationDate: &pvz.RegDate,
+		dtoReceptions := make([]dto.ReceptionWithProducts, 0, len(pvz.Receptions))
+		for _, rec := range pvz.Receptions {
+			dtoProducts := make([]dto.Product, 0, len(rec.Products))
+			for _, product := range rec.Products {
+				dtoProducts = append(dtoProducts, dto.Product{
+					Id:          (*types.UUID)(&product.ID),
+					Type:        dto.ProductType(product.Type),
+					ReceptionId: (types.UUID)(product.ReceptionID),
+					DateTime:    &product.DateTime,
+					Barcode:     product.Barcode,
+				})
+			}
+
+			dtoReceptions = append(dtoReceptions, dto.ReceptionWithProducts{
+				Reception: dto.Reception{
+					Id:       (*types.UUID)(&rec.Reception.ID),
+					PvzId:    (types.UUID)(rec.Reception.PVZID),
+					Status:   dto.ReceptionStatus(rec.Reception.Status),
+					DateTime: rec.Reception.DateTime,
+				},
+				Products: dtoProducts,
+			})
+		}
+
+		dtoPvzs = append(dtoPvzs, dto.PVZWithReceptions{
+			Pvz: dto.PVZ{
+				Id:               (*types.UUID)(&pvz.PVZ.ID),
+				City:             dto.PVZCity(pvz.PVZ.City),
+				RegistrationDate: &pvz.PVZ.RegDate,
+			},
+			Receptions: dtoReceptions,
 		})
 	}
 
 	return c.JSON(http.StatusOK, dtoPvzs)
 }
+
+type GetPVZDetailsRequest struct {
+	PVZID string `param:"pvzId"`
+	Page  *int   `query:"page"`
+	Limit *int   `query:"limit"`
+}
+
+func (ph *PVZHandler) GetPVZDetails(c echo.Context) error {
+	var params GetPVZDetailsRequest
+	if err := c.Bind(&params); err != nil {
+		return apperrors.Validation("невалидный запрос")
+	}
+
+	page := 1
+	if params.Page != nil {
+		page = *params.Page
+	}
+
+	limit := 10
+	if params.Limit != nil {
+		limit = *params.Limit
+	}
+
+	details, err := ph.pvzSvc.GetPVZDetails(c.Request().Context(), params.PVZID, page, limit)
+	if err != nil {
+		return err
+	}
+
+	history := make([]dto.ReceptionHistoryItem, 0, len(details.History))
+	for _, item := range details.History {
+		history = append(history, dto.ReceptionHistoryItem{
+			Reception: dto.Reception{
+				Id:       (*types.UUID)(&item.Reception.ID),
+				PvzId:    (types.UUID)(item.Reception.PVZID),
+				Status:   dto.ReceptionStatus(item.Reception.Status),
+				DateTime: item.Reception.DateTime,
+			},
+			ProductCount: item.ProductCount,
+		})
+	}
+
+	resp := dto.PVZDetails{
+		Pvz: dto.PVZ{
+			Id:               (*types.UUID)(&details.PVZ.ID),
+			City:             dto.PVZCity(details.PVZ.City),
+			RegistrationDate: &details.PVZ.RegDate,
+		},
+		History: history,
+	}
+	if details.OpenReception != nil {
+		resp.OpenReception = &dto.Reception{
+			Id:       (*types.UUID)(&details.OpenReception.ID),
+			PvzId:    (types.UUID)(details.OpenReception.PVZID),
+			Status:   dto.ReceptionStatus(details.OpenReception.Status),
+			DateTime: details.OpenReception.DateTime,
+		}
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/forzeyy/avito-internship-spring-service/internal/handlers"
 	"github.com/forzeyy/avito-internship-spring-service/internal/mocks"
 	"github.com/forzeyy/avito-internship-spring-service/internal/models"
+	"github.com/forzeyy/avito-internship-spring-service/internal/services"
 	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
 	"github.com/stretchr/testify/assert"
@@ -58,8 +59,8 @@ func TestCreatePVZ_BindError(t *testing.T) {
 	ctx := e.NewContext(req, rec)
 
 	err := handler.CreatePVZ(ctx)
+	handlers.ErrorHandler(err, ctx)
 
-	assert.NoError(t, err)
 	assert.Equal(t, http.StatusBadRequest, rec.Code)
 	assert.Contains(t, rec.Body.String(), "невалидный запрос")
 }
@@ -79,10 +80,10 @@ func TestCreatePVZ_ServiceError(t *testing.T) {
 	ctx := e.NewContext(req, rec)
 
 	err := handler.CreatePVZ(ctx)
+	handlers.ErrorHandler(err, ctx)
 
-	assert.NoError(t, err)
-	assert.Equal(t, http.StatusBadRequest, rec.Code)
-	assert.Contains(t, rec.Body.String(), "ошибка создания")
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	assert.Contains(t, rec.Body.String(), "внутренняя ошибка сервера")
 }
 
 func TestGetPVZs_Success(t *testing.T) {
@@ -92,11 +93,32 @@ func TestGetPVZs_Success(t *testing.T) {
 
 	regDate := time.Now()
 	pvzID := uuid.New()
-	pvzs := []models.PVZ{
+	receptionID := uuid.New()
+	pvzs := []models.PVZWithReceptions{
 		{
-			ID:      pvzID,
-			City:    "Москва",
-			RegDate: regDate,
+			PVZ: models.PVZ{
+				ID:      pvzID,
+				City:    "Москва",
+				RegDate: regDate,
+			},
+			Receptions: []models.ReceptionWithProducts{
+				{
+					Reception: models.Reception{
+						ID:       receptionID,
+						PVZID:    pvzID,
+						Status:   "in_progress",
+						DateTime: regDate,
+					},
+					Products: []models.Product{
+						{
+							ID:          uuid.New(),
+							Type:        "обувь",
+							ReceptionID: receptionID,
+							DateTime:    regDate,
+						},
+					},
+				},
+			},
 		},
 	}
 
@@ -111,6 +133,8 @@ func TestGetPVZs_Success(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, http.StatusOK, rec.Code)
 	assert.Contains(t, rec.Body.String(), `"city":"Москва"`)
+	assert.Contains(t, rec.Body.String(), `"status":"in_progress"`)
+	assert.Contains(t, rec.Body.String(), `"type":"обувь"`)
 	mockSvc.AssertExpectations(t)
 }
 
@@ -125,9 +149,99 @@ func TestGetPVZs_ServiceError(t *testing.T) {
 	rec := httptest.NewRecorder()
 	ctx := e.NewContext(req, rec)
 
+	err := handler.GetPVZs(ctx)
+	handlers.ErrorHandler(err, ctx)
+
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	assert.Contains(t, rec.Body.String(), "внутренняя ошибка сервера")
+}
+
+func TestGetPVZs_PageParams(t *testing.T) {
+	e := echo.New()
+	mockSvc := new(mocks.PVZService)
+	handler := handlers.NewPVZHandler(mockSvc)
+
+	mockSvc.On("GetPVZs", mock.Anything, (*time.Time)(nil), (*time.Time)(nil), 3, 5).Return([]models.PVZWithReceptions{}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/pvz?page=3&limit=5", nil)
+	rec := httptest.NewRecorder()
+	ctx := e.NewContext(req, rec)
+
 	err := handler.GetPVZs(ctx)
 
 	assert.NoError(t, err)
-	assert.Equal(t, http.StatusBadRequest, rec.Code)
-	assert.Contains(t, rec.Body.String(), "ошибка получения")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Empty(t, rec.Header().Get(handlers.HeaderNextCursor))
+	mockSvc.AssertExpectations(t)
+}
+
+func TestGetPVZs_Cursor(t *testing.T) {
+	e := echo.New()
+	mockSvc := new(mocks.PVZService)
+	handler := handlers.NewPVZHandler(mockSvc)
+
+	mockSvc.On("GetPVZsByCursor", mock.Anything, (*time.Time)(nil), (*time.Time)(nil), "", 2).
+		Return([]models.PVZWithReceptions{}, "next", nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/pvz?cursor=&limit=2", nil)
+	rec := httptest.NewRecorder()
+	ctx := e.NewContext(req, rec)
+
+	err := handler.GetPVZs(ctx)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "next", rec.Header().Get(handlers.HeaderNextCursor))
+	mockSvc.AssertExpectations(t)
+}
+
+func TestGetPVZDetails_Success(t *testing.T) {
+	e := echo.New()
+	mockSvc := new(mocks.PVZService)
+	handler := handlers.NewPVZHandler(mockSvc)
+
+	pvzID := uuid.New()
+	now := time.Now()
+
+	mockSvc.On("GetPVZDetails", mock.Anything, pvzID.String(), 2, 5).Return(&models.PVZDetails{
+		PVZ:           models.PVZ{ID: pvzID, City: "Казань", RegDate: now},
+		OpenReception: &models.Reception{ID: uuid.New(), PVZID: pvzID, Status: "in_progress", DateTime: now},
+		History: []models.ReceptionWithProductCount{
+			{Reception: models.Reception{ID: uuid.New(), PVZID: pvzID, Status: "close", DateTime: now}, ProductCount: 7},
+		},
+	}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/pvz/"+pvzID.String()+"?page=2&limit=5", nil)
+	rec := httptest.NewRecorder()
+	ctx := e.NewContext(req, rec)
+	ctx.SetParamNames("pvzId")
+	ctx.SetParamValues(pvzID.String())
+
+	err := handler.GetPVZDetails(ctx)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"openReception"`)
+	assert.Contains(t, rec.Body.String(), `"productCount":7`)
+	mockSvc.AssertExpectations(t)
+}
+
+func TestGetPVZDetails_NotFound(t *testing.T) {
+	e := echo.New()
+	mockSvc := new(mocks.PVZService)
+	handler := handlers.NewPVZHandler(mockSvc)
+
+	pvzID := uuid.New()
+	mockSvc.On("GetPVZDetails", mock.Anything, pvzID.String(), 1, 10).Return(nil, services.ErrPVZNotFound)
+
+	req := httptest.NewRequest(http.MethodGet, "/pvz/"+pvzID.String(), nil)
+	rec := httptest.NewRecorder()
+	ctx := e.NewContext(req, rec)
+	ctx.SetParamNames("pvzId")
+	ctx.SetParamValues(pvzID.String())
+
+	err := handler.GetPVZDetails(ctx)
+	handlers.ErrorHandler(err, ctx)
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
 }
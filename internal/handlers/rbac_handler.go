@@ -0,0 +1,72 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/forzeyy/avito-internship-spring-service/internal/apperrors"
+	"github.com/forzeyy/avito-internship-spring-service/internal/dto"
+	"github.com/forzeyy/avito-internship-spring-service/internal/models"
+	"github.com/forzeyy/avito-internship-spring-service/internal/services"
+	"github.com/labstack/echo/v4"
+)
+
+type RBACHandler struct {
+	rbacSvc services.RBACService
+}
+
+func NewRBACHandler(rbacSvc services.RBACService) *RBACHandler {
+	return &RBACHandler{rbacSvc: rbacSvc}
+}
+
+// GET /roles
+func (rh *RBACHandler) GetRoles(c echo.Context) error {
+	roles, err := rh.rbacSvc.GetRoles(c.Request().Context())
+	if err != nil {
+		return err
+	}
+
+	dtoRoles := make([]dto.Role, 0, len(roles))
+	for _, role := range roles {
+		dtoRoles = append(dtoRoles, toDTORole(&role))
+	}
+	return c.JSON(http.StatusOK, dtoRoles)
+}
+
+// GET /permissions
+func (rh *RBACHandler) GetPermissions(c echo.Context) error {
+	permissions, err := rh.rbacSvc.GetPermissions(c.Request().Context())
+	if err != nil {
+		return err
+	}
+
+	dtoPermissions := make([]dto.Permission, 0, len(permissions))
+	for _, permission := range permissions {
+		dtoPermissions = append(dtoPermissions, dto.Permission{
+			Name:        permission.Name,
+			Description: permission.Description,
+		})
+	}
+	return c.JSON(http.StatusOK, dtoPermissions)
+}
+
+// PUT /roles/{role}/permissions
+func (rh *RBACHandler) SetRolePermissions(c echo.Context) error {
+	var request dto.PutRolesRolePermissionsJSONRequestBody
+	if err := c.Bind(&request); err != nil {
+		return apperrors.Validation("невалидный запрос")
+	}
+
+	role, err := rh.rbacSvc.SetRolePermissions(c.Request().Context(), c.Param("role"), request.Permissions)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, toDTORole(role))
+}
+
+func toDTORole(role *models.Role) dto.Role {
+	return dto.Role{
+		Name:        role.Name,
+		Description: role.Description,
+		Permissions: role.Permissions,
+	}
+}
@@ -2,8 +2,11 @@ package handlers
 
 import (
 	"net/http"
+	"time"
 
+	"github.com/forzeyy/avito-internship-spring-service/internal/apperrors"
 	"github.com/forzeyy/avito-internship-spring-service/internal/dto"
+	"github.com/forzeyy/avito-internship-spring-service/internal/models"
 	"github.com/forzeyy/avito-internship-spring-service/internal/services"
 	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
@@ -21,21 +24,17 @@ func NewReceptionHandler(recSvc services.ReceptionService) *ReceptionHandler {
 func (rh *ReceptionHandler) CreateReception(c echo.Context) error {
 	var request dto.PostReceptionsJSONRequestBody
 	if err := c.Bind(&request); err != nil {
-		return c.JSON(http.StatusBadRequest, dto.Error{
-			Message: "невалидный запрос",
-		})
+		return apperrors.Validation("невалидный запрос")
 	}
 
-	reception, err := rh.recSvc.CreateReception(c.Request().Context(), request.PvzId.String())
+	reception, err := rh.recSvc.CreateReception(c.Request().Context(), request.PvzId.String(), userIDFromContext(c))
 	if err != nil {
-		return c.JSON(http.StatusBadRequest, dto.Error{
-			Message: err.Error(),
-		})
+		return err
 	}
 	return c.JSON(http.StatusCreated, dto.Reception{
 		Id:       (*types.UUID)(&reception.ID),
 		PvzId:    (types.UUID)(reception.PVZID),
-		Status:   dto.InProgress,
+		Status:   dto.ReceptionStatusInProgress,
 		DateTime: reception.DateTime,
 	})
 }
@@ -43,22 +42,123 @@ func (rh *ReceptionHandler) CreateReception(c echo.Context) error {
 func (rh *ReceptionHandler) CloseLastReception(c echo.Context) error {
 	pvzID := c.Param("pvzId")
 	if _, err := uuid.Parse(pvzID); err != nil {
-		return c.JSON(http.StatusBadRequest, dto.Error{
-			Message: "неверный формат pvz_id",
-		})
+		return apperrors.Validation("неверный формат pvz_id")
+	}
+
+	reception, err := rh.recSvc.CloseLastReception(c.Request().Context(), pvzID, userIDFromContext(c))
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, toDTOReception(reception))
+}
+
+type GetReceptionsRequest struct {
+	PVZID       string     `query:"pvzId"`
+	Status      string     `query:"status"`
+	CreatedFrom *time.Time `query:"createdFrom"`
+	CreatedTo   *time.Time `query:"createdTo"`
+	ClosedFrom  *time.Time `query:"closedFrom"`
+	ClosedTo    *time.Time `query:"closedTo"`
+	Page        *int       `query:"page"`
+	Limit       *int       `query:"limit"`
+}
+
+func (rh *ReceptionHandler) GetReceptions(c echo.Context) error {
+	var request GetReceptionsRequest
+	if err := c.Bind(&request); err != nil {
+		return apperrors.Validation("невалидный запрос")
+	}
+
+	params := services.ReceptionListParams{
+		PVZID:       request.PVZID,
+		Status:      request.Status,
+		CreatedFrom: request.CreatedFrom,
+		CreatedTo:   request.CreatedTo,
+		ClosedFrom:  request.ClosedFrom,
+		ClosedTo:    request.ClosedTo,
+		Page:        1,
+		Limit:       10,
+	}
+	if request.Page != nil {
+		params.Page = *request.Page
+	}
+	if request.Limit != nil {
+		params.Limit = *request.Limit
 	}
 
-	reception, err := rh.recSvc.CloseLastReception(c.Request().Context(), pvzID)
+	receptions, err := rh.recSvc.GetReceptions(c.Request().Context(), params)
 	if err != nil {
-		return c.JSON(http.StatusBadRequest, dto.Error{
-			Message: err.Error(),
+		return err
+	}
+
+	dtoReceptions := make([]dto.Reception, 0, len(receptions))
+	for _, reception := range receptions {
+		dtoReceptions = append(dtoReceptions, toDTOReception(&reception))
+	}
+
+	return c.JSON(http.StatusOK, dtoReceptions)
+}
+
+func (rh *ReceptionHandler) GetReception(c echo.Context) error {
+	reception, err := rh.recSvc.GetReception(c.Request().Context(), c.Param("receptionId"))
+	if err != nil {
+		return err
+	}
+
+	products := make([]dto.Product, 0, len(reception.Products))
+	for _, product := range reception.Products {
+		products = append(products, dto.Product{
+			Id:          (*types.UUID)(&product.ID),
+			Type:        dto.ProductType(product.Type),
+			ReceptionId: (types.UUID)(product.ReceptionID),
+			DateTime:    &product.DateTime,
+			Barcode:     product.Barcode,
 		})
 	}
 
-	return c.JSON(http.StatusOK, dto.Reception{
-		DateTime: reception.DateTime,
+	return c.JSON(http.StatusOK, dto.ReceptionWithProducts{
+		Reception: toDTOReception(&reception.Reception),
+		Products:  products,
+	})
+}
+
+func toDTOReception(reception *models.Reception) dto.Reception {
+	return dto.Reception{
 		Id:       (*types.UUID)(&reception.ID),
 		PvzId:    (types.UUID)(reception.PVZID),
 		Status:   dto.ReceptionStatus(reception.Status),
-	})
+		DateTime: reception.DateTime,
+		ClosedAt: reception.ClosedAt,
+		// поле передается только у автоматически закрытых приемок, чтобы не менять ответ для остальных
+		AutoClosed: autoClosedFlag(reception.AutoClosed),
+		Summary:    toDTOReceptionSummary(reception),
+	}
+}
+
+func toDTOReceptionSummary(reception *models.Reception) *dto.ReceptionSummary {
+	if reception.Summary == nil {
+		return nil
+	}
+
+	summary := &dto.ReceptionSummary{
+		ProductCount:   reception.Summary.ProductCount,
+		ProductsByType: reception.Summary.ProductsByType,
+		FirstScanAt:    reception.Summary.FirstScanAt,
+		LastScanAt:     reception.Summary.LastScanAt,
+	}
+	if summary.ProductsByType == nil {
+		summary.ProductsByType = map[string]int{}
+	}
+	if reception.ClosedAt != nil {
+		summary.DurationSeconds = int(reception.ClosedAt.Sub(reception.DateTime).Seconds())
+	}
+	return summary
+}
+
+func autoClosedFlag(autoClosed bool) *bool {
+	if !autoClosed {
+		return nil
+	}
+	return &autoClosed
 }
@@ -0,0 +1,97 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/forzeyy/avito-internship-spring-service/internal/dto"
+	"github.com/forzeyy/avito-internship-spring-service/internal/handlers"
+	"github.com/forzeyy/avito-internship-spring-service/internal/mocks"
+	"github.com/forzeyy/avito-internship-spring-service/internal/models"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCloseLastReception_ReturnsSummary(t *testing.T) {
+	e := echo.New()
+	mockService := new(mocks.ReceptionService)
+	handler := handlers.NewReceptionHandler(mockService)
+
+	pvzID := uuid.New()
+	createdAt := time.Now().Add(-90 * time.Minute)
+	closedAt := createdAt.Add(90 * time.Minute)
+	firstScanAt := createdAt.Add(5 * time.Minute)
+	lastScanAt := createdAt.Add(80 * time.Minute)
+
+	req := httptest.NewRequest(http.MethodPost, "/pvz/"+pvzID.String()+"/close_last_reception", nil)
+	rec := httptest.NewRecorder()
+	ctx := e.NewContext(req, rec)
+	ctx.SetParamNames("pvzId")
+	ctx.SetParamValues(pvzID.String())
+	ctx.Set("userID", "11111111-1111-1111-1111-111111111111")
+
+	mockService.On("CloseLastReception", mock.Anything, pvzID.String(), "11111111-1111-1111-1111-111111111111").Return(&models.Reception{
+		ID:       uuid.New(),
+		PVZID:    pvzID,
+		Status:   "close",
+		DateTime: createdAt,
+		ClosedAt: &closedAt,
+		Summary: &models.ReceptionSummary{
+			ProductCount:   3,
+			ProductsByType: map[string]int{"электроника": 2, "обувь": 1},
+			FirstScanAt:    &firstScanAt,
+			LastScanAt:     &lastScanAt,
+		},
+	}, nil)
+
+	err := handler.CloseLastReception(ctx)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var body dto.Reception
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	if assert.NotNil(t, body.Summary) {
+		assert.Equal(t, 3, body.Summary.ProductCount)
+		assert.Equal(t, map[string]int{"электроника": 2, "обувь": 1}, body.Summary.ProductsByType)
+		assert.Equal(t, 5400, body.Summary.DurationSeconds)
+	}
+	mockService.AssertExpectations(t)
+}
+
+func TestCloseLastReception_EmptyReceptionSummary(t *testing.T) {
+	e := echo.New()
+	mockService := new(mocks.ReceptionService)
+	handler := handlers.NewReceptionHandler(mockService)
+
+	pvzID := uuid.New()
+	createdAt := time.Now().Add(-time.Minute)
+	closedAt := time.Now()
+
+	req := httptest.NewRequest(http.MethodPost, "/pvz/"+pvzID.String()+"/close_last_reception", nil)
+	rec := httptest.NewRecorder()
+	ctx := e.NewContext(req, rec)
+	ctx.SetParamNames("pvzId")
+	ctx.SetParamValues(pvzID.String())
+
+	mockService.On("CloseLastReception", mock.Anything, pvzID.String(), mock.Anything).Return(&models.Reception{
+		ID:       uuid.New(),
+		PVZID:    pvzID,
+		Status:   "close",
+		DateTime: createdAt,
+		ClosedAt: &closedAt,
+		Summary:  &models.ReceptionSummary{},
+	}, nil)
+
+	err := handler.CloseLastReception(ctx)
+
+	assert.NoError(t, err)
+	assert.Contains(t, rec.Body.String(), `"productsByType":{}`)
+	assert.Contains(t, rec.Body.String(), `"productCount":0`)
+	assert.NotContains(t, rec.Body.String(), "firstScanAt")
+}
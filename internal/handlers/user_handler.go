@@ -0,0 +1,122 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/forzeyy/avito-internship-spring-service/internal/apperrors"
+	"github.com/forzeyy/avito-internship-spring-service/internal/dto"
+	"github.com/forzeyy/avito-internship-spring-service/internal/models"
+	"github.com/forzeyy/avito-internship-spring-service/internal/services"
+	"github.com/labstack/echo/v4"
+	"github.com/oapi-codegen/runtime/types"
+)
+
+type UserHandler struct {
+	userAdminSvc services.UserAdminService
+}
+
+func NewUserHandler(userAdminSvc services.UserAdminService) *UserHandler {
+	return &UserHandler{userAdminSvc: userAdminSvc}
+}
+
+type GetUsersRequest struct {
+	Email  string `query:"email"`
+	Role   string `query:"role"`
+	Status string `query:"status"`
+	Page   *int   `query:"page"`
+	Limit  *int   `query:"limit"`
+}
+
+// GET /users
+func (uh *UserHandler) GetUsers(c echo.Context) error {
+	var request GetUsersRequest
+	if err := c.Bind(&request); err != nil {
+		return apperrors.Validation("невалидный запрос")
+	}
+
+	params := services.UserListParams{
+		Email:  request.Email,
+		Role:   request.Role,
+		Status: request.Status,
+		Page:   1,
+		Limit:  30,
+	}
+	if request.Page != nil {
+		params.Page = *request.Page
+	}
+	if request.Limit != nil {
+		params.Limit = *request.Limit
+	}
+
+	users, err := uh.userAdminSvc.GetUsers(c.Request().Context(), params)
+	if err != nil {
+		return err
+	}
+
+	dtoUsers := make([]dto.User, 0, len(users))
+	for _, user := range users {
+		dtoUsers = append(dtoUsers, toDTOUser(&user))
+	}
+	return c.JSON(http.StatusOK, dtoUsers)
+}
+
+// GET /users/{userId}
+func (uh *UserHandler) GetUser(c echo.Context) error {
+	user, err := uh.userAdminSvc.GetUser(c.Request().Context(), c.Param("userId"))
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, toDTOUser(user))
+}
+
+// PUT /users/{userId}/role
+func (uh *UserHandler) ChangeRole(c echo.Context) error {
+	var request dto.PutUsersUserIdRoleJSONRequestBody
+	if err := c.Bind(&request); err != nil {
+		return apperrors.Validation("невалидный запрос")
+	}
+
+	user, err := uh.userAdminSvc.ChangeRole(c.Request().Context(), c.Param("userId"), request.Role)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, toDTOUser(user))
+}
+
+// POST /users/{userId}/deactivate
+func (uh *UserHandler) DeactivateUser(c echo.Context) error {
+	user, err := uh.userAdminSvc.DeactivateUser(c.Request().Context(), c.Param("userId"))
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, toDTOUser(user))
+}
+
+// POST /users/{userId}/activate
+func (uh *UserHandler) ActivateUser(c echo.Context) error {
+	user, err := uh.userAdminSvc.ActivateUser(c.Request().Context(), c.Param("userId"))
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, toDTOUser(user))
+}
+
+// POST /users/{userId}/force_password_reset
+func (uh *UserHandler) ForcePasswordReset(c echo.Context) error {
+	user, err := uh.userAdminSvc.ForcePasswordReset(c.Request().Context(), c.Param("userId"))
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, toDTOUser(user))
+}
+
+func toDTOUser(user *models.User) dto.User {
+	return dto.User{
+		Id:                    (*types.UUID)(&user.ID),
+		Email:                 types.Email(user.Email),
+		Role:                  user.Role,
+		CreatedAt:             &user.CreatedAt,
+		DeactivatedAt:         user.DeactivatedAt,
+		PasswordResetRequired: &user.PasswordResetRequired,
+	}
+}
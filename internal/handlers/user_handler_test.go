@@ -0,0 +1,98 @@
+package handlers_test
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/forzeyy/avito-internship-spring-service/internal/apperrors"
+	"github.com/forzeyy/avito-internship-spring-service/internal/handlers"
+	"github.com/forzeyy/avito-internship-spring-service/internal/mocks"
+	"github.com/forzeyy/avito-internship-spring-service/internal/models"
+	"github.com/forzeyy/avito-internship-spring-service/internal/services"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetUsers_Success(t *testing.T) {
+	e := echo.New()
+	mockSvc := new(mocks.UserAdminService)
+	handler := handlers.NewUserHandler(mockSvc)
+
+	mockSvc.On("GetUsers", mock.Anything, services.UserListParams{
+		Email:  "ivan",
+		Status: services.UserStatusActive,
+		Page:   1,
+		Limit:  30,
+	}).Return([]models.User{{
+		ID:           uuid.New(),
+		Email:        "ivan@mail.ru",
+		PasswordHash: "hash",
+		Role:         "moderator",
+		CreatedAt:    time.Now(),
+	}}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/users?email=ivan&status=active", nil)
+	rec := httptest.NewRecorder()
+	ctx := e.NewContext(req, rec)
+
+	err := handler.GetUsers(ctx)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"email":"ivan@mail.ru"`)
+	assert.Contains(t, rec.Body.String(), `"passwordResetRequired":false`)
+	assert.NotContains(t, rec.Body.String(), "hash")
+	mockSvc.AssertExpectations(t)
+}
+
+func TestChangeRole_Success(t *testing.T) {
+	e := echo.New()
+	mockSvc := new(mocks.UserAdminService)
+	handler := handlers.NewUserHandler(mockSvc)
+
+	userID := uuid.New()
+	mockSvc.On("ChangeRole", mock.Anything, userID.String(), "moderator").Return(&models.User{
+		ID:    userID,
+		Email: "worker@mail.ru",
+		Role:  "moderator",
+	}, nil)
+
+	req := httptest.NewRequest(http.MethodPut, "/users/"+userID.String()+"/role", bytes.NewBufferString(`{"role":"moderator"}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	ctx := e.NewContext(req, rec)
+	ctx.SetParamNames("userId")
+	ctx.SetParamValues(userID.String())
+
+	err := handler.ChangeRole(ctx)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"role":"moderator"`)
+	mockSvc.AssertExpectations(t)
+}
+
+func TestDeactivateUser_Self(t *testing.T) {
+	e := echo.New()
+	mockSvc := new(mocks.UserAdminService)
+	handler := handlers.NewUserHandler(mockSvc)
+
+	userID := uuid.NewString()
+	mockSvc.On("DeactivateUser", mock.Anything, userID).Return(nil, apperrors.Conflict("нельзя изменить собственную учетную запись"))
+
+	req := httptest.NewRequest(http.MethodPost, "/users/"+userID+"/deactivate", nil)
+	rec := httptest.NewRecorder()
+	ctx := e.NewContext(req, rec)
+	ctx.SetParamNames("userId")
+	ctx.SetParamValues(userID)
+
+	err := handler.DeactivateUser(ctx)
+	handlers.ErrorHandler(err, ctx)
+
+	assert.Equal(t, http.StatusConflict, rec.Code)
+}
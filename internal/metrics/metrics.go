@@ -0,0 +1,53 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// технические метрики
+var (
+	HTTPRequestsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "http_requests_total",
+		Help: "Количество HTTP запросов",
+	}, []string{"method", "path", "status"})
+
+	HTTPRequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "http_request_duration_seconds",
+		Help:    "Время обработки HTTP запросов",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"method", "path"})
+)
+
+// бизнесовые метрики
+var (
+	PVZCreatedTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "pvz_created_total",
+		Help: "Количество созданных ПВЗ",
+	})
+
+	ReceptionsCreatedTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "receptions_created_total",
+		Help: "Количество открытых приемок",
+	})
+
+	ReceptionsClosedTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "receptions_closed_total",
+		Help: "Количество закрытых приемок",
+	})
+
+	ReceptionsAutoClosedTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "receptions_auto_closed_total",
+		Help: "Количество приемок, закрытых автоматически из-за бездействия",
+	})
+
+	ProductsAddedTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "products_added_total",
+		Help: "Количество добавленных товаров",
+	})
+
+	ProductsDeletedTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "products_deleted_total",
+		Help: "Количество удаленных товаров",
+	})
+)
@@ -0,0 +1,27 @@
+package middleware
+
+import (
+	"context"
+
+	"github.com/labstack/echo/v4"
+)
+
+type AccessChecker interface {
+	CheckAccess(ctx context.Context, userID, role string) error
+}
+
+// RequireActiveUser отклоняет токены деактивированных пользователей, пользователей с принудительным
+// сбросом пароля и токены со старой ролью. Ставится после WithRole
+func RequireActiveUser(checker AccessChecker) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			userID, _ := c.Get("userID").(string)
+			role, _ := c.Get("role").(string)
+
+			if err := checker.CheckAccess(c.Request().Context(), userID, role); err != nil {
+				return err
+			}
+			return next(c)
+		}
+	}
+}
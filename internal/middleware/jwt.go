@@ -4,15 +4,16 @@ import (
 	"log"
 	"net/http"
 
+	"github.com/forzeyy/avito-internship-spring-service/internal/utils"
 	jwtMiddleware "github.com/labstack/echo-jwt/v4"
 	"github.com/labstack/echo/v4"
 )
 
-func JWTMiddleware(jwtSecret string) echo.MiddlewareFunc {
+// JWTMiddleware проверяет подпись access токена ключом из набора, выбранным по kid
+func JWTMiddleware(keys *utils.KeySet) echo.MiddlewareFunc {
 	return jwtMiddleware.WithConfig(jwtMiddleware.Config{
-		SigningKey:    []byte(jwtSecret),
-		TokenLookup:   "header:Authorization:Bearer ",
-		SigningMethod: "HS256",
+		KeyFunc:     keys.Keyfunc,
+		TokenLookup: "header:Authorization:Bearer ",
 		ErrorHandler: func(c echo.Context, err error) error {
 			log.Printf("Ошибка проверки JWT: %v", err)
 			return c.JSON(http.StatusUnauthorized, echo.Map{"message": "invalid or expired jwt"})
@@ -0,0 +1,36 @@
+package middleware
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/forzeyy/avito-internship-spring-service/internal/metrics"
+	"github.com/labstack/echo/v4"
+)
+
+func Metrics() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			start := time.Now()
+
+			err := next(c)
+			if err != nil {
+				// чтобы в метрику попал итоговый статус ответа
+				c.Error(err)
+			}
+
+			// шаблон маршрута вместо фактического пути, чтобы не плодить метки
+			path := c.Path()
+			if path == "" {
+				path = "unknown"
+			}
+			method := c.Request().Method
+			status := strconv.Itoa(c.Response().Status)
+
+			metrics.HTTPRequestsTotal.WithLabelValues(method, path, status).Inc()
+			metrics.HTTPRequestDuration.WithLabelValues(method, path).Observe(time.Since(start).Seconds())
+
+			return nil
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package middleware
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers/legacy"
+	"github.com/labstack/echo/v4"
+)
+
+const uuidPattern = `^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`
+
+// OpenAPIValidator проверяет параметры и тело запроса по спецификации до того, как запрос попадет в обработчик.
+// Запросы к маршрутам, которых нет в спецификации, пропускаются без проверки
+func OpenAPIValidator(spec *openapi3.T) (echo.MiddlewareFunc, error) {
+	// форматы uuid и email kin-openapi по умолчанию не проверяет
+	openapi3.DefineStringFormatValidator("uuid", openapi3.NewRegexpFormatValidator(uuidPattern))
+	openapi3.DefineStringFormatValidator("email", openapi3.NewRegexpFormatValidator(openapi3.FormatOfStringForEmail))
+
+	// маршруты сопоставляются только по пути, адреса серверов из спецификации не учитываются
+	spec.Servers = nil
+	router, err := legacy.NewRouter(spec)
+	if err != nil {
+		return nil, fmt.Errorf("не удалось построить маршруты по спецификации: %v", err)
+	}
+
+	options := &openapi3filter.Options{
+		// авторизацию проверяет JWTMiddleware
+		AuthenticationFunc: openapi3filter.NoopAuthenticationFunc,
+	}
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+			route, pathParams, err := router.FindRoute(req)
+			if err != nil {
+				return next(c)
+			}
+
+			input := &openapi3filter.RequestValidationInput{
+				Request:    req,
+				PathParams: pathParams,
+				Route:      route,
+				Options:    options,
+			}
+			if err := openapi3filter.ValidateRequest(req.Context(), input); err != nil {
+				return echo.NewHTTPError(http.StatusBadRequest, validationMessage(err))
+			}
+			return next(c)
+		}
+	}, nil
+}
+
+func validationMessage(err error) string {
+	var reqErr *openapi3filter.RequestError
+	if !errors.As(err, &reqErr) {
+		return "невалидный запрос"
+	}
+
+	if reqErr.Parameter != nil {
+		return fmt.Sprintf("неверный параметр %s", reqErr.Parameter.Name)
+	}
+
+	var schemaErr *openapi3.SchemaError
+	if errors.As(err, &schemaErr) {
+		if field := strings.Join(schemaErr.JSONPointer(), "."); field != "" {
+			return fmt.Sprintf("неверное поле %s в теле запроса", field)
+		}
+	}
+	return "невалидное тело запроса"
+}
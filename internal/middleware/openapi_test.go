@@ -0,0 +1,81 @@
+package middleware_test
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/forzeyy/avito-internship-spring-service/internal/dto"
+	"github.com/forzeyy/avito-internship-spring-service/internal/handlers"
+	"github.com/forzeyy/avito-internship-spring-service/internal/middleware"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newValidatedEcho(t *testing.T) *echo.Echo {
+	spec, err := dto.GetSwagger()
+	require.NoError(t, err)
+	validator, err := middleware.OpenAPIValidator(spec)
+	require.NoError(t, err)
+
+	ok := func(c echo.Context) error {
+		return c.NoContent(http.StatusOK)
+	}
+
+	e := echo.New()
+	e.HTTPErrorHandler = handlers.ErrorHandler
+	e.Use(validator)
+	e.POST("/dummyLogin", ok)
+	e.GET("/pvz", ok)
+	e.GET("/pvz/:pvzId", ok)
+	e.GET("/receptions", ok)
+	e.POST("/products", ok)
+	e.POST("/register", ok)
+	e.GET("/users/lockouts", ok)
+	e.GET("/users/:userId", ok)
+	return e
+}
+
+func TestOpenAPIValidator(t *testing.T) {
+	tests := []struct {
+		name    string
+		method  string
+		target  string
+		body    string
+		code    int
+		message string
+	}{
+		{"valid body", http.MethodPost, "/dummyLogin", `{"role":"employee"}`, http.StatusOK, ""},
+		{"unknown enum", http.MethodPost, "/dummyLogin", `{"role":"superuser"}`, http.StatusBadRequest, "неверное поле role в теле запроса"},
+		{"missing required field", http.MethodPost, "/products", `{"type":"обувь"}`, http.StatusBadRequest, "неверное поле pvzId в теле запроса"},
+		{"malformed uuid in body", http.MethodPost, "/products", `{"type":"обувь","pvzId":"123"}`, http.StatusBadRequest, "неверное поле pvzId в теле запроса"},
+		{"malformed uuid in path", http.MethodGet, "/pvz/not-a-uuid", "", http.StatusBadRequest, "неверный параметр pvzId"},
+		{"malformed date", http.MethodGet, "/pvz?startDate=yesterday", "", http.StatusBadRequest, "неверный параметр startDate"},
+		{"limit out of range", http.MethodGet, "/pvz?limit=100", "", http.StatusBadRequest, "неверный параметр limit"},
+		{"empty cursor", http.MethodGet, "/pvz?cursor=", "", http.StatusOK, ""},
+		{"unknown status", http.MethodGet, "/receptions?status=open", "", http.StatusBadRequest, "неверный параметр status"},
+		{"self-registration as moderator", http.MethodPost, "/register", `{"email":"boss@mail.ru","password":"123","role":"moderator"}`, http.StatusBadRequest, "неверное поле role в теле запроса"},
+		{"static path before parameter", http.MethodGet, "/users/lockouts", "", http.StatusOK, ""},
+		{"malformed user id", http.MethodGet, "/users/lockouts-old", "", http.StatusBadRequest, "неверный параметр userId"},
+	}
+
+	e := newValidatedEcho(t)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.target, bytes.NewBufferString(tt.body))
+			if tt.body != "" {
+				req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			}
+			rec := httptest.NewRecorder()
+
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.code, rec.Code)
+			if tt.message != "" {
+				assert.JSONEq(t, `{"message":"`+tt.message+`"}`, rec.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,34 @@
+package middleware
+
+import (
+	"context"
+
+	"github.com/forzeyy/avito-internship-spring-service/internal/apperrors"
+	"github.com/labstack/echo/v4"
+)
+
+type PermissionChecker interface {
+	HasPermission(ctx context.Context, role, permission string) (bool, error)
+}
+
+// RequirePermission пропускает запрос, если роли из токена выдано право permission.
+// Права ролей хранятся в базе, поэтому их изменение не требует перевыпуска токенов
+func RequirePermission(checker PermissionChecker, permission string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			role, ok := c.Get("role").(string)
+			if !ok {
+				return apperrors.Forbidden("доступ запрещен")
+			}
+
+			allowed, err := checker.HasPermission(c.Request().Context(), role, permission)
+			if err != nil {
+				return err
+			}
+			if !allowed {
+				return apperrors.Forbidden("доступ запрещен")
+			}
+			return next(c)
+		}
+	}
+}
@@ -0,0 +1,66 @@
+package middleware_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/forzeyy/avito-internship-spring-service/internal/handlers"
+	"github.com/forzeyy/avito-internship-spring-service/internal/middleware"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+type permissionCheckerFunc func(ctx context.Context, role, permission string) (bool, error)
+
+func (f permissionCheckerFunc) HasPermission(ctx context.Context, role, permission string) (bool, error) {
+	return f(ctx, role, permission)
+}
+
+func TestRequirePermission(t *testing.T) {
+	checker := permissionCheckerFunc(func(ctx context.Context, role, permission string) (bool, error) {
+		switch role {
+		case "broken":
+			return false, errors.New("база недоступна")
+		case "moderator":
+			return permission == "pvz:create", nil
+		}
+		return false, nil
+	})
+
+	tests := []struct {
+		name   string
+		role   any
+		status int
+	}{
+		{name: "право выдано", role: "moderator", status: http.StatusOK},
+		{name: "права нет", role: "employee", status: http.StatusForbidden},
+		{name: "нет роли в контексте", role: nil, status: http.StatusForbidden},
+		{name: "ошибка проверки", role: "broken", status: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			e.HTTPErrorHandler = handlers.ErrorHandler
+			setRole := func(next echo.HandlerFunc) echo.HandlerFunc {
+				return func(c echo.Context) error {
+					if tt.role != nil {
+						c.Set("role", tt.role)
+					}
+					return next(c)
+				}
+			}
+			e.POST("/pvz", func(c echo.Context) error {
+				return c.NoContent(http.StatusOK)
+			}, setRole, middleware.RequirePermission(checker, "pvz:create"))
+
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/pvz", nil))
+
+			assert.Equal(t, tt.status, rec.Code)
+		})
+	}
+}
@@ -0,0 +1,46 @@
+package middleware
+
+import (
+	"github.com/forzeyy/avito-internship-spring-service/internal/reqctx"
+	"github.com/labstack/echo/v4"
+	echoMiddleware "github.com/labstack/echo/v4/middleware"
+)
+
+// maxRequestIDLen совпадает с размером колонки audit_log.request_id
+const maxRequestIDLen = 64
+
+// RequestID берет id запроса из заголовка X-Request-ID или генерирует новый,
+// возвращает его в ответе и кладет в контекст запроса для журнала аудита.
+// Слишком длинный или непечатный id из заголовка заменяется новым: иначе запись аудита не сохранилась бы
+// и вместе с ней откатилась бы вся операция
+func RequestID() echo.MiddlewareFunc {
+	requestID := echoMiddleware.RequestIDWithConfig(echoMiddleware.RequestIDConfig{
+		RequestIDHandler: func(c echo.Context, requestID string) {
+			ctx := reqctx.WithRequestID(c.Request().Context(), requestID)
+			c.SetRequest(c.Request().WithContext(ctx))
+		},
+	})
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		handler := requestID(next)
+		return func(c echo.Context) error {
+			header := c.Request().Header
+			if !isValidRequestID(header.Get(echo.HeaderXRequestID)) {
+				header.Del(echo.HeaderXRequestID)
+			}
+			return handler(c)
+		}
+	}
+}
+
+func isValidRequestID(id string) bool {
+	if len(id) > maxRequestIDLen {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		if id[i] < '!' || id[i] > '~' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,56 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/forzeyy/avito-internship-spring-service/internal/middleware"
+	"github.com/forzeyy/avito-internship-spring-service/internal/reqctx"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequestID(t *testing.T) {
+	tests := []struct {
+		name   string
+		header string
+		keep   bool
+	}{
+		{name: "id клиента", header: "req-42", keep: true},
+		{name: "нет заголовка", header: "", keep: false},
+		{name: "id длиной 64", header: strings.Repeat("a", 64), keep: true},
+		{name: "слишком длинный id", header: strings.Repeat("a", 65), keep: false},
+		{name: "непечатные символы", header: "req 42\t", keep: false},
+		{name: "не ASCII", header: "запрос", keep: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			var inContext string
+			e.GET("/", func(c echo.Context) error {
+				inContext = reqctx.RequestID(c.Request().Context())
+				return c.NoContent(http.StatusOK)
+			}, middleware.RequestID())
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tt.header != "" {
+				req.Header.Set(echo.HeaderXRequestID, tt.header)
+			}
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+
+			returned := rec.Header().Get(echo.HeaderXRequestID)
+			assert.Equal(t, returned, inContext)
+			assert.LessOrEqual(t, len(returned), 64)
+			if tt.keep {
+				assert.Equal(t, tt.header, returned)
+			} else {
+				assert.NotEmpty(t, returned)
+				assert.NotEqual(t, tt.header, returned)
+			}
+		})
+	}
+}
@@ -3,7 +3,9 @@ package middleware
 import (
 	"net/http"
 
+	"github.com/forzeyy/avito-internship-spring-service/internal/reqctx"
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
 )
 
@@ -25,28 +27,23 @@ func WithRole() echo.MiddlewareFunc {
 				return echo.NewHTTPError(http.StatusUnauthorized, "нет роли")
 			}
 
+			userID, ok := claims["sub"].(string)
+			if !ok || userID == "" {
+				return echo.NewHTTPError(http.StatusUnauthorized, "нет идентификатора пользователя")
+			}
+
+			email, _ := claims["email"].(string)
+
 			c.Set("role", role)
-			return next(c)
-		}
-	}
-}
+			c.Set("userID", userID)
+			c.Set("email", email)
 
-func RequireRole(role string) echo.MiddlewareFunc {
-	return func(next echo.HandlerFunc) echo.HandlerFunc {
-		return func(c echo.Context) error {
-			userRole, ok := c.Get("role").(string)
-			if !ok || userRole != role {
-				return echo.NewHTTPError(http.StatusForbidden, "доступ запрещен")
+			// пользователь в контексте запроса нужен сервисам для журнала аудита
+			if id, err := uuid.Parse(userID); err == nil {
+				ctx := reqctx.WithUser(c.Request().Context(), reqctx.User{ID: id, Role: role})
+				c.SetRequest(c.Request().WithContext(ctx))
 			}
 			return next(c)
 		}
 	}
 }
-
-func OnlyModerator() echo.MiddlewareFunc {
-	return RequireRole("moderator")
-}
-
-func OnlyEmployee() echo.MiddlewareFunc {
-	return RequireRole("employee")
-}
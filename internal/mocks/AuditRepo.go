@@ -0,0 +1,145 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	models "github.com/forzeyy/avito-internship-spring-service/internal/models"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// AuditRepo is an autogenerated mock type for the AuditRepo type
+type AuditRepo struct {
+	mock.Mock
+}
+
+type AuditRepo_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *AuditRepo) EXPECT() *AuditRepo_Expecter {
+	return &AuditRepo_Expecter{mock: &_m.Mock}
+}
+
+// CreateEntry provides a mock function with given fields: ctx, entry
+func (_m *AuditRepo) CreateEntry(ctx context.Context, entry *models.AuditEntry) error {
+	ret := _m.Called(ctx, entry)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateEntry")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.AuditEntry) error); ok {
+		r0 = rf(ctx, entry)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AuditRepo_CreateEntry_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateEntry'
+type AuditRepo_CreateEntry_Call struct {
+	*mock.Call
+}
+
+// CreateEntry is a helper method to define mock.On call
+//   - ctx context.Context
+//   - entry *models.AuditEntry
+func (_e *AuditRepo_Expecter) CreateEntry(ctx interface{}, entry interface{}) *AuditRepo_CreateEntry_Call {
+	return &AuditRepo_CreateEntry_Call{Call: _e.mock.On("CreateEntry", ctx, entry)}
+}
+
+func (_c *AuditRepo_CreateEntry_Call) Run(run func(ctx context.Context, entry *models.AuditEntry)) *AuditRepo_CreateEntry_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.AuditEntry))
+	})
+	return _c
+}
+
+func (_c *AuditRepo_CreateEntry_Call) Return(_a0 error) *AuditRepo_CreateEntry_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *AuditRepo_CreateEntry_Call) RunAndReturn(run func(context.Context, *models.AuditEntry) error) *AuditRepo_CreateEntry_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetEntries provides a mock function with given fields: ctx, filter, page, limit
+func (_m *AuditRepo) GetEntries(ctx context.Context, filter models.AuditFilter, page int, limit int) ([]models.AuditEntry, error) {
+	ret := _m.Called(ctx, filter, page, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetEntries")
+	}
+
+	var r0 []models.AuditEntry
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.AuditFilter, int, int) ([]models.AuditEntry, error)); ok {
+		return rf(ctx, filter, page, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, models.AuditFilter, int, int) []models.AuditEntry); ok {
+		r0 = rf(ctx, filter, page, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.AuditEntry)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, models.AuditFilter, int, int) error); ok {
+		r1 = rf(ctx, filter, page, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AuditRepo_GetEntries_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetEntries'
+type AuditRepo_GetEntries_Call struct {
+	*mock.Call
+}
+
+// GetEntries is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filter models.AuditFilter
+//   - page int
+//   - limit int
+func (_e *AuditRepo_Expecter) GetEntries(ctx interface{}, filter interface{}, page interface{}, limit interface{}) *AuditRepo_GetEntries_Call {
+	return &AuditRepo_GetEntries_Call{Call: _e.mock.On("GetEntries", ctx, filter, page, limit)}
+}
+
+func (_c *AuditRepo_GetEntries_Call) Run(run func(ctx context.Context, filter models.AuditFilter, page int, limit int)) *AuditRepo_GetEntries_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(models.AuditFilter), args[2].(int), args[3].(int))
+	})
+	return _c
+}
+
+func (_c *AuditRepo_GetEntries_Call) Return(_a0 []models.AuditEntry, _a1 error) *AuditRepo_GetEntries_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *AuditRepo_GetEntries_Call) RunAndReturn(run func(context.Context, models.AuditFilter, int, int) ([]models.AuditEntry, error)) *AuditRepo_GetEntries_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewAuditRepo creates a new instance of AuditRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewAuditRepo(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *AuditRepo {
+	mock := &AuditRepo{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
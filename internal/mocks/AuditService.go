@@ -0,0 +1,98 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	models "github.com/forzeyy/avito-internship-spring-service/internal/models"
+	mock "github.com/stretchr/testify/mock"
+
+	services "github.com/forzeyy/avito-internship-spring-service/internal/services"
+)
+
+// AuditService is an autogenerated mock type for the AuditService type
+type AuditService struct {
+	mock.Mock
+}
+
+type AuditService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *AuditService) EXPECT() *AuditService_Expecter {
+	return &AuditService_Expecter{mock: &_m.Mock}
+}
+
+// GetEntries provides a mock function with given fields: ctx, params
+func (_m *AuditService) GetEntries(ctx context.Context, params services.AuditListParams) ([]models.AuditEntry, error) {
+	ret := _m.Called(ctx, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetEntries")
+	}
+
+	var r0 []models.AuditEntry
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, services.AuditListParams) ([]models.AuditEntry, error)); ok {
+		return rf(ctx, params)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, services.AuditListParams) []models.AuditEntry); ok {
+		r0 = rf(ctx, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.AuditEntry)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, services.AuditListParams) error); ok {
+		r1 = rf(ctx, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AuditService_GetEntries_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetEntries'
+type AuditService_GetEntries_Call struct {
+	*mock.Call
+}
+
+// GetEntries is a helper method to define mock.On call
+//   - ctx context.Context
+//   - params services.AuditListParams
+func (_e *AuditService_Expecter) GetEntries(ctx interface{}, params interface{}) *AuditService_GetEntries_Call {
+	return &AuditService_GetEntries_Call{Call: _e.mock.On("GetEntries", ctx, params)}
+}
+
+func (_c *AuditService_GetEntries_Call) Run(run func(ctx context.Context, params services.AuditListParams)) *AuditService_GetEntries_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(services.AuditListParams))
+	})
+	return _c
+}
+
+func (_c *AuditService_GetEntries_Call) Return(_a0 []models.AuditEntry, _a1 error) *AuditService_GetEntries_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *AuditService_GetEntries_Call) RunAndReturn(run func(context.Context, services.AuditListParams) ([]models.AuditEntry, error)) *AuditService_GetEntries_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewAuditService creates a new instance of AuditService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewAuditService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *AuditService {
+	mock := &AuditService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -64,9 +64,9 @@ func (_c *AuthUtil_CheckPassword_Call) RunAndReturn(run func(string, string) boo
 	return _c
 }
 
-// GenerateAccessToken provides a mock function with given fields: role, secret
-func (_m *AuthUtil) GenerateAccessToken(role string, secret string) (string, error) {
-	ret := _m.Called(role, secret)
+// GenerateAccessToken provides a mock function with given fields: userID, email, role
+func (_m *AuthUtil) GenerateAccessToken(userID string, email string, role string) (string, error) {
+	ret := _m.Called(userID, email, role)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GenerateAccessToken")
@@ -74,17 +74,17 @@ func (_m *AuthUtil) GenerateAccessToken(role string, secret string) (string, err
 
 	var r0 string
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string, string) (string, error)); ok {
-		return rf(role, secret)
+	if rf, ok := ret.Get(0).(func(string, string, string) (string, error)); ok {
+		return rf(userID, email, role)
 	}
-	if rf, ok := ret.Get(0).(func(string, string) string); ok {
-		r0 = rf(role, secret)
+	if rf, ok := ret.Get(0).(func(string, string, string) string); ok {
+		r0 = rf(userID, email, role)
 	} else {
 		r0 = ret.Get(0).(string)
 	}
 
-	if rf, ok := ret.Get(1).(func(string, string) error); ok {
-		r1 = rf(role, secret)
+	if rf, ok := ret.Get(1).(func(string, string, string) error); ok {
+		r1 = rf(userID, email, role)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -98,15 +98,16 @@ type AuthUtil_GenerateAccessToken_Call struct {
 }
 
 // GenerateAccessToken is a helper method to define mock.On call
+//   - userID string
+//   - email string
 //   - role string
-//   - secret string
-func (_e *AuthUtil_Expecter) GenerateAccessToken(role interface{}, secret interface{}) *AuthUtil_GenerateAccessToken_Call {
-	return &AuthUtil_GenerateAccessToken_Call{Call: _e.mock.On("GenerateAccessToken", role, secret)}
+func (_e *AuthUtil_Expecter) GenerateAccessToken(userID interface{}, email interface{}, role interface{}) *AuthUtil_GenerateAccessToken_Call {
+	return &AuthUtil_GenerateAccessToken_Call{Call: _e.mock.On("GenerateAccessToken", userID, email, role)}
 }
 
-func (_c *AuthUtil_GenerateAccessToken_Call) Run(run func(role string, secret string)) *AuthUtil_GenerateAccessToken_Call {
+func (_c *AuthUtil_GenerateAccessToken_Call) Run(run func(userID string, email string, role string)) *AuthUtil_GenerateAccessToken_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string), args[1].(string))
+		run(args[0].(string), args[1].(string), args[2].(string))
 	})
 	return _c
 }
@@ -116,7 +117,7 @@ func (_c *AuthUtil_GenerateAccessToken_Call) Return(_a0 string, _a1 error) *Auth
 	return _c
 }
 
-func (_c *AuthUtil_GenerateAccessToken_Call) RunAndReturn(run func(string, string) (string, error)) *AuthUtil_GenerateAccessToken_Call {
+func (_c *AuthUtil_GenerateAccessToken_Call) RunAndReturn(run func(string, string, string) (string, error)) *AuthUtil_GenerateAccessToken_Call {
 	_c.Call.Return(run)
 	return _c
 }
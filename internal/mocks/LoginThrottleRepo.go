@@ -0,0 +1,317 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	models "github.com/forzeyy/avito-internship-spring-service/internal/models"
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// LoginThrottleRepo is an autogenerated mock type for the LoginThrottleRepo type
+type LoginThrottleRepo struct {
+	mock.Mock
+}
+
+type LoginThrottleRepo_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *LoginThrottleRepo) EXPECT() *LoginThrottleRepo_Expecter {
+	return &LoginThrottleRepo_Expecter{mock: &_m.Mock}
+}
+
+// GetActiveLockouts provides a mock function with given fields: ctx, now
+func (_m *LoginThrottleRepo) GetActiveLockouts(ctx context.Context, now time.Time) ([]models.LoginThrottle, error) {
+	ret := _m.Called(ctx, now)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetActiveLockouts")
+	}
+
+	var r0 []models.LoginThrottle
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) ([]models.LoginThrottle, error)); ok {
+		return rf(ctx, now)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) []models.LoginThrottle); ok {
+		r0 = rf(ctx, now)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.LoginThrottle)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LoginThrottleRepo_GetActiveLockouts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetActiveLockouts'
+type LoginThrottleRepo_GetActiveLockouts_Call struct {
+	*mock.Call
+}
+
+// GetActiveLockouts is a helper method to define mock.On call
+//   - ctx context.Context
+//   - now time.Time
+func (_e *LoginThrottleRepo_Expecter) GetActiveLockouts(ctx interface{}, now interface{}) *LoginThrottleRepo_GetActiveLockouts_Call {
+	return &LoginThrottleRepo_GetActiveLockouts_Call{Call: _e.mock.On("GetActiveLockouts", ctx, now)}
+}
+
+func (_c *LoginThrottleRepo_GetActiveLockouts_Call) Run(run func(ctx context.Context, now time.Time)) *LoginThrottleRepo_GetActiveLockouts_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time))
+	})
+	return _c
+}
+
+func (_c *LoginThrottleRepo_GetActiveLockouts_Call) Return(_a0 []models.LoginThrottle, _a1 error) *LoginThrottleRepo_GetActiveLockouts_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *LoginThrottleRepo_GetActiveLockouts_Call) RunAndReturn(run func(context.Context, time.Time) ([]models.LoginThrottle, error)) *LoginThrottleRepo_GetActiveLockouts_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetLoginThrottle provides a mock function with given fields: ctx, scope, key
+func (_m *LoginThrottleRepo) GetLoginThrottle(ctx context.Context, scope string, key string) (*models.LoginThrottle, error) {
+	ret := _m.Called(ctx, scope, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLoginThrottle")
+	}
+
+	var r0 *models.LoginThrottle
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*models.LoginThrottle, error)); ok {
+		return rf(ctx, scope, key)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *models.LoginThrottle); ok {
+		r0 = rf(ctx, scope, key)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.LoginThrottle)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, scope, key)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LoginThrottleRepo_GetLoginThrottle_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLoginThrottle'
+type LoginThrottleRepo_GetLoginThrottle_Call struct {
+	*mock.Call
+}
+
+// GetLoginThrottle is a helper method to define mock.On call
+//   - ctx context.Context
+//   - scope string
+//   - key string
+func (_e *LoginThrottleRepo_Expecter) GetLoginThrottle(ctx interface{}, scope interface{}, key interface{}) *LoginThrottleRepo_GetLoginThrottle_Call {
+	return &LoginThrottleRepo_GetLoginThrottle_Call{Call: _e.mock.On("GetLoginThrottle", ctx, scope, key)}
+}
+
+func (_c *LoginThrottleRepo_GetLoginThrottle_Call) Run(run func(ctx context.Context, scope string, key string)) *LoginThrottleRepo_GetLoginThrottle_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *LoginThrottleRepo_GetLoginThrottle_Call) Return(_a0 *models.LoginThrottle, _a1 error) *LoginThrottleRepo_GetLoginThrottle_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *LoginThrottleRepo_GetLoginThrottle_Call) RunAndReturn(run func(context.Context, string, string) (*models.LoginThrottle, error)) *LoginThrottleRepo_GetLoginThrottle_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// LockLogin provides a mock function with given fields: ctx, scope, key, until
+func (_m *LoginThrottleRepo) LockLogin(ctx context.Context, scope string, key string, until time.Time) error {
+	ret := _m.Called(ctx, scope, key, until)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LockLogin")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Time) error); ok {
+		r0 = rf(ctx, scope, key, until)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// LoginThrottleRepo_LockLogin_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LockLogin'
+type LoginThrottleRepo_LockLogin_Call struct {
+	*mock.Call
+}
+
+// LockLogin is a helper method to define mock.On call
+//   - ctx context.Context
+//   - scope string
+//   - key string
+//   - until time.Time
+func (_e *LoginThrottleRepo_Expecter) LockLogin(ctx interface{}, scope interface{}, key interface{}, until interface{}) *LoginThrottleRepo_LockLogin_Call {
+	return &LoginThrottleRepo_LockLogin_Call{Call: _e.mock.On("LockLogin", ctx, scope, key, until)}
+}
+
+func (_c *LoginThrottleRepo_LockLogin_Call) Run(run func(ctx context.Context, scope string, key string, until time.Time)) *LoginThrottleRepo_LockLogin_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(time.Time))
+	})
+	return _c
+}
+
+func (_c *LoginThrottleRepo_LockLogin_Call) Return(_a0 error) *LoginThrottleRepo_LockLogin_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *LoginThrottleRepo_LockLogin_Call) RunAndReturn(run func(context.Context, string, string, time.Time) error) *LoginThrottleRepo_LockLogin_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RegisterLoginFailure provides a mock function with given fields: ctx, scope, key, at, resetBefore
+func (_m *LoginThrottleRepo) RegisterLoginFailure(ctx context.Context, scope string, key string, at time.Time, resetBefore time.Time) (*models.LoginThrottle, error) {
+	ret := _m.Called(ctx, scope, key, at, resetBefore)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RegisterLoginFailure")
+	}
+
+	var r0 *models.LoginThrottle
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Time, time.Time) (*models.LoginThrottle, error)); ok {
+		return rf(ctx, scope, key, at, resetBefore)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Time, time.Time) *models.LoginThrottle); ok {
+		r0 = rf(ctx, scope, key, at, resetBefore)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.LoginThrottle)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, time.Time, time.Time) error); ok {
+		r1 = rf(ctx, scope, key, at, resetBefore)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LoginThrottleRepo_RegisterLoginFailure_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RegisterLoginFailure'
+type LoginThrottleRepo_RegisterLoginFailure_Call struct {
+	*mock.Call
+}
+
+// RegisterLoginFailure is a helper method to define mock.On call
+//   - ctx context.Context
+//   - scope string
+//   - key string
+//   - at time.Time
+//   - resetBefore time.Time
+func (_e *LoginThrottleRepo_Expecter) RegisterLoginFailure(ctx interface{}, scope interface{}, key interface{}, at interface{}, resetBefore interface{}) *LoginThrottleRepo_RegisterLoginFailure_Call {
+	return &LoginThrottleRepo_RegisterLoginFailure_Call{Call: _e.mock.On("RegisterLoginFailure", ctx, scope, key, at, resetBefore)}
+}
+
+func (_c *LoginThrottleRepo_RegisterLoginFailure_Call) Run(run func(ctx context.Context, scope string, key string, at time.Time, resetBefore time.Time)) *LoginThrottleRepo_RegisterLoginFailure_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(time.Time), args[4].(time.Time))
+	})
+	return _c
+}
+
+func (_c *LoginThrottleRepo_RegisterLoginFailure_Call) Return(_a0 *models.LoginThrottle, _a1 error) *LoginThrottleRepo_RegisterLoginFailure_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *LoginThrottleRepo_RegisterLoginFailure_Call) RunAndReturn(run func(context.Context, string, string, time.Time, time.Time) (*models.LoginThrottle, error)) *LoginThrottleRepo_RegisterLoginFailure_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ResetLoginThrottle provides a mock function with given fields: ctx, scope, key
+func (_m *LoginThrottleRepo) ResetLoginThrottle(ctx context.Context, scope string, key string) error {
+	ret := _m.Called(ctx, scope, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ResetLoginThrottle")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, scope, key)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// LoginThrottleRepo_ResetLoginThrottle_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ResetLoginThrottle'
+type LoginThrottleRepo_ResetLoginThrottle_Call struct {
+	*mock.Call
+}
+
+// ResetLoginThrottle is a helper method to define mock.On call
+//   - ctx context.Context
+//   - scope string
+//   - key string
+func (_e *LoginThrottleRepo_Expecter) ResetLoginThrottle(ctx interface{}, scope interface{}, key interface{}) *LoginThrottleRepo_ResetLoginThrottle_Call {
+	return &LoginThrottleRepo_ResetLoginThrottle_Call{Call: _e.mock.On("ResetLoginThrottle", ctx, scope, key)}
+}
+
+func (_c *LoginThrottleRepo_ResetLoginThrottle_Call) Run(run func(ctx context.Context, scope string, key string)) *LoginThrottleRepo_ResetLoginThrottle_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *LoginThrottleRepo_ResetLoginThrottle_Call) Return(_a0 error) *LoginThrottleRepo_ResetLoginThrottle_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *LoginThrottleRepo_ResetLoginThrottle_Call) RunAndReturn(run func(context.Context, string, string) error) *LoginThrottleRepo_ResetLoginThrottle_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewLoginThrottleRepo creates a new instance of LoginThrottleRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewLoginThrottleRepo(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *LoginThrottleRepo {
+	mock := &LoginThrottleRepo{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,251 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	models "github.com/forzeyy/avito-internship-spring-service/internal/models"
+	mock "github.com/stretchr/testify/mock"
+
+	uuid "github.com/google/uuid"
+)
+
+// PVZAssignmentRepo is an autogenerated mock type for the PVZAssignmentRepo type
+type PVZAssignmentRepo struct {
+	mock.Mock
+}
+
+type PVZAssignmentRepo_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *PVZAssignmentRepo) EXPECT() *PVZAssignmentRepo_Expecter {
+	return &PVZAssignmentRepo_Expecter{mock: &_m.Mock}
+}
+
+// AssignEmployee provides a mock function with given fields: ctx, assignment
+func (_m *PVZAssignmentRepo) AssignEmployee(ctx context.Context, assignment *models.PVZAssignment) error {
+	ret := _m.Called(ctx, assignment)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AssignEmployee")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.PVZAssignment) error); ok {
+		r0 = rf(ctx, assignment)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// PVZAssignmentRepo_AssignEmployee_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AssignEmployee'
+type PVZAssignmentRepo_AssignEmployee_Call struct {
+	*mock.Call
+}
+
+// AssignEmployee is a helper method to define mock.On call
+//   - ctx context.Context
+//   - assignment *models.PVZAssignment
+func (_e *PVZAssignmentRepo_Expecter) AssignEmployee(ctx interface{}, assignment interface{}) *PVZAssignmentRepo_AssignEmployee_Call {
+	return &PVZAssignmentRepo_AssignEmployee_Call{Call: _e.mock.On("AssignEmployee", ctx, assignment)}
+}
+
+func (_c *PVZAssignmentRepo_AssignEmployee_Call) Run(run func(ctx context.Context, assignment *models.PVZAssignment)) *PVZAssignmentRepo_AssignEmployee_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.PVZAssignment))
+	})
+	return _c
+}
+
+func (_c *PVZAssignmentRepo_AssignEmployee_Call) Return(_a0 error) *PVZAssignmentRepo_AssignEmployee_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *PVZAssignmentRepo_AssignEmployee_Call) RunAndReturn(run func(context.Context, *models.PVZAssignment) error) *PVZAssignmentRepo_AssignEmployee_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAssignments provides a mock function with given fields: ctx, pvzID
+func (_m *PVZAssignmentRepo) GetAssignments(ctx context.Context, pvzID uuid.UUID) ([]models.PVZAssignment, error) {
+	ret := _m.Called(ctx, pvzID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAssignments")
+	}
+
+	var r0 []models.PVZAssignment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]models.PVZAssignment, error)); ok {
+		return rf(ctx, pvzID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) []models.PVZAssignment); ok {
+		r0 = rf(ctx, pvzID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.PVZAssignment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, pvzID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PVZAssignmentRepo_GetAssignments_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAssignments'
+type PVZAssignmentRepo_GetAssignments_Call struct {
+	*mock.Call
+}
+
+// GetAssignments is a helper method to define mock.On call
+//   - ctx context.Context
+//   - pvzID uuid.UUID
+func (_e *PVZAssignmentRepo_Expecter) GetAssignments(ctx interface{}, pvzID interface{}) *PVZAssignmentRepo_GetAssignments_Call {
+	return &PVZAssignmentRepo_GetAssignments_Call{Call: _e.mock.On("GetAssignments", ctx, pvzID)}
+}
+
+func (_c *PVZAssignmentRepo_GetAssignments_Call) Run(run func(ctx context.Context, pvzID uuid.UUID)) *PVZAssignmentRepo_GetAssignments_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *PVZAssignmentRepo_GetAssignments_Call) Return(_a0 []models.PVZAssignment, _a1 error) *PVZAssignmentRepo_GetAssignments_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *PVZAssignmentRepo_GetAssignments_Call) RunAndReturn(run func(context.Context, uuid.UUID) ([]models.PVZAssignment, error)) *PVZAssignmentRepo_GetAssignments_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// IsAssigned provides a mock function with given fields: ctx, pvzID, userID
+func (_m *PVZAssignmentRepo) IsAssigned(ctx context.Context, pvzID uuid.UUID, userID uuid.UUID) (bool, error) {
+	ret := _m.Called(ctx, pvzID, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IsAssigned")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) (bool, error)); ok {
+		return rf(ctx, pvzID, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) bool); ok {
+		r0 = rf(ctx, pvzID, userID)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
+		r1 = rf(ctx, pvzID, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PVZAssignmentRepo_IsAssigned_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IsAssigned'
+type PVZAssignmentRepo_IsAssigned_Call struct {
+	*mock.Call
+}
+
+// IsAssigned is a helper method to define mock.On call
+//   - ctx context.Context
+//   - pvzID uuid.UUID
+//   - userID uuid.UUID
+func (_e *PVZAssignmentRepo_Expecter) IsAssigned(ctx interface{}, pvzID interface{}, userID interface{}) *PVZAssignmentRepo_IsAssigned_Call {
+	return &PVZAssignmentRepo_IsAssigned_Call{Call: _e.mock.On("IsAssigned", ctx, pvzID, userID)}
+}
+
+func (_c *PVZAssignmentRepo_IsAssigned_Call) Run(run func(ctx context.Context, pvzID uuid.UUID, userID uuid.UUID)) *PVZAssignmentRepo_IsAssigned_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *PVZAssignmentRepo_IsAssigned_Call) Return(_a0 bool, _a1 error) *PVZAssignmentRepo_IsAssigned_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *PVZAssignmentRepo_IsAssigned_Call) RunAndReturn(run func(context.Context, uuid.UUID, uuid.UUID) (bool, error)) *PVZAssignmentRepo_IsAssigned_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UnassignEmployee provides a mock function with given fields: ctx, pvzID, userID
+func (_m *PVZAssignmentRepo) UnassignEmployee(ctx context.Context, pvzID uuid.UUID, userID uuid.UUID) error {
+	ret := _m.Called(ctx, pvzID, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UnassignEmployee")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
+		r0 = rf(ctx, pvzID, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// PVZAssignmentRepo_UnassignEmployee_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UnassignEmployee'
+type PVZAssignmentRepo_UnassignEmployee_Call struct {
+	*mock.Call
+}
+
+// UnassignEmployee is a helper method to define mock.On call
+//   - ctx context.Context
+//   - pvzID uuid.UUID
+//   - userID uuid.UUID
+func (_e *PVZAssignmentRepo_Expecter) UnassignEmployee(ctx interface{}, pvzID interface{}, userID interface{}) *PVZAssignmentRepo_UnassignEmployee_Call {
+	return &PVZAssignmentRepo_UnassignEmployee_Call{Call: _e.mock.On("UnassignEmployee", ctx, pvzID, userID)}
+}
+
+func (_c *PVZAssignmentRepo_UnassignEmployee_Call) Run(run func(ctx context.Context, pvzID uuid.UUID, userID uuid.UUID)) *PVZAssignmentRepo_UnassignEmployee_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *PVZAssignmentRepo_UnassignEmployee_Call) Return(_a0 error) *PVZAssignmentRepo_UnassignEmployee_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *PVZAssignmentRepo_UnassignEmployee_Call) RunAndReturn(run func(context.Context, uuid.UUID, uuid.UUID) error) *PVZAssignmentRepo_UnassignEmployee_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewPVZAssignmentRepo creates a new instance of PVZAssignmentRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewPVZAssignmentRepo(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *PVZAssignmentRepo {
+	mock := &PVZAssignmentRepo{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
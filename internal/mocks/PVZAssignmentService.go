@@ -0,0 +1,204 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	models "github.com/forzeyy/avito-internship-spring-service/internal/models"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// PVZAssignmentService is an autogenerated mock type for the PVZAssignmentService type
+type PVZAssignmentService struct {
+	mock.Mock
+}
+
+type PVZAssignmentService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *PVZAssignmentService) EXPECT() *PVZAssignmentService_Expecter {
+	return &PVZAssignmentService_Expecter{mock: &_m.Mock}
+}
+
+// AssignEmployee provides a mock function with given fields: ctx, pvzID, userID
+func (_m *PVZAssignmentService) AssignEmployee(ctx context.Context, pvzID string, userID string) (*models.PVZAssignment, error) {
+	ret := _m.Called(ctx, pvzID, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AssignEmployee")
+	}
+
+	var r0 *models.PVZAssignment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*models.PVZAssignment, error)); ok {
+		return rf(ctx, pvzID, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *models.PVZAssignment); ok {
+		r0 = rf(ctx, pvzID, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.PVZAssignment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, pvzID, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PVZAssignmentService_AssignEmployee_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AssignEmployee'
+type PVZAssignmentService_AssignEmployee_Call struct {
+	*mock.Call
+}
+
+// AssignEmployee is a helper method to define mock.On call
+//   - ctx context.Context
+//   - pvzID string
+//   - userID string
+func (_e *PVZAssignmentService_Expecter) AssignEmployee(ctx interface{}, pvzID interface{}, userID interface{}) *PVZAssignmentService_AssignEmployee_Call {
+	return &PVZAssignmentService_AssignEmployee_Call{Call: _e.mock.On("AssignEmployee", ctx, pvzID, userID)}
+}
+
+func (_c *PVZAssignmentService_AssignEmployee_Call) Run(run func(ctx context.Context, pvzID string, userID string)) *PVZAssignmentService_AssignEmployee_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *PVZAssignmentService_AssignEmployee_Call) Return(_a0 *models.PVZAssignment, _a1 error) *PVZAssignmentService_AssignEmployee_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *PVZAssignmentService_AssignEmployee_Call) RunAndReturn(run func(context.Context, string, string) (*models.PVZAssignment, error)) *PVZAssignmentService_AssignEmployee_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAssignments provides a mock function with given fields: ctx, pvzID
+func (_m *PVZAssignmentService) GetAssignments(ctx context.Context, pvzID string) ([]models.PVZAssignment, error) {
+	ret := _m.Called(ctx, pvzID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAssignments")
+	}
+
+	var r0 []models.PVZAssignment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]models.PVZAssignment, error)); ok {
+		return rf(ctx, pvzID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []models.PVZAssignment); ok {
+		r0 = rf(ctx, pvzID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.PVZAssignment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, pvzID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PVZAssignmentService_GetAssignments_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAssignments'
+type PVZAssignmentService_GetAssignments_Call struct {
+	*mock.Call
+}
+
+// GetAssignments is a helper method to define mock.On call
+//   - ctx context.Context
+//   - pvzID string
+func (_e *PVZAssignmentService_Expecter) GetAssignments(ctx interface{}, pvzID interface{}) *PVZAssignmentService_GetAssignments_Call {
+	return &PVZAssignmentService_GetAssignments_Call{Call: _e.mock.On("GetAssignments", ctx, pvzID)}
+}
+
+func (_c *PVZAssignmentService_GetAssignments_Call) Run(run func(ctx context.Context, pvzID string)) *PVZAssignmentService_GetAssignments_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *PVZAssignmentService_GetAssignments_Call) Return(_a0 []models.PVZAssignment, _a1 error) *PVZAssignmentService_GetAssignments_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *PVZAssignmentService_GetAssignments_Call) RunAndReturn(run func(context.Context, string) ([]models.PVZAssignment, error)) *PVZAssignmentService_GetAssignments_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UnassignEmployee provides a mock function with given fields: ctx, pvzID, userID
+func (_m *PVZAssignmentService) UnassignEmployee(ctx context.Context, pvzID string, userID string) error {
+	ret := _m.Called(ctx, pvzID, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UnassignEmployee")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, pvzID, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// PVZAssignmentService_UnassignEmployee_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UnassignEmployee'
+type PVZAssignmentService_UnassignEmployee_Call struct {
+	*mock.Call
+}
+
+// UnassignEmployee is a helper method to define mock.On call
+//   - ctx context.Context
+//   - pvzID string
+//   - userID string
+func (_e *PVZAssignmentService_Expecter) UnassignEmployee(ctx interface{}, pvzID interface{}, userID interface{}) *PVZAssignmentService_UnassignEmployee_Call {
+	return &PVZAssignmentService_UnassignEmployee_Call{Call: _e.mock.On("UnassignEmployee", ctx, pvzID, userID)}
+}
+
+func (_c *PVZAssignmentService_UnassignEmployee_Call) Run(run func(ctx context.Context, pvzID string, userID string)) *PVZAssignmentService_UnassignEmployee_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *PVZAssignmentService_UnassignEmployee_Call) Return(_a0 error) *PVZAssignmentService_UnassignEmployee_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *PVZAssignmentService_UnassignEmployee_Call) RunAndReturn(run func(context.Context, string, string) error) *PVZAssignmentService_UnassignEmployee_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewPVZAssignmentService creates a new instance of PVZAssignmentService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewPVZAssignmentService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *PVZAssignmentService {
+	mock := &PVZAssignmentService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -9,6 +9,8 @@ import (
 	mock "github.com/stretchr/testify/mock"
 
 	time "time"
+
+	uuid "github.com/google/uuid"
 )
 
 // PVZRepo is an autogenerated mock type for the PVZRepo type
@@ -71,6 +73,65 @@ func (_c *PVZRepo_CreatePVZ_Call) RunAndReturn(run func(context.Context, *models
 	return _c
 }
 
+// GetPVZByID provides a mock function with given fields: ctx, id
+func (_m *PVZRepo) GetPVZByID(ctx context.Context, id uuid.UUID) (*models.PVZ, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPVZByID")
+	}
+
+	var r0 *models.PVZ
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*models.PVZ, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *models.PVZ); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.PVZ)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PVZRepo_GetPVZByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPVZByID'
+type PVZRepo_GetPVZByID_Call struct {
+	*mock.Call
+}
+
+// GetPVZByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+func (_e *PVZRepo_Expecter) GetPVZByID(ctx interface{}, id interface{}) *PVZRepo_GetPVZByID_Call {
+	return &PVZRepo_GetPVZByID_Call{Call: _e.mock.On("GetPVZByID", ctx, id)}
+}
+
+func (_c *PVZRepo_GetPVZByID_Call) Run(run func(ctx context.Context, id uuid.UUID)) *PVZRepo_GetPVZByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *PVZRepo_GetPVZByID_Call) Return(_a0 *models.PVZ, _a1 error) *PVZRepo_GetPVZByID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *PVZRepo_GetPVZByID_Call) RunAndReturn(run func(context.Context, uuid.UUID) (*models.PVZ, error)) *PVZRepo_GetPVZByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetPVZs provides a mock function with given fields: ctx, startDate, endDate, page, limit
 func (_m *PVZRepo) GetPVZs(ctx context.Context, startDate *time.Time, endDate *time.Time, page int, limit int) ([]models.PVZ, error) {
 	ret := _m.Called(ctx, startDate, endDate, page, limit)
@@ -133,6 +194,68 @@ func (_c *PVZRepo_GetPVZs_Call) RunAndReturn(run func(context.Context, *time.Tim
 	return _c
 }
 
+// GetPVZsAfter provides a mock function with given fields: ctx, startDate, endDate, after, limit
+func (_m *PVZRepo) GetPVZsAfter(ctx context.Context, startDate *time.Time, endDate *time.Time, after *models.PVZCursor, limit int) ([]models.PVZ, error) {
+	ret := _m.Called(ctx, startDate, endDate, after, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPVZsAfter")
+	}
+
+	var r0 []models.PVZ
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *time.Time, *time.Time, *models.PVZCursor, int) ([]models.PVZ, error)); ok {
+		return rf(ctx, startDate, endDate, after, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *time.Time, *time.Time, *models.PVZCursor, int) []models.PVZ); ok {
+		r0 = rf(ctx, startDate, endDate, after, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.PVZ)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *time.Time, *time.Time, *models.PVZCursor, int) error); ok {
+		r1 = rf(ctx, startDate, endDate, after, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PVZRepo_GetPVZsAfter_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPVZsAfter'
+type PVZRepo_GetPVZsAfter_Call struct {
+	*mock.Call
+}
+
+// GetPVZsAfter is a helper method to define mock.On call
+//   - ctx context.Context
+//   - startDate *time.Time
+//   - endDate *time.Time
+//   - after *models.PVZCursor
+//   - limit int
+func (_e *PVZRepo_Expecter) GetPVZsAfter(ctx interface{}, startDate interface{}, endDate interface{}, after interface{}, limit interface{}) *PVZRepo_GetPVZsAfter_Call {
+	return &PVZRepo_GetPVZsAfter_Call{Call: _e.mock.On("GetPVZsAfter", ctx, startDate, endDate, after, limit)}
+}
+
+func (_c *PVZRepo_GetPVZsAfter_Call) Run(run func(ctx context.Context, startDate *time.Time, endDate *time.Time, after *models.PVZCursor, limit int)) *PVZRepo_GetPVZsAfter_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*time.Time), args[2].(*time.Time), args[3].(*models.PVZCursor), args[4].(int))
+	})
+	return _c
+}
+
+func (_c *PVZRepo_GetPVZsAfter_Call) Return(_a0 []models.PVZ, _a1 error) *PVZRepo_GetPVZsAfter_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *PVZRepo_GetPVZsAfter_Call) RunAndReturn(run func(context.Context, *time.Time, *time.Time, *models.PVZCursor, int) ([]models.PVZ, error)) *PVZRepo_GetPVZsAfter_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewPVZRepo creates a new instance of PVZRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewPVZRepo(t interface {
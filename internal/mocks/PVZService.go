@@ -83,24 +83,85 @@ func (_c *PVZService_CreatePVZ_Call) RunAndReturn(run func(context.Context, stri
 	return _c
 }
 
+// GetPVZDetails provides a mock function with given fields: ctx, pvzID, page, limit
+func (_m *PVZService) GetPVZDetails(ctx context.Context, pvzID string, page int, limit int) (*models.PVZDetails, error) {
+	ret := _m.Called(ctx, pvzID, page, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPVZDetails")
+	}
+
+	var r0 *models.PVZDetails
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, int) (*models.PVZDetails, error)); ok {
+		return rf(ctx, pvzID, page, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, int) *models.PVZDetails); ok {
+		r0 = rf(ctx, pvzID, page, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.PVZDetails)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int, int) error); ok {
+		r1 = rf(ctx, pvzID, page, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PVZService_GetPVZDetails_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPVZDetails'
+type PVZService_GetPVZDetails_Call struct {
+	*mock.Call
+}
+
+// GetPVZDetails is a helper method to define mock.On call
+//   - ctx context.Context
+//   - pvzID string
+//   - page int
+//   - limit int
+func (_e *PVZService_Expecter) GetPVZDetails(ctx interface{}, pvzID interface{}, page interface{}, limit interface{}) *PVZService_GetPVZDetails_Call {
+	return &PVZService_GetPVZDetails_Call{Call: _e.mock.On("GetPVZDetails", ctx, pvzID, page, limit)}
+}
+
+func (_c *PVZService_GetPVZDetails_Call) Run(run func(ctx context.Context, pvzID string, page int, limit int)) *PVZService_GetPVZDetails_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(int), args[3].(int))
+	})
+	return _c
+}
+
+func (_c *PVZService_GetPVZDetails_Call) Return(_a0 *models.PVZDetails, _a1 error) *PVZService_GetPVZDetails_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *PVZService_GetPVZDetails_Call) RunAndReturn(run func(context.Context, string, int, int) (*models.PVZDetails, error)) *PVZService_GetPVZDetails_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetPVZs provides a mock function with given fields: ctx, startDate, endDate, page, limit
-func (_m *PVZService) GetPVZs(ctx context.Context, startDate *time.Time, endDate *time.Time, page int, limit int) ([]models.PVZ, error) {
+func (_m *PVZService) GetPVZs(ctx context.Context, startDate *time.Time, endDate *time.Time, page int, limit int) ([]models.PVZWithReceptions, error) {
 	ret := _m.Called(ctx, startDate, endDate, page, limit)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetPVZs")
 	}
 
-	var r0 []models.PVZ
+	var r0 []models.PVZWithReceptions
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, *time.Time, *time.Time, int, int) ([]models.PVZ, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, *time.Time, *time.Time, int, int) ([]models.PVZWithReceptions, error)); ok {
 		return rf(ctx, startDate, endDate, page, limit)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, *time.Time, *time.Time, int, int) []models.PVZ); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, *time.Time, *time.Time, int, int) []models.PVZWithReceptions); ok {
 		r0 = rf(ctx, startDate, endDate, page, limit)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]models.PVZ)
+			r0 = ret.Get(0).([]models.PVZWithReceptions)
 		}
 	}
 
@@ -135,12 +196,148 @@ func (_c *PVZService_GetPVZs_Call) Run(run func(ctx context.Context, startDate *
 	return _c
 }
 
-func (_c *PVZService_GetPVZs_Call) Return(_a0 []models.PVZ, _a1 error) *PVZService_GetPVZs_Call {
+func (_c *PVZService_GetPVZs_Call) Return(_a0 []models.PVZWithReceptions, _a1 error) *PVZService_GetPVZs_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *PVZService_GetPVZs_Call) RunAndReturn(run func(context.Context, *time.Time, *time.Time, int, int) ([]models.PVZ, error)) *PVZService_GetPVZs_Call {
+func (_c *PVZService_GetPVZs_Call) RunAndReturn(run func(context.Context, *time.Time, *time.Time, int, int) ([]models.PVZWithReceptions, error)) *PVZService_GetPVZs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPVZsByCursor provides a mock function with given fields: ctx, startDate, endDate, cursor, limit
+func (_m *PVZService) GetPVZsByCursor(ctx context.Context, startDate *time.Time, endDate *time.Time, cursor string, limit int) ([]models.PVZWithReceptions, string, error) {
+	ret := _m.Called(ctx, startDate, endDate, cursor, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPVZsByCursor")
+	}
+
+	var r0 []models.PVZWithReceptions
+	var r1 string
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, *time.Time, *time.Time, string, int) ([]models.PVZWithReceptions, string, error)); ok {
+		return rf(ctx, startDate, endDate, cursor, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *time.Time, *time.Time, string, int) []models.PVZWithReceptions); ok {
+		r0 = rf(ctx, startDate, endDate, cursor, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.PVZWithReceptions)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *time.Time, *time.Time, string, int) string); ok {
+		r1 = rf(ctx, startDate, endDate, cursor, limit)
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, *time.Time, *time.Time, string, int) error); ok {
+		r2 = rf(ctx, startDate, endDate, cursor, limit)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// PVZService_GetPVZsByCursor_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPVZsByCursor'
+type PVZService_GetPVZsByCursor_Call struct {
+	*mock.Call
+}
+
+// GetPVZsByCursor is a helper method to define mock.On call
+//   - ctx context.Context
+//   - startDate *time.Time
+//   - endDate *time.Time
+//   - cursor string
+//   - limit int
+func (_e *PVZService_Expecter) GetPVZsByCursor(ctx interface{}, startDate interface{}, endDate interface{}, cursor interface{}, limit interface{}) *PVZService_GetPVZsByCursor_Call {
+	return &PVZService_GetPVZsByCursor_Call{Call: _e.mock.On("GetPVZsByCursor", ctx, startDate, endDate, cursor, limit)}
+}
+
+func (_c *PVZService_GetPVZsByCursor_Call) Run(run func(ctx context.Context, startDate *time.Time, endDate *time.Time, cursor string, limit int)) *PVZService_GetPVZsByCursor_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*time.Time), args[2].(*time.Time), args[3].(string), args[4].(int))
+	})
+	return _c
+}
+
+func (_c *PVZService_GetPVZsByCursor_Call) Return(_a0 []models.PVZWithReceptions, _a1 string, _a2 error) *PVZService_GetPVZsByCursor_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *PVZService_GetPVZsByCursor_Call) RunAndReturn(run func(context.Context, *time.Time, *time.Time, string, int) ([]models.PVZWithReceptions, string, error)) *PVZService_GetPVZsByCursor_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListPVZs provides a mock function with given fields: ctx, cursor, limit
+func (_m *PVZService) ListPVZs(ctx context.Context, cursor string, limit int) ([]models.PVZ, string, error) {
+	ret := _m.Called(ctx, cursor, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListPVZs")
+	}
+
+	var r0 []models.PVZ
+	var r1 string
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) ([]models.PVZ, string, error)); ok {
+		return rf(ctx, cursor, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) []models.PVZ); ok {
+		r0 = rf(ctx, cursor, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.PVZ)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int) string); ok {
+		r1 = rf(ctx, cursor, limit)
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, int) error); ok {
+		r2 = rf(ctx, cursor, limit)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// PVZService_ListPVZs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListPVZs'
+type PVZService_ListPVZs_Call struct {
+	*mock.Call
+}
+
+// ListPVZs is a helper method to define mock.On call
+//   - ctx context.Context
+//   - cursor string
+//   - limit int
+func (_e *PVZService_Expecter) ListPVZs(ctx interface{}, cursor interface{}, limit interface{}) *PVZService_ListPVZs_Call {
+	return &PVZService_ListPVZs_Call{Call: _e.mock.On("ListPVZs", ctx, cursor, limit)}
+}
+
+func (_c *PVZService_ListPVZs_Call) Run(run func(ctx context.Context, cursor string, limit int)) *PVZService_ListPVZs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(int))
+	})
+	return _c
+}
+
+func (_c *PVZService_ListPVZs_Call) Return(_a0 []models.PVZ, _a1 string, _a2 error) *PVZService_ListPVZs_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *PVZService_ListPVZs_Call) RunAndReturn(run func(context.Context, string, int) ([]models.PVZ, string, error)) *PVZService_ListPVZs_Call {
 	_c.Call.Return(run)
 	return _c
 }
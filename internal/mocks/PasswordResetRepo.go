@@ -0,0 +1,192 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	models "github.com/forzeyy/avito-internship-spring-service/internal/models"
+	mock "github.com/stretchr/testify/mock"
+
+	uuid "github.com/google/uuid"
+)
+
+// PasswordResetRepo is an autogenerated mock type for the PasswordResetRepo type
+type PasswordResetRepo struct {
+	mock.Mock
+}
+
+type PasswordResetRepo_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *PasswordResetRepo) EXPECT() *PasswordResetRepo_Expecter {
+	return &PasswordResetRepo_Expecter{mock: &_m.Mock}
+}
+
+// CreateResetToken provides a mock function with given fields: ctx, token
+func (_m *PasswordResetRepo) CreateResetToken(ctx context.Context, token *models.PasswordResetToken) error {
+	ret := _m.Called(ctx, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateResetToken")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.PasswordResetToken) error); ok {
+		r0 = rf(ctx, token)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// PasswordResetRepo_CreateResetToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateResetToken'
+type PasswordResetRepo_CreateResetToken_Call struct {
+	*mock.Call
+}
+
+// CreateResetToken is a helper method to define mock.On call
+//   - ctx context.Context
+//   - token *models.PasswordResetToken
+func (_e *PasswordResetRepo_Expecter) CreateResetToken(ctx interface{}, token interface{}) *PasswordResetRepo_CreateResetToken_Call {
+	return &PasswordResetRepo_CreateResetToken_Call{Call: _e.mock.On("CreateResetToken", ctx, token)}
+}
+
+func (_c *PasswordResetRepo_CreateResetToken_Call) Run(run func(ctx context.Context, token *models.PasswordResetToken)) *PasswordResetRepo_CreateResetToken_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.PasswordResetToken))
+	})
+	return _c
+}
+
+func (_c *PasswordResetRepo_CreateResetToken_Call) Return(_a0 error) *PasswordResetRepo_CreateResetToken_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *PasswordResetRepo_CreateResetToken_Call) RunAndReturn(run func(context.Context, *models.PasswordResetToken) error) *PasswordResetRepo_CreateResetToken_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// LockResetToken provides a mock function with given fields: ctx, tokenHash
+func (_m *PasswordResetRepo) LockResetToken(ctx context.Context, tokenHash string) (*models.PasswordResetToken, error) {
+	ret := _m.Called(ctx, tokenHash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LockResetToken")
+	}
+
+	var r0 *models.PasswordResetToken
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.PasswordResetToken, error)); ok {
+		return rf(ctx, tokenHash)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.PasswordResetToken); ok {
+		r0 = rf(ctx, tokenHash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.PasswordResetToken)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, tokenHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PasswordResetRepo_LockResetToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LockResetToken'
+type PasswordResetRepo_LockResetToken_Call struct {
+	*mock.Call
+}
+
+// LockResetToken is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tokenHash string
+func (_e *PasswordResetRepo_Expecter) LockResetToken(ctx interface{}, tokenHash interface{}) *PasswordResetRepo_LockResetToken_Call {
+	return &PasswordResetRepo_LockResetToken_Call{Call: _e.mock.On("LockResetToken", ctx, tokenHash)}
+}
+
+func (_c *PasswordResetRepo_LockResetToken_Call) Run(run func(ctx context.Context, tokenHash string)) *PasswordResetRepo_LockResetToken_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *PasswordResetRepo_LockResetToken_Call) Return(_a0 *models.PasswordResetToken, _a1 error) *PasswordResetRepo_LockResetToken_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *PasswordResetRepo_LockResetToken_Call) RunAndReturn(run func(context.Context, string) (*models.PasswordResetToken, error)) *PasswordResetRepo_LockResetToken_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RevokeUserResetTokens provides a mock function with given fields: ctx, userID
+func (_m *PasswordResetRepo) RevokeUserResetTokens(ctx context.Context, userID uuid.UUID) error {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevokeUserResetTokens")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// PasswordResetRepo_RevokeUserResetTokens_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RevokeUserResetTokens'
+type PasswordResetRepo_RevokeUserResetTokens_Call struct {
+	*mock.Call
+}
+
+// RevokeUserResetTokens is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+func (_e *PasswordResetRepo_Expecter) RevokeUserResetTokens(ctx interface{}, userID interface{}) *PasswordResetRepo_RevokeUserResetTokens_Call {
+	return &PasswordResetRepo_RevokeUserResetTokens_Call{Call: _e.mock.On("RevokeUserResetTokens", ctx, userID)}
+}
+
+func (_c *PasswordResetRepo_RevokeUserResetTokens_Call) Run(run func(ctx context.Context, userID uuid.UUID)) *PasswordResetRepo_RevokeUserResetTokens_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *PasswordResetRepo_RevokeUserResetTokens_Call) Return(_a0 error) *PasswordResetRepo_RevokeUserResetTokens_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *PasswordResetRepo_RevokeUserResetTokens_Call) RunAndReturn(run func(context.Context, uuid.UUID) error) *PasswordResetRepo_RevokeUserResetTokens_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewPasswordResetRepo creates a new instance of PasswordResetRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewPasswordResetRepo(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *PasswordResetRepo {
+	mock := &PasswordResetRepo{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -71,6 +71,53 @@ func (_c *ProductRepo_AddProduct_Call) RunAndReturn(run func(context.Context, *m
 	return _c
 }
 
+// AddProducts provides a mock function with given fields: ctx, products
+func (_m *ProductRepo) AddProducts(ctx context.Context, products []models.Product) error {
+	ret := _m.Called(ctx, products)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddProducts")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []models.Product) error); ok {
+		r0 = rf(ctx, products)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ProductRepo_AddProducts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddProducts'
+type ProductRepo_AddProducts_Call struct {
+	*mock.Call
+}
+
+// AddProducts is a helper method to define mock.On call
+//   - ctx context.Context
+//   - products []models.Product
+func (_e *ProductRepo_Expecter) AddProducts(ctx interface{}, products interface{}) *ProductRepo_AddProducts_Call {
+	return &ProductRepo_AddProducts_Call{Call: _e.mock.On("AddProducts", ctx, products)}
+}
+
+func (_c *ProductRepo_AddProducts_Call) Run(run func(ctx context.Context, products []models.Product)) *ProductRepo_AddProducts_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]models.Product))
+	})
+	return _c
+}
+
+func (_c *ProductRepo_AddProducts_Call) Return(_a0 error) *ProductRepo_AddProducts_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ProductRepo_AddProducts_Call) RunAndReturn(run func(context.Context, []models.Product) error) *ProductRepo_AddProducts_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // DeleteLastProduct provides a mock function with given fields: ctx, pvzID
 func (_m *ProductRepo) DeleteLastProduct(ctx context.Context, pvzID uuid.UUID) error {
 	ret := _m.Called(ctx, pvzID)
@@ -118,6 +165,337 @@ func (_c *ProductRepo_DeleteLastProduct_Call) RunAndReturn(run func(context.Cont
 	return _c
 }
 
+// DeleteProduct provides a mock function with given fields: ctx, id
+func (_m *ProductRepo) DeleteProduct(ctx context.Context, id uuid.UUID) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteProduct")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ProductRepo_DeleteProduct_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteProduct'
+type ProductRepo_DeleteProduct_Call struct {
+	*mock.Call
+}
+
+// DeleteProduct is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+func (_e *ProductRepo_Expecter) DeleteProduct(ctx interface{}, id interface{}) *ProductRepo_DeleteProduct_Call {
+	return &ProductRepo_DeleteProduct_Call{Call: _e.mock.On("DeleteProduct", ctx, id)}
+}
+
+func (_c *ProductRepo_DeleteProduct_Call) Run(run func(ctx context.Context, id uuid.UUID)) *ProductRepo_DeleteProduct_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *ProductRepo_DeleteProduct_Call) Return(_a0 error) *ProductRepo_DeleteProduct_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ProductRepo_DeleteProduct_Call) RunAndReturn(run func(context.Context, uuid.UUID) error) *ProductRepo_DeleteProduct_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindBarcodes provides a mock function with given fields: ctx, barcodes, receptionID
+func (_m *ProductRepo) FindBarcodes(ctx context.Context, barcodes []string, receptionID *uuid.UUID) ([]string, error) {
+	ret := _m.Called(ctx, barcodes, receptionID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindBarcodes")
+	}
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string, *uuid.UUID) ([]string, error)); ok {
+		return rf(ctx, barcodes, receptionID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []string, *uuid.UUID) []string); ok {
+		r0 = rf(ctx, barcodes, receptionID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []string, *uuid.UUID) error); ok {
+		r1 = rf(ctx, barcodes, receptionID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ProductRepo_FindBarcodes_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindBarcodes'
+type ProductRepo_FindBarcodes_Call struct {
+	*mock.Call
+}
+
+// FindBarcodes is a helper method to define mock.On call
+//   - ctx context.Context
+//   - barcodes []string
+//   - receptionID *uuid.UUID
+func (_e *ProductRepo_Expecter) FindBarcodes(ctx interface{}, barcodes interface{}, receptionID interface{}) *ProductRepo_FindBarcodes_Call {
+	return &ProductRepo_FindBarcodes_Call{Call: _e.mock.On("FindBarcodes", ctx, barcodes, receptionID)}
+}
+
+func (_c *ProductRepo_FindBarcodes_Call) Run(run func(ctx context.Context, barcodes []string, receptionID *uuid.UUID)) *ProductRepo_FindBarcodes_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]string), args[2].(*uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *ProductRepo_FindBarcodes_Call) Return(_a0 []string, _a1 error) *ProductRepo_FindBarcodes_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ProductRepo_FindBarcodes_Call) RunAndReturn(run func(context.Context, []string, *uuid.UUID) ([]string, error)) *ProductRepo_FindBarcodes_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetProductByID provides a mock function with given fields: ctx, id
+func (_m *ProductRepo) GetProductByID(ctx context.Context, id uuid.UUID) (*models.Product, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetProductByID")
+	}
+
+	var r0 *models.Product
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*models.Product, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *models.Product); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Product)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ProductRepo_GetProductByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetProductByID'
+type ProductRepo_GetProductByID_Call struct {
+	*mock.Call
+}
+
+// GetProductByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+func (_e *ProductRepo_Expecter) GetProductByID(ctx interface{}, id interface{}) *ProductRepo_GetProductByID_Call {
+	return &ProductRepo_GetProductByID_Call{Call: _e.mock.On("GetProductByID", ctx, id)}
+}
+
+func (_c *ProductRepo_GetProductByID_Call) Run(run func(ctx context.Context, id uuid.UUID)) *ProductRepo_GetProductByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *ProductRepo_GetProductByID_Call) Return(_a0 *models.Product, _a1 error) *ProductRepo_GetProductByID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ProductRepo_GetProductByID_Call) RunAndReturn(run func(context.Context, uuid.UUID) (*models.Product, error)) *ProductRepo_GetProductByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetProductsByBarcode provides a mock function with given fields: ctx, barcode
+func (_m *ProductRepo) GetProductsByBarcode(ctx context.Context, barcode string) ([]models.ProductLocation, error) {
+	ret := _m.Called(ctx, barcode)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetProductsByBarcode")
+	}
+
+	var r0 []models.ProductLocation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]models.ProductLocation, error)); ok {
+		return rf(ctx, barcode)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []models.ProductLocation); ok {
+		r0 = rf(ctx, barcode)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.ProductLocation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, barcode)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ProductRepo_GetProductsByBarcode_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetProductsByBarcode'
+type ProductRepo_GetProductsByBarcode_Call struct {
+	*mock.Call
+}
+
+// GetProductsByBarcode is a helper method to define mock.On call
+//   - ctx context.Context
+//   - barcode string
+func (_e *ProductRepo_Expecter) GetProductsByBarcode(ctx interface{}, barcode interface{}) *ProductRepo_GetProductsByBarcode_Call {
+	return &ProductRepo_GetProductsByBarcode_Call{Call: _e.mock.On("GetProductsByBarcode", ctx, barcode)}
+}
+
+func (_c *ProductRepo_GetProductsByBarcode_Call) Run(run func(ctx context.Context, barcode string)) *ProductRepo_GetProductsByBarcode_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *ProductRepo_GetProductsByBarcode_Call) Return(_a0 []models.ProductLocation, _a1 error) *ProductRepo_GetProductsByBarcode_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ProductRepo_GetProductsByBarcode_Call) RunAndReturn(run func(context.Context, string) ([]models.ProductLocation, error)) *ProductRepo_GetProductsByBarcode_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetProductsByReceptionIDs provides a mock function with given fields: ctx, receptionIDs
+func (_m *ProductRepo) GetProductsByReceptionIDs(ctx context.Context, receptionIDs []uuid.UUID) ([]models.Product, error) {
+	ret := _m.Called(ctx, receptionIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetProductsByReceptionIDs")
+	}
+
+	var r0 []models.Product
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []uuid.UUID) ([]models.Product, error)); ok {
+		return rf(ctx, receptionIDs)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []uuid.UUID) []models.Product); ok {
+		r0 = rf(ctx, receptionIDs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Product)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []uuid.UUID) error); ok {
+		r1 = rf(ctx, receptionIDs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ProductRepo_GetProductsByReceptionIDs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetProductsByReceptionIDs'
+type ProductRepo_GetProductsByReceptionIDs_Call struct {
+	*mock.Call
+}
+
+// GetProductsByReceptionIDs is a helper method to define mock.On call
+//   - ctx context.Context
+//   - receptionIDs []uuid.UUID
+func (_e *ProductRepo_Expecter) GetProductsByReceptionIDs(ctx interface{}, receptionIDs interface{}) *ProductRepo_GetProductsByReceptionIDs_Call {
+	return &ProductRepo_GetProductsByReceptionIDs_Call{Call: _e.mock.On("GetProductsByReceptionIDs", ctx, receptionIDs)}
+}
+
+func (_c *ProductRepo_GetProductsByReceptionIDs_Call) Run(run func(ctx context.Context, receptionIDs []uuid.UUID)) *ProductRepo_GetProductsByReceptionIDs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *ProductRepo_GetProductsByReceptionIDs_Call) Return(_a0 []models.Product, _a1 error) *ProductRepo_GetProductsByReceptionIDs_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ProductRepo_GetProductsByReceptionIDs_Call) RunAndReturn(run func(context.Context, []uuid.UUID) ([]models.Product, error)) *ProductRepo_GetProductsByReceptionIDs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// LockBarcodes provides a mock function with given fields: ctx, barcodes
+func (_m *ProductRepo) LockBarcodes(ctx context.Context, barcodes []string) error {
+	ret := _m.Called(ctx, barcodes)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LockBarcodes")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string) error); ok {
+		r0 = rf(ctx, barcodes)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ProductRepo_LockBarcodes_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LockBarcodes'
+type ProductRepo_LockBarcodes_Call struct {
+	*mock.Call
+}
+
+// LockBarcodes is a helper method to define mock.On call
+//   - ctx context.Context
+//   - barcodes []string
+func (_e *ProductRepo_Expecter) LockBarcodes(ctx interface{}, barcodes interface{}) *ProductRepo_LockBarcodes_Call {
+	return &ProductRepo_LockBarcodes_Call{Call: _e.mock.On("LockBarcodes", ctx, barcodes)}
+}
+
+func (_c *ProductRepo_LockBarcodes_Call) Run(run func(ctx context.Context, barcodes []string)) *ProductRepo_LockBarcodes_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]string))
+	})
+	return _c
+}
+
+func (_c *ProductRepo_LockBarcodes_Call) Return(_a0 error) *ProductRepo_LockBarcodes_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ProductRepo_LockBarcodes_Call) RunAndReturn(run func(context.Context, []string) error) *ProductRepo_LockBarcodes_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewProductRepo creates a new instance of ProductRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewProductRepo(t interface {
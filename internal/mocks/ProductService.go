@@ -22,9 +22,9 @@ func (_m *ProductService) EXPECT() *ProductService_Expecter {
 	return &ProductService_Expecter{mock: &_m.Mock}
 }
 
-// AddProduct provides a mock function with given fields: ctx, productType, pvzID
-func (_m *ProductService) AddProduct(ctx context.Context, productType string, pvzID string) (*models.Product, error) {
-	ret := _m.Called(ctx, productType, pvzID)
+// AddProduct provides a mock function with given fields: ctx, productType, barcode, pvzID, userID
+func (_m *ProductService) AddProduct(ctx context.Context, productType string, barcode string, pvzID string, userID string) (*models.Product, error) {
+	ret := _m.Called(ctx, productType, barcode, pvzID, userID)
 
 	if len(ret) == 0 {
 		panic("no return value specified for AddProduct")
@@ -32,19 +32,19 @@ func (_m *ProductService) AddProduct(ctx context.Context, productType string, pv
 
 	var r0 *models.Product
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*models.Product, error)); ok {
-		return rf(ctx, productType, pvzID)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, string) (*models.Product, error)); ok {
+		return rf(ctx, productType, barcode, pvzID, userID)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) *models.Product); ok {
-		r0 = rf(ctx, productType, pvzID)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, string) *models.Product); ok {
+		r0 = rf(ctx, productType, barcode, pvzID, userID)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*models.Product)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
-		r1 = rf(ctx, productType, pvzID)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string, string) error); ok {
+		r1 = rf(ctx, productType, barcode, pvzID, userID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -60,14 +60,16 @@ type ProductService_AddProduct_Call struct {
 // AddProduct is a helper method to define mock.On call
 //   - ctx context.Context
 //   - productType string
+//   - barcode string
 //   - pvzID string
-func (_e *ProductService_Expecter) AddProduct(ctx interface{}, productType interface{}, pvzID interface{}) *ProductService_AddProduct_Call {
-	return &ProductService_AddProduct_Call{Call: _e.mock.On("AddProduct", ctx, productType, pvzID)}
+//   - userID string
+func (_e *ProductService_Expecter) AddProduct(ctx interface{}, productType interface{}, barcode interface{}, pvzID interface{}, userID interface{}) *ProductService_AddProduct_Call {
+	return &ProductService_AddProduct_Call{Call: _e.mock.On("AddProduct", ctx, productType, barcode, pvzID, userID)}
 }
 
-func (_c *ProductService_AddProduct_Call) Run(run func(ctx context.Context, productType string, pvzID string)) *ProductService_AddProduct_Call {
+func (_c *ProductService_AddProduct_Call) Run(run func(ctx context.Context, productType string, barcode string, pvzID string, userID string)) *ProductService_AddProduct_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string), args[2].(string))
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string), args[4].(string))
 	})
 	return _c
 }
@@ -77,22 +79,83 @@ func (_c *ProductService_AddProduct_Call) Return(_a0 *models.Product, _a1 error)
 	return _c
 }
 
-func (_c *ProductService_AddProduct_Call) RunAndReturn(run func(context.Context, string, string) (*models.Product, error)) *ProductService_AddProduct_Call {
+func (_c *ProductService_AddProduct_Call) RunAndReturn(run func(context.Context, string, string, string, string) (*models.Product, error)) *ProductService_AddProduct_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// AddProducts provides a mock function with given fields: ctx, items, pvzID, userID
+func (_m *ProductService) AddProducts(ctx context.Context, items []models.NewProduct, pvzID string, userID string) (*models.ProductBatch, error) {
+	ret := _m.Called(ctx, items, pvzID, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddProducts")
+	}
+
+	var r0 *models.ProductBatch
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []models.NewProduct, string, string) (*models.ProductBatch, error)); ok {
+		return rf(ctx, items, pvzID, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []models.NewProduct, string, string) *models.ProductBatch); ok {
+		r0 = rf(ctx, items, pvzID, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.ProductBatch)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []models.NewProduct, string, string) error); ok {
+		r1 = rf(ctx, items, pvzID, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ProductService_AddProducts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddProducts'
+type ProductService_AddProducts_Call struct {
+	*mock.Call
+}
+
+// AddProducts is a helper method to define mock.On call
+//   - ctx context.Context
+//   - items []models.NewProduct
+//   - pvzID string
+//   - userID string
+func (_e *ProductService_Expecter) AddProducts(ctx interface{}, items interface{}, pvzID interface{}, userID interface{}) *ProductService_AddProducts_Call {
+	return &ProductService_AddProducts_Call{Call: _e.mock.On("AddProducts", ctx, items, pvzID, userID)}
+}
+
+func (_c *ProductService_AddProducts_Call) Run(run func(ctx context.Context, items []models.NewProduct, pvzID string, userID string)) *ProductService_AddProducts_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]models.NewProduct), args[2].(string), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *ProductService_AddProducts_Call) Return(_a0 *models.ProductBatch, _a1 error) *ProductService_AddProducts_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ProductService_AddProducts_Call) RunAndReturn(run func(context.Context, []models.NewProduct, string, string) (*models.ProductBatch, error)) *ProductService_AddProducts_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// DeleteLastProduct provides a mock function with given fields: ctx, pvzID
-func (_m *ProductService) DeleteLastProduct(ctx context.Context, pvzID string) error {
-	ret := _m.Called(ctx, pvzID)
+// DeleteLastProduct provides a mock function with given fields: ctx, pvzID, userID
+func (_m *ProductService) DeleteLastProduct(ctx context.Context, pvzID string, userID string) error {
+	ret := _m.Called(ctx, pvzID, userID)
 
 	if len(ret) == 0 {
 		panic("no return value specified for DeleteLastProduct")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
-		r0 = rf(ctx, pvzID)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, pvzID, userID)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -108,13 +171,14 @@ type ProductService_DeleteLastProduct_Call struct {
 // DeleteLastProduct is a helper method to define mock.On call
 //   - ctx context.Context
 //   - pvzID string
-func (_e *ProductService_Expecter) DeleteLastProduct(ctx interface{}, pvzID interface{}) *ProductService_DeleteLastProduct_Call {
-	return &ProductService_DeleteLastProduct_Call{Call: _e.mock.On("DeleteLastProduct", ctx, pvzID)}
+//   - userID string
+func (_e *ProductService_Expecter) DeleteLastProduct(ctx interface{}, pvzID interface{}, userID interface{}) *ProductService_DeleteLastProduct_Call {
+	return &ProductService_DeleteLastProduct_Call{Call: _e.mock.On("DeleteLastProduct", ctx, pvzID, userID)}
 }
 
-func (_c *ProductService_DeleteLastProduct_Call) Run(run func(ctx context.Context, pvzID string)) *ProductService_DeleteLastProduct_Call {
+func (_c *ProductService_DeleteLastProduct_Call) Run(run func(ctx context.Context, pvzID string, userID string)) *ProductService_DeleteLastProduct_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string))
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
 	})
 	return _c
 }
@@ -124,7 +188,114 @@ func (_c *ProductService_DeleteLastProduct_Call) Return(_a0 error) *ProductServi
 	return _c
 }
 
-func (_c *ProductService_DeleteLastProduct_Call) RunAndReturn(run func(context.Context, string) error) *ProductService_DeleteLastProduct_Call {
+func (_c *ProductService_DeleteLastProduct_Call) RunAndReturn(run func(context.Context, string, string) error) *ProductService_DeleteLastProduct_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteProduct provides a mock function with given fields: ctx, productID, userID
+func (_m *ProductService) DeleteProduct(ctx context.Context, productID string, userID string) error {
+	ret := _m.Called(ctx, productID, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteProduct")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, productID, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ProductService_DeleteProduct_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteProduct'
+type ProductService_DeleteProduct_Call struct {
+	*mock.Call
+}
+
+// DeleteProduct is a helper method to define mock.On call
+//   - ctx context.Context
+//   - productID string
+//   - userID string
+func (_e *ProductService_Expecter) DeleteProduct(ctx interface{}, productID interface{}, userID interface{}) *ProductService_DeleteProduct_Call {
+	return &ProductService_DeleteProduct_Call{Call: _e.mock.On("DeleteProduct", ctx, productID, userID)}
+}
+
+func (_c *ProductService_DeleteProduct_Call) Run(run func(ctx context.Context, productID string, userID string)) *ProductService_DeleteProduct_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *ProductService_DeleteProduct_Call) Return(_a0 error) *ProductService_DeleteProduct_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ProductService_DeleteProduct_Call) RunAndReturn(run func(context.Context, string, string) error) *ProductService_DeleteProduct_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetProductsByBarcode provides a mock function with given fields: ctx, barcode
+func (_m *ProductService) GetProductsByBarcode(ctx context.Context, barcode string) ([]models.ProductLocation, error) {
+	ret := _m.Called(ctx, barcode)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetProductsByBarcode")
+	}
+
+	var r0 []models.ProductLocation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]models.ProductLocation, error)); ok {
+		return rf(ctx, barcode)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []models.ProductLocation); ok {
+		r0 = rf(ctx, barcode)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.ProductLocation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, barcode)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ProductService_GetProductsByBarcode_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetProductsByBarcode'
+type ProductService_GetProductsByBarcode_Call struct {
+	*mock.Call
+}
+
+// GetProductsByBarcode is a helper method to define mock.On call
+//   - ctx context.Context
+//   - barcode string
+func (_e *ProductService_Expecter) GetProductsByBarcode(ctx interface{}, barcode interface{}) *ProductService_GetProductsByBarcode_Call {
+	return &ProductService_GetProductsByBarcode_Call{Call: _e.mock.On("GetProductsByBarcode", ctx, barcode)}
+}
+
+func (_c *ProductService_GetProductsByBarcode_Call) Run(run func(ctx context.Context, barcode string)) *ProductService_GetProductsByBarcode_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *ProductService_GetProductsByBarcode_Call) Return(_a0 []models.ProductLocation, _a1 error) *ProductService_GetProductsByBarcode_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ProductService_GetProductsByBarcode_Call) RunAndReturn(run func(context.Context, string) ([]models.ProductLocation, error)) *ProductService_GetProductsByBarcode_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -0,0 +1,271 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	models "github.com/forzeyy/avito-internship-spring-service/internal/models"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// RBACService is an autogenerated mock type for the RBACService type
+type RBACService struct {
+	mock.Mock
+}
+
+type RBACService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *RBACService) EXPECT() *RBACService_Expecter {
+	return &RBACService_Expecter{mock: &_m.Mock}
+}
+
+// GetPermissions provides a mock function with given fields: ctx
+func (_m *RBACService) GetPermissions(ctx context.Context) ([]models.Permission, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPermissions")
+	}
+
+	var r0 []models.Permission
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]models.Permission, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []models.Permission); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Permission)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RBACService_GetPermissions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPermissions'
+type RBACService_GetPermissions_Call struct {
+	*mock.Call
+}
+
+// GetPermissions is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *RBACService_Expecter) GetPermissions(ctx interface{}) *RBACService_GetPermissions_Call {
+	return &RBACService_GetPermissions_Call{Call: _e.mock.On("GetPermissions", ctx)}
+}
+
+func (_c *RBACService_GetPermissions_Call) Run(run func(ctx context.Context)) *RBACService_GetPermissions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *RBACService_GetPermissions_Call) Return(_a0 []models.Permission, _a1 error) *RBACService_GetPermissions_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *RBACService_GetPermissions_Call) RunAndReturn(run func(context.Context) ([]models.Permission, error)) *RBACService_GetPermissions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetRoles provides a mock function with given fields: ctx
+func (_m *RBACService) GetRoles(ctx context.Context) ([]models.Role, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRoles")
+	}
+
+	var r0 []models.Role
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]models.Role, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []models.Role); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Role)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RBACService_GetRoles_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetRoles'
+type RBACService_GetRoles_Call struct {
+	*mock.Call
+}
+
+// GetRoles is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *RBACService_Expecter) GetRoles(ctx interface{}) *RBACService_GetRoles_Call {
+	return &RBACService_GetRoles_Call{Call: _e.mock.On("GetRoles", ctx)}
+}
+
+func (_c *RBACService_GetRoles_Call) Run(run func(ctx context.Context)) *RBACService_GetRoles_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *RBACService_GetRoles_Call) Return(_a0 []models.Role, _a1 error) *RBACService_GetRoles_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *RBACService_GetRoles_Call) RunAndReturn(run func(context.Context) ([]models.Role, error)) *RBACService_GetRoles_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// HasPermission provides a mock function with given fields: ctx, role, permission
+func (_m *RBACService) HasPermission(ctx context.Context, role string, permission string) (bool, error) {
+	ret := _m.Called(ctx, role, permission)
+
+	if len(ret) == 0 {
+		panic("no return value specified for HasPermission")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (bool, error)); ok {
+		return rf(ctx, role, permission)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) bool); ok {
+		r0 = rf(ctx, role, permission)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, role, permission)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RBACService_HasPermission_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'HasPermission'
+type RBACService_HasPermission_Call struct {
+	*mock.Call
+}
+
+// HasPermission is a helper method to define mock.On call
+//   - ctx context.Context
+//   - role string
+//   - permission string
+func (_e *RBACService_Expecter) HasPermission(ctx interface{}, role interface{}, permission interface{}) *RBACService_HasPermission_Call {
+	return &RBACService_HasPermission_Call{Call: _e.mock.On("HasPermission", ctx, role, permission)}
+}
+
+func (_c *RBACService_HasPermission_Call) Run(run func(ctx context.Context, role string, permission string)) *RBACService_HasPermission_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *RBACService_HasPermission_Call) Return(_a0 bool, _a1 error) *RBACService_HasPermission_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *RBACService_HasPermission_Call) RunAndReturn(run func(context.Context, string, string) (bool, error)) *RBACService_HasPermission_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetRolePermissions provides a mock function with given fields: ctx, role, permissions
+func (_m *RBACService) SetRolePermissions(ctx context.Context, role string, permissions []string) (*models.Role, error) {
+	ret := _m.Called(ctx, role, permissions)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetRolePermissions")
+	}
+
+	var r0 *models.Role
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string) (*models.Role, error)); ok {
+		return rf(ctx, role, permissions)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string) *models.Role); ok {
+		r0 = rf(ctx, role, permissions)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Role)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, []string) error); ok {
+		r1 = rf(ctx, role, permissions)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RBACService_SetRolePermissions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetRolePermissions'
+type RBACService_SetRolePermissions_Call struct {
+	*mock.Call
+}
+
+// SetRolePermissions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - role string
+//   - permissions []string
+func (_e *RBACService_Expecter) SetRolePermissions(ctx interface{}, role interface{}, permissions interface{}) *RBACService_SetRolePermissions_Call {
+	return &RBACService_SetRolePermissions_Call{Call: _e.mock.On("SetRolePermissions", ctx, role, permissions)}
+}
+
+func (_c *RBACService_SetRolePermissions_Call) Run(run func(ctx context.Context, role string, permissions []string)) *RBACService_SetRolePermissions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].([]string))
+	})
+	return _c
+}
+
+func (_c *RBACService_SetRolePermissions_Call) Return(_a0 *models.Role, _a1 error) *RBACService_SetRolePermissions_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *RBACService_SetRolePermissions_Call) RunAndReturn(run func(context.Context, string, []string) (*models.Role, error)) *RBACService_SetRolePermissions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewRBACService creates a new instance of RBACService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewRBACService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *RBACService {
+	mock := &RBACService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
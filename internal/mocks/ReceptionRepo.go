@@ -8,6 +8,8 @@ import (
 	models "github.com/forzeyy/avito-internship-spring-service/internal/models"
 	mock "github.com/stretchr/testify/mock"
 
+	time "time"
+
 	uuid "github.com/google/uuid"
 )
 
@@ -24,9 +26,9 @@ func (_m *ReceptionRepo) EXPECT() *ReceptionRepo_Expecter {
 	return &ReceptionRepo_Expecter{mock: &_m.Mock}
 }
 
-// CloseLastReception provides a mock function with given fields: ctx, pvzID
-func (_m *ReceptionRepo) CloseLastReception(ctx context.Context, pvzID uuid.UUID) (*models.Reception, error) {
-	ret := _m.Called(ctx, pvzID)
+// CloseLastReception provides a mock function with given fields: ctx, pvzID, closedBy, closedAt
+func (_m *ReceptionRepo) CloseLastReception(ctx context.Context, pvzID uuid.UUID, closedBy uuid.UUID, closedAt time.Time) (*models.Reception, error) {
+	ret := _m.Called(ctx, pvzID, closedBy, closedAt)
 
 	if len(ret) == 0 {
 		panic("no return value specified for CloseLastReception")
@@ -34,19 +36,19 @@ func (_m *ReceptionRepo) CloseLastReception(ctx context.Context, pvzID uuid.UUID
 
 	var r0 *models.Reception
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*models.Reception, error)); ok {
-		return rf(ctx, pvzID)
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID, time.Time) (*models.Reception, error)); ok {
+		return rf(ctx, pvzID, closedBy, closedAt)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *models.Reception); ok {
-		r0 = rf(ctx, pvzID)
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID, time.Time) *models.Reception); ok {
+		r0 = rf(ctx, pvzID, closedBy, closedAt)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*models.Reception)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
-		r1 = rf(ctx, pvzID)
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, uuid.UUID, time.Time) error); ok {
+		r1 = rf(ctx, pvzID, closedBy, closedAt)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -62,13 +64,15 @@ type ReceptionRepo_CloseLastReception_Call struct {
 // CloseLastReception is a helper method to define mock.On call
 //   - ctx context.Context
 //   - pvzID uuid.UUID
-func (_e *ReceptionRepo_Expecter) CloseLastReception(ctx interface{}, pvzID interface{}) *ReceptionRepo_CloseLastReception_Call {
-	return &ReceptionRepo_CloseLastReception_Call{Call: _e.mock.On("CloseLastReception", ctx, pvzID)}
+//   - closedBy uuid.UUID
+//   - closedAt time.Time
+func (_e *ReceptionRepo_Expecter) CloseLastReception(ctx interface{}, pvzID interface{}, closedBy interface{}, closedAt interface{}) *ReceptionRepo_CloseLastReception_Call {
+	return &ReceptionRepo_CloseLastReception_Call{Call: _e.mock.On("CloseLastReception", ctx, pvzID, closedBy, closedAt)}
 }
 
-func (_c *ReceptionRepo_CloseLastReception_Call) Run(run func(ctx context.Context, pvzID uuid.UUID)) *ReceptionRepo_CloseLastReception_Call {
+func (_c *ReceptionRepo_CloseLastReception_Call) Run(run func(ctx context.Context, pvzID uuid.UUID, closedBy uuid.UUID, closedAt time.Time)) *ReceptionRepo_CloseLastReception_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(uuid.UUID))
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(uuid.UUID), args[3].(time.Time))
 	})
 	return _c
 }
@@ -78,7 +82,68 @@ func (_c *ReceptionRepo_CloseLastReception_Call) Return(_a0 *models.Reception, _
 	return _c
 }
 
-func (_c *ReceptionRepo_CloseLastReception_Call) RunAndReturn(run func(context.Context, uuid.UUID) (*models.Reception, error)) *ReceptionRepo_CloseLastReception_Call {
+func (_c *ReceptionRepo_CloseLastReception_Call) RunAndReturn(run func(context.Context, uuid.UUID, uuid.UUID, time.Time) (*models.Reception, error)) *ReceptionRepo_CloseLastReception_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CloseStaleReceptions provides a mock function with given fields: ctx, inactiveSince, closedAt, limit
+func (_m *ReceptionRepo) CloseStaleReceptions(ctx context.Context, inactiveSince time.Time, closedAt time.Time, limit int) ([]models.Reception, error) {
+	ret := _m.Called(ctx, inactiveSince, closedAt, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CloseStaleReceptions")
+	}
+
+	var r0 []models.Reception
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, time.Time, int) ([]models.Reception, error)); ok {
+		return rf(ctx, inactiveSince, closedAt, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, time.Time, int) []models.Reception); ok {
+		r0 = rf(ctx, inactiveSince, closedAt, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Reception)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time, time.Time, int) error); ok {
+		r1 = rf(ctx, inactiveSince, closedAt, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ReceptionRepo_CloseStaleReceptions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CloseStaleReceptions'
+type ReceptionRepo_CloseStaleReceptions_Call struct {
+	*mock.Call
+}
+
+// CloseStaleReceptions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - inactiveSince time.Time
+//   - closedAt time.Time
+//   - limit int
+func (_e *ReceptionRepo_Expecter) CloseStaleReceptions(ctx interface{}, inactiveSince interface{}, closedAt interface{}, limit interface{}) *ReceptionRepo_CloseStaleReceptions_Call {
+	return &ReceptionRepo_CloseStaleReceptions_Call{Call: _e.mock.On("CloseStaleReceptions", ctx, inactiveSince, closedAt, limit)}
+}
+
+func (_c *ReceptionRepo_CloseStaleReceptions_Call) Run(run func(ctx context.Context, inactiveSince time.Time, closedAt time.Time, limit int)) *ReceptionRepo_CloseStaleReceptions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time), args[2].(time.Time), args[3].(int))
+	})
+	return _c
+}
+
+func (_c *ReceptionRepo_CloseStaleReceptions_Call) Return(_a0 []models.Reception, _a1 error) *ReceptionRepo_CloseStaleReceptions_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ReceptionRepo_CloseStaleReceptions_Call) RunAndReturn(run func(context.Context, time.Time, time.Time, int) ([]models.Reception, error)) *ReceptionRepo_CloseStaleReceptions_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -130,6 +195,67 @@ func (_c *ReceptionRepo_CreateReception_Call) RunAndReturn(run func(context.Cont
 	return _c
 }
 
+// GetClosedReceptions provides a mock function with given fields: ctx, pvzID, page, limit
+func (_m *ReceptionRepo) GetClosedReceptions(ctx context.Context, pvzID uuid.UUID, page int, limit int) ([]models.ReceptionWithProductCount, error) {
+	ret := _m.Called(ctx, pvzID, page, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetClosedReceptions")
+	}
+
+	var r0 []models.ReceptionWithProductCount
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, int, int) ([]models.ReceptionWithProductCount, error)); ok {
+		return rf(ctx, pvzID, page, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, int, int) []models.ReceptionWithProductCount); ok {
+		r0 = rf(ctx, pvzID, page, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.ReceptionWithProductCount)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, int, int) error); ok {
+		r1 = rf(ctx, pvzID, page, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ReceptionRepo_GetClosedReceptions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetClosedReceptions'
+type ReceptionRepo_GetClosedReceptions_Call struct {
+	*mock.Call
+}
+
+// GetClosedReceptions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - pvzID uuid.UUID
+//   - page int
+//   - limit int
+func (_e *ReceptionRepo_Expecter) GetClosedReceptions(ctx interface{}, pvzID interface{}, page interface{}, limit interface{}) *ReceptionRepo_GetClosedReceptions_Call {
+	return &ReceptionRepo_GetClosedReceptions_Call{Call: _e.mock.On("GetClosedReceptions", ctx, pvzID, page, limit)}
+}
+
+func (_c *ReceptionRepo_GetClosedReceptions_Call) Run(run func(ctx context.Context, pvzID uuid.UUID, page int, limit int)) *ReceptionRepo_GetClosedReceptions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(int), args[3].(int))
+	})
+	return _c
+}
+
+func (_c *ReceptionRepo_GetClosedReceptions_Call) Return(_a0 []models.ReceptionWithProductCount, _a1 error) *ReceptionRepo_GetClosedReceptions_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ReceptionRepo_GetClosedReceptions_Call) RunAndReturn(run func(context.Context, uuid.UUID, int, int) ([]models.ReceptionWithProductCount, error)) *ReceptionRepo_GetClosedReceptions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetLastOpenReception provides a mock function with given fields: ctx, pvzID
 func (_m *ReceptionRepo) GetLastOpenReception(ctx context.Context, pvzID uuid.UUID) (*models.Reception, error) {
 	ret := _m.Called(ctx, pvzID)
@@ -189,6 +315,246 @@ func (_c *ReceptionRepo_GetLastOpenReception_Call) RunAndReturn(run func(context
 	return _c
 }
 
+// GetReceptionByID provides a mock function with given fields: ctx, id
+func (_m *ReceptionRepo) GetReceptionByID(ctx context.Context, id uuid.UUID) (*models.Reception, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetReceptionByID")
+	}
+
+	var r0 *models.Reception
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*models.Reception, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *models.Reception); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Reception)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ReceptionRepo_GetReceptionByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetReceptionByID'
+type ReceptionRepo_GetReceptionByID_Call struct {
+	*mock.Call
+}
+
+// GetReceptionByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+func (_e *ReceptionRepo_Expecter) GetReceptionByID(ctx interface{}, id interface{}) *ReceptionRepo_GetReceptionByID_Call {
+	return &ReceptionRepo_GetReceptionByID_Call{Call: _e.mock.On("GetReceptionByID", ctx, id)}
+}
+
+func (_c *ReceptionRepo_GetReceptionByID_Call) Run(run func(ctx context.Context, id uuid.UUID)) *ReceptionRepo_GetReceptionByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *ReceptionRepo_GetReceptionByID_Call) Return(_a0 *models.Reception, _a1 error) *ReceptionRepo_GetReceptionByID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ReceptionRepo_GetReceptionByID_Call) RunAndReturn(run func(context.Context, uuid.UUID) (*models.Reception, error)) *ReceptionRepo_GetReceptionByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetReceptions provides a mock function with given fields: ctx, filter, page, limit
+func (_m *ReceptionRepo) GetReceptions(ctx context.Context, filter models.ReceptionFilter, page int, limit int) ([]models.Reception, error) {
+	ret := _m.Called(ctx, filter, page, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetReceptions")
+	}
+
+	var r0 []models.Reception
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.ReceptionFilter, int, int) ([]models.Reception, error)); ok {
+		return rf(ctx, filter, page, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, models.ReceptionFilter, int, int) []models.Reception); ok {
+		r0 = rf(ctx, filter, page, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Reception)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, models.ReceptionFilter, int, int) error); ok {
+		r1 = rf(ctx, filter, page, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ReceptionRepo_GetReceptions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetReceptions'
+type ReceptionRepo_GetReceptions_Call struct {
+	*mock.Call
+}
+
+// GetReceptions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filter models.ReceptionFilter
+//   - page int
+//   - limit int
+func (_e *ReceptionRepo_Expecter) GetReceptions(ctx interface{}, filter interface{}, page interface{}, limit interface{}) *ReceptionRepo_GetReceptions_Call {
+	return &ReceptionRepo_GetReceptions_Call{Call: _e.mock.On("GetReceptions", ctx, filter, page, limit)}
+}
+
+func (_c *ReceptionRepo_GetReceptions_Call) Run(run func(ctx context.Context, filter models.ReceptionFilter, page int, limit int)) *ReceptionRepo_GetReceptions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(models.ReceptionFilter), args[2].(int), args[3].(int))
+	})
+	return _c
+}
+
+func (_c *ReceptionRepo_GetReceptions_Call) Return(_a0 []models.Reception, _a1 error) *ReceptionRepo_GetReceptions_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ReceptionRepo_GetReceptions_Call) RunAndReturn(run func(context.Context, models.ReceptionFilter, int, int) ([]models.Reception, error)) *ReceptionRepo_GetReceptions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetReceptionsByPVZIDs provides a mock function with given fields: ctx, pvzIDs, startDate, endDate
+func (_m *ReceptionRepo) GetReceptionsByPVZIDs(ctx context.Context, pvzIDs []uuid.UUID, startDate *time.Time, endDate *time.Time) ([]models.Reception, error) {
+	ret := _m.Called(ctx, pvzIDs, startDate, endDate)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetReceptionsByPVZIDs")
+	}
+
+	var r0 []models.Reception
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []uuid.UUID, *time.Time, *time.Time) ([]models.Reception, error)); ok {
+		return rf(ctx, pvzIDs, startDate, endDate)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []uuid.UUID, *time.Time, *time.Time) []models.Reception); ok {
+		r0 = rf(ctx, pvzIDs, startDate, endDate)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Reception)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []uuid.UUID, *time.Time, *time.Time) error); ok {
+		r1 = rf(ctx, pvzIDs, startDate, endDate)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ReceptionRepo_GetReceptionsByPVZIDs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetReceptionsByPVZIDs'
+type ReceptionRepo_GetReceptionsByPVZIDs_Call struct {
+	*mock.Call
+}
+
+// GetReceptionsByPVZIDs is a helper method to define mock.On call
+//   - ctx context.Context
+//   - pvzIDs []uuid.UUID
+//   - startDate *time.Time
+//   - endDate *time.Time
+func (_e *ReceptionRepo_Expecter) GetReceptionsByPVZIDs(ctx interface{}, pvzIDs interface{}, startDate interface{}, endDate interface{}) *ReceptionRepo_GetReceptionsByPVZIDs_Call {
+	return &ReceptionRepo_GetReceptionsByPVZIDs_Call{Call: _e.mock.On("GetReceptionsByPVZIDs", ctx, pvzIDs, startDate, endDate)}
+}
+
+func (_c *ReceptionRepo_GetReceptionsByPVZIDs_Call) Run(run func(ctx context.Context, pvzIDs []uuid.UUID, startDate *time.Time, endDate *time.Time)) *ReceptionRepo_GetReceptionsByPVZIDs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]uuid.UUID), args[2].(*time.Time), args[3].(*time.Time))
+	})
+	return _c
+}
+
+func (_c *ReceptionRepo_GetReceptionsByPVZIDs_Call) Return(_a0 []models.Reception, _a1 error) *ReceptionRepo_GetReceptionsByPVZIDs_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ReceptionRepo_GetReceptionsByPVZIDs_Call) RunAndReturn(run func(context.Context, []uuid.UUID, *time.Time, *time.Time) ([]models.Reception, error)) *ReceptionRepo_GetReceptionsByPVZIDs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// LockLastOpenReception provides a mock function with given fields: ctx, pvzID
+func (_m *ReceptionRepo) LockLastOpenReception(ctx context.Context, pvzID uuid.UUID) (*models.Reception, error) {
+	ret := _m.Called(ctx, pvzID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LockLastOpenReception")
+	}
+
+	var r0 *models.Reception
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*models.Reception, error)); ok {
+		return rf(ctx, pvzID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *models.Reception); ok {
+		r0 = rf(ctx, pvzID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Reception)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, pvzID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ReceptionRepo_LockLastOpenReception_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LockLastOpenReception'
+type ReceptionRepo_LockLastOpenReception_Call struct {
+	*mock.Call
+}
+
+// LockLastOpenReception is a helper method to define mock.On call
+//   - ctx context.Context
+//   - pvzID uuid.UUID
+func (_e *ReceptionRepo_Expecter) LockLastOpenReception(ctx interface{}, pvzID interface{}) *ReceptionRepo_LockLastOpenReception_Call {
+	return &ReceptionRepo_LockLastOpenReception_Call{Call: _e.mock.On("LockLastOpenReception", ctx, pvzID)}
+}
+
+func (_c *ReceptionRepo_LockLastOpenReception_Call) Run(run func(ctx context.Context, pvzID uuid.UUID)) *ReceptionRepo_LockLastOpenReception_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *ReceptionRepo_LockLastOpenReception_Call) Return(_a0 *models.Reception, _a1 error) *ReceptionRepo_LockLastOpenReception_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ReceptionRepo_LockLastOpenReception_Call) RunAndReturn(run func(context.Context, uuid.UUID) (*models.Reception, error)) *ReceptionRepo_LockLastOpenReception_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewReceptionRepo creates a new instance of ReceptionRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewReceptionRepo(t interface {
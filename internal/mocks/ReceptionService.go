@@ -7,6 +7,10 @@ import (
 
 	models "github.com/forzeyy/avito-internship-spring-service/internal/models"
 	mock "github.com/stretchr/testify/mock"
+
+	services "github.com/forzeyy/avito-internship-spring-service/internal/services"
+
+	time "time"
 )
 
 // ReceptionService is an autogenerated mock type for the ReceptionService type
@@ -22,9 +26,9 @@ func (_m *ReceptionService) EXPECT() *ReceptionService_Expecter {
 	return &ReceptionService_Expecter{mock: &_m.Mock}
 }
 
-// CloseLastReception provides a mock function with given fields: ctx, pvzID
-func (_m *ReceptionService) CloseLastReception(ctx context.Context, pvzID string) (*models.Reception, error) {
-	ret := _m.Called(ctx, pvzID)
+// CloseLastReception provides a mock function with given fields: ctx, pvzID, userID
+func (_m *ReceptionService) CloseLastReception(ctx context.Context, pvzID string, userID string) (*models.Reception, error) {
+	ret := _m.Called(ctx, pvzID, userID)
 
 	if len(ret) == 0 {
 		panic("no return value specified for CloseLastReception")
@@ -32,19 +36,19 @@ func (_m *ReceptionService) CloseLastReception(ctx context.Context, pvzID string
 
 	var r0 *models.Reception
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.Reception, error)); ok {
-		return rf(ctx, pvzID)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*models.Reception, error)); ok {
+		return rf(ctx, pvzID, userID)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string) *models.Reception); ok {
-		r0 = rf(ctx, pvzID)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *models.Reception); ok {
+		r0 = rf(ctx, pvzID, userID)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*models.Reception)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = rf(ctx, pvzID)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, pvzID, userID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -60,13 +64,14 @@ type ReceptionService_CloseLastReception_Call struct {
 // CloseLastReception is a helper method to define mock.On call
 //   - ctx context.Context
 //   - pvzID string
-func (_e *ReceptionService_Expecter) CloseLastReception(ctx interface{}, pvzID interface{}) *ReceptionService_CloseLastReception_Call {
-	return &ReceptionService_CloseLastReception_Call{Call: _e.mock.On("CloseLastReception", ctx, pvzID)}
+//   - userID string
+func (_e *ReceptionService_Expecter) CloseLastReception(ctx interface{}, pvzID interface{}, userID interface{}) *ReceptionService_CloseLastReception_Call {
+	return &ReceptionService_CloseLastReception_Call{Call: _e.mock.On("CloseLastReception", ctx, pvzID, userID)}
 }
 
-func (_c *ReceptionService_CloseLastReception_Call) Run(run func(ctx context.Context, pvzID string)) *ReceptionService_CloseLastReception_Call {
+func (_c *ReceptionService_CloseLastReception_Call) Run(run func(ctx context.Context, pvzID string, userID string)) *ReceptionService_CloseLastReception_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string))
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
 	})
 	return _c
 }
@@ -76,14 +81,73 @@ func (_c *ReceptionService_CloseLastReception_Call) Return(_a0 *models.Reception
 	return _c
 }
 
-func (_c *ReceptionService_CloseLastReception_Call) RunAndReturn(run func(context.Context, string) (*models.Reception, error)) *ReceptionService_CloseLastReception_Call {
+func (_c *ReceptionService_CloseLastReception_Call) RunAndReturn(run func(context.Context, string, string) (*models.Reception, error)) *ReceptionService_CloseLastReception_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CloseStaleReceptions provides a mock function with given fields: ctx, inactiveFor
+func (_m *ReceptionService) CloseStaleReceptions(ctx context.Context, inactiveFor time.Duration) ([]models.Reception, error) {
+	ret := _m.Called(ctx, inactiveFor)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CloseStaleReceptions")
+	}
+
+	var r0 []models.Reception
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Duration) ([]models.Reception, error)); ok {
+		return rf(ctx, inactiveFor)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Duration) []models.Reception); ok {
+		r0 = rf(ctx, inactiveFor)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Reception)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Duration) error); ok {
+		r1 = rf(ctx, inactiveFor)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ReceptionService_CloseStaleReceptions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CloseStaleReceptions'
+type ReceptionService_CloseStaleReceptions_Call struct {
+	*mock.Call
+}
+
+// CloseStaleReceptions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - inactiveFor time.Duration
+func (_e *ReceptionService_Expecter) CloseStaleReceptions(ctx interface{}, inactiveFor interface{}) *ReceptionService_CloseStaleReceptions_Call {
+	return &ReceptionService_CloseStaleReceptions_Call{Call: _e.mock.On("CloseStaleReceptions", ctx, inactiveFor)}
+}
+
+func (_c *ReceptionService_CloseStaleReceptions_Call) Run(run func(ctx context.Context, inactiveFor time.Duration)) *ReceptionService_CloseStaleReceptions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Duration))
+	})
+	return _c
+}
+
+func (_c *ReceptionService_CloseStaleReceptions_Call) Return(_a0 []models.Reception, _a1 error) *ReceptionService_CloseStaleReceptions_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ReceptionService_CloseStaleReceptions_Call) RunAndReturn(run func(context.Context, time.Duration) ([]models.Reception, error)) *ReceptionService_CloseStaleReceptions_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// CreateReception provides a mock function with given fields: ctx, pvzID
-func (_m *ReceptionService) CreateReception(ctx context.Context, pvzID string) (*models.Reception, error) {
-	ret := _m.Called(ctx, pvzID)
+// CreateReception provides a mock function with given fields: ctx, pvzID, userID
+func (_m *ReceptionService) CreateReception(ctx context.Context, pvzID string, userID string) (*models.Reception, error) {
+	ret := _m.Called(ctx, pvzID, userID)
 
 	if len(ret) == 0 {
 		panic("no return value specified for CreateReception")
@@ -91,19 +155,19 @@ func (_m *ReceptionService) CreateReception(ctx context.Context, pvzID string) (
 
 	var r0 *models.Reception
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.Reception, error)); ok {
-		return rf(ctx, pvzID)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*models.Reception, error)); ok {
+		return rf(ctx, pvzID, userID)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string) *models.Reception); ok {
-		r0 = rf(ctx, pvzID)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *models.Reception); ok {
+		r0 = rf(ctx, pvzID, userID)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*models.Reception)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = rf(ctx, pvzID)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, pvzID, userID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -119,13 +183,14 @@ type ReceptionService_CreateReception_Call struct {
 // CreateReception is a helper method to define mock.On call
 //   - ctx context.Context
 //   - pvzID string
-func (_e *ReceptionService_Expecter) CreateReception(ctx interface{}, pvzID interface{}) *ReceptionService_CreateReception_Call {
-	return &ReceptionService_CreateReception_Call{Call: _e.mock.On("CreateReception", ctx, pvzID)}
+//   - userID string
+func (_e *ReceptionService_Expecter) CreateReception(ctx interface{}, pvzID interface{}, userID interface{}) *ReceptionService_CreateReception_Call {
+	return &ReceptionService_CreateReception_Call{Call: _e.mock.On("CreateReception", ctx, pvzID, userID)}
 }
 
-func (_c *ReceptionService_CreateReception_Call) Run(run func(ctx context.Context, pvzID string)) *ReceptionService_CreateReception_Call {
+func (_c *ReceptionService_CreateReception_Call) Run(run func(ctx context.Context, pvzID string, userID string)) *ReceptionService_CreateReception_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string))
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
 	})
 	return _c
 }
@@ -135,7 +200,125 @@ func (_c *ReceptionService_CreateReception_Call) Return(_a0 *models.Reception, _
 	return _c
 }
 
-func (_c *ReceptionService_CreateReception_Call) RunAndReturn(run func(context.Context, string) (*models.Reception, error)) *ReceptionService_CreateReception_Call {
+func (_c *ReceptionService_CreateReception_Call) RunAndReturn(run func(context.Context, string, string) (*models.Reception, error)) *ReceptionService_CreateReception_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetReception provides a mock function with given fields: ctx, receptionID
+func (_m *ReceptionService) GetReception(ctx context.Context, receptionID string) (*models.ReceptionWithProducts, error) {
+	ret := _m.Called(ctx, receptionID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetReception")
+	}
+
+	var r0 *models.ReceptionWithProducts
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.ReceptionWithProducts, error)); ok {
+		return rf(ctx, receptionID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.ReceptionWithProducts); ok {
+		r0 = rf(ctx, receptionID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.ReceptionWithProducts)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, receptionID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ReceptionService_GetReception_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetReception'
+type ReceptionService_GetReception_Call struct {
+	*mock.Call
+}
+
+// GetReception is a helper method to define mock.On call
+//   - ctx context.Context
+//   - receptionID string
+func (_e *ReceptionService_Expecter) GetReception(ctx interface{}, receptionID interface{}) *ReceptionService_GetReception_Call {
+	return &ReceptionService_GetReception_Call{Call: _e.mock.On("GetReception", ctx, receptionID)}
+}
+
+func (_c *ReceptionService_GetReception_Call) Run(run func(ctx context.Context, receptionID string)) *ReceptionService_GetReception_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *ReceptionService_GetReception_Call) Return(_a0 *models.ReceptionWithProducts, _a1 error) *ReceptionService_GetReception_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ReceptionService_GetReception_Call) RunAndReturn(run func(context.Context, string) (*models.ReceptionWithProducts, error)) *ReceptionService_GetReception_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetReceptions provides a mock function with given fields: ctx, params
+func (_m *ReceptionService) GetReceptions(ctx context.Context, params services.ReceptionListParams) ([]models.Reception, error) {
+	ret := _m.Called(ctx, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetReceptions")
+	}
+
+	var r0 []models.Reception
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, services.ReceptionListParams) ([]models.Reception, error)); ok {
+		return rf(ctx, params)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, services.ReceptionListParams) []models.Reception); ok {
+		r0 = rf(ctx, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Reception)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, services.ReceptionListParams) error); ok {
+		r1 = rf(ctx, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ReceptionService_GetReceptions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetReceptions'
+type ReceptionService_GetReceptions_Call struct {
+	*mock.Call
+}
+
+// GetReceptions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - params services.ReceptionListParams
+func (_e *ReceptionService_Expecter) GetReceptions(ctx interface{}, params interface{}) *ReceptionService_GetReceptions_Call {
+	return &ReceptionService_GetReceptions_Call{Call: _e.mock.On("GetReceptions", ctx, params)}
+}
+
+func (_c *ReceptionService_GetReceptions_Call) Run(run func(ctx context.Context, params services.ReceptionListParams)) *ReceptionService_GetReceptions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(services.ReceptionListParams))
+	})
+	return _c
+}
+
+func (_c *ReceptionService_GetReceptions_Call) Return(_a0 []models.Reception, _a1 error) *ReceptionService_GetReceptions_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ReceptionService_GetReceptions_Call) RunAndReturn(run func(context.Context, services.ReceptionListParams) ([]models.Reception, error)) *ReceptionService_GetReceptions_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -0,0 +1,286 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	models "github.com/forzeyy/avito-internship-spring-service/internal/models"
+	mock "github.com/stretchr/testify/mock"
+
+	uuid "github.com/google/uuid"
+)
+
+// RefreshTokenRepo is an autogenerated mock type for the RefreshTokenRepo type
+type RefreshTokenRepo struct {
+	mock.Mock
+}
+
+type RefreshTokenRepo_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *RefreshTokenRepo) EXPECT() *RefreshTokenRepo_Expecter {
+	return &RefreshTokenRepo_Expecter{mock: &_m.Mock}
+}
+
+// CreateRefreshToken provides a mock function with given fields: ctx, token
+func (_m *RefreshTokenRepo) CreateRefreshToken(ctx context.Context, token *models.RefreshToken) error {
+	ret := _m.Called(ctx, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateRefreshToken")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.RefreshToken) error); ok {
+		r0 = rf(ctx, token)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RefreshTokenRepo_CreateRefreshToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateRefreshToken'
+type RefreshTokenRepo_CreateRefreshToken_Call struct {
+	*mock.Call
+}
+
+// CreateRefreshToken is a helper method to define mock.On call
+//   - ctx context.Context
+//   - token *models.RefreshToken
+func (_e *RefreshTokenRepo_Expecter) CreateRefreshToken(ctx interface{}, token interface{}) *RefreshTokenRepo_CreateRefreshToken_Call {
+	return &RefreshTokenRepo_CreateRefreshToken_Call{Call: _e.mock.On("CreateRefreshToken", ctx, token)}
+}
+
+func (_c *RefreshTokenRepo_CreateRefreshToken_Call) Run(run func(ctx context.Context, token *models.RefreshToken)) *RefreshTokenRepo_CreateRefreshToken_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.RefreshToken))
+	})
+	return _c
+}
+
+func (_c *RefreshTokenRepo_CreateRefreshToken_Call) Return(_a0 error) *RefreshTokenRepo_CreateRefreshToken_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *RefreshTokenRepo_CreateRefreshToken_Call) RunAndReturn(run func(context.Context, *models.RefreshToken) error) *RefreshTokenRepo_CreateRefreshToken_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// LockRefreshToken provides a mock function with given fields: ctx, tokenHash
+func (_m *RefreshTokenRepo) LockRefreshToken(ctx context.Context, tokenHash string) (*models.RefreshToken, error) {
+	ret := _m.Called(ctx, tokenHash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LockRefreshToken")
+	}
+
+	var r0 *models.RefreshToken
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.RefreshToken, error)); ok {
+		return rf(ctx, tokenHash)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.RefreshToken); ok {
+		r0 = rf(ctx, tokenHash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.RefreshToken)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, tokenHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RefreshTokenRepo_LockRefreshToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LockRefreshToken'
+type RefreshTokenRepo_LockRefreshToken_Call struct {
+	*mock.Call
+}
+
+// LockRefreshToken is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tokenHash string
+func (_e *RefreshTokenRepo_Expecter) LockRefreshToken(ctx interface{}, tokenHash interface{}) *RefreshTokenRepo_LockRefreshToken_Call {
+	return &RefreshTokenRepo_LockRefreshToken_Call{Call: _e.mock.On("LockRefreshToken", ctx, tokenHash)}
+}
+
+func (_c *RefreshTokenRepo_LockRefreshToken_Call) Run(run func(ctx context.Context, tokenHash string)) *RefreshTokenRepo_LockRefreshToken_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *RefreshTokenRepo_LockRefreshToken_Call) Return(_a0 *models.RefreshToken, _a1 error) *RefreshTokenRepo_LockRefreshToken_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *RefreshTokenRepo_LockRefreshToken_Call) RunAndReturn(run func(context.Context, string) (*models.RefreshToken, error)) *RefreshTokenRepo_LockRefreshToken_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RevokeRefreshToken provides a mock function with given fields: ctx, id
+func (_m *RefreshTokenRepo) RevokeRefreshToken(ctx context.Context, id uuid.UUID) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevokeRefreshToken")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RefreshTokenRepo_RevokeRefreshToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RevokeRefreshToken'
+type RefreshTokenRepo_RevokeRefreshToken_Call struct {
+	*mock.Call
+}
+
+// RevokeRefreshToken is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+func (_e *RefreshTokenRepo_Expecter) RevokeRefreshToken(ctx interface{}, id interface{}) *RefreshTokenRepo_RevokeRefreshToken_Call {
+	return &RefreshTokenRepo_RevokeRefreshToken_Call{Call: _e.mock.On("RevokeRefreshToken", ctx, id)}
+}
+
+func (_c *RefreshTokenRepo_RevokeRefreshToken_Call) Run(run func(ctx context.Context, id uuid.UUID)) *RefreshTokenRepo_RevokeRefreshToken_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *RefreshTokenRepo_RevokeRefreshToken_Call) Return(_a0 error) *RefreshTokenRepo_RevokeRefreshToken_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *RefreshTokenRepo_RevokeRefreshToken_Call) RunAndReturn(run func(context.Context, uuid.UUID) error) *RefreshTokenRepo_RevokeRefreshToken_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RevokeRefreshTokenFamily provides a mock function with given fields: ctx, familyID
+func (_m *RefreshTokenRepo) RevokeRefreshTokenFamily(ctx context.Context, familyID uuid.UUID) error {
+	ret := _m.Called(ctx, familyID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevokeRefreshTokenFamily")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
+		r0 = rf(ctx, familyID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RefreshTokenRepo_RevokeRefreshTokenFamily_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RevokeRefreshTokenFamily'
+type RefreshTokenRepo_RevokeRefreshTokenFamily_Call struct {
+	*mock.Call
+}
+
+// RevokeRefreshTokenFamily is a helper method to define mock.On call
+//   - ctx context.Context
+//   - familyID uuid.UUID
+func (_e *RefreshTokenRepo_Expecter) RevokeRefreshTokenFamily(ctx interface{}, familyID interface{}) *RefreshTokenRepo_RevokeRefreshTokenFamily_Call {
+	return &RefreshTokenRepo_RevokeRefreshTokenFamily_Call{Call: _e.mock.On("RevokeRefreshTokenFamily", ctx, familyID)}
+}
+
+func (_c *RefreshTokenRepo_RevokeRefreshTokenFamily_Call) Run(run func(ctx context.Context, familyID uuid.UUID)) *RefreshTokenRepo_RevokeRefreshTokenFamily_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *RefreshTokenRepo_RevokeRefreshTokenFamily_Call) Return(_a0 error) *RefreshTokenRepo_RevokeRefreshTokenFamily_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *RefreshTokenRepo_RevokeRefreshTokenFamily_Call) RunAndReturn(run func(context.Context, uuid.UUID) error) *RefreshTokenRepo_RevokeRefreshTokenFamily_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RevokeUserRefreshTokens provides a mock function with given fields: ctx, userID
+func (_m *RefreshTokenRepo) RevokeUserRefreshTokens(ctx context.Context, userID uuid.UUID) error {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevokeUserRefreshTokens")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RefreshTokenRepo_RevokeUserRefreshTokens_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RevokeUserRefreshTokens'
+type RefreshTokenRepo_RevokeUserRefreshTokens_Call struct {
+	*mock.Call
+}
+
+// RevokeUserRefreshTokens is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+func (_e *RefreshTokenRepo_Expecter) RevokeUserRefreshTokens(ctx interface{}, userID interface{}) *RefreshTokenRepo_RevokeUserRefreshTokens_Call {
+	return &RefreshTokenRepo_RevokeUserRefreshTokens_Call{Call: _e.mock.On("RevokeUserRefreshTokens", ctx, userID)}
+}
+
+func (_c *RefreshTokenRepo_RevokeUserRefreshTokens_Call) Run(run func(ctx context.Context, userID uuid.UUID)) *RefreshTokenRepo_RevokeUserRefreshTokens_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *RefreshTokenRepo_RevokeUserRefreshTokens_Call) Return(_a0 error) *RefreshTokenRepo_RevokeUserRefreshTokens_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *RefreshTokenRepo_RevokeUserRefreshTokens_Call) RunAndReturn(run func(context.Context, uuid.UUID) error) *RefreshTokenRepo_RevokeUserRefreshTokens_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewRefreshTokenRepo creates a new instance of RefreshTokenRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewRefreshTokenRepo(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *RefreshTokenRepo {
+	mock := &RefreshTokenRepo{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,260 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	models "github.com/forzeyy/avito-internship-spring-service/internal/models"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// RoleRepo is an autogenerated mock type for the RoleRepo type
+type RoleRepo struct {
+	mock.Mock
+}
+
+type RoleRepo_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *RoleRepo) EXPECT() *RoleRepo_Expecter {
+	return &RoleRepo_Expecter{mock: &_m.Mock}
+}
+
+// GetPermissions provides a mock function with given fields: ctx
+func (_m *RoleRepo) GetPermissions(ctx context.Context) ([]models.Permission, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPermissions")
+	}
+
+	var r0 []models.Permission
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]models.Permission, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []models.Permission); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Permission)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RoleRepo_GetPermissions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPermissions'
+type RoleRepo_GetPermissions_Call struct {
+	*mock.Call
+}
+
+// GetPermissions is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *RoleRepo_Expecter) GetPermissions(ctx interface{}) *RoleRepo_GetPermissions_Call {
+	return &RoleRepo_GetPermissions_Call{Call: _e.mock.On("GetPermissions", ctx)}
+}
+
+func (_c *RoleRepo_GetPermissions_Call) Run(run func(ctx context.Context)) *RoleRepo_GetPermissions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *RoleRepo_GetPermissions_Call) Return(_a0 []models.Permission, _a1 error) *RoleRepo_GetPermissions_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *RoleRepo_GetPermissions_Call) RunAndReturn(run func(context.Context) ([]models.Permission, error)) *RoleRepo_GetPermissions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetRole provides a mock function with given fields: ctx, name
+func (_m *RoleRepo) GetRole(ctx context.Context, name string) (*models.Role, error) {
+	ret := _m.Called(ctx, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRole")
+	}
+
+	var r0 *models.Role
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.Role, error)); ok {
+		return rf(ctx, name)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.Role); ok {
+		r0 = rf(ctx, name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Role)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RoleRepo_GetRole_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetRole'
+type RoleRepo_GetRole_Call struct {
+	*mock.Call
+}
+
+// GetRole is a helper method to define mock.On call
+//   - ctx context.Context
+//   - name string
+func (_e *RoleRepo_Expecter) GetRole(ctx interface{}, name interface{}) *RoleRepo_GetRole_Call {
+	return &RoleRepo_GetRole_Call{Call: _e.mock.On("GetRole", ctx, name)}
+}
+
+func (_c *RoleRepo_GetRole_Call) Run(run func(ctx context.Context, name string)) *RoleRepo_GetRole_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *RoleRepo_GetRole_Call) Return(_a0 *models.Role, _a1 error) *RoleRepo_GetRole_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *RoleRepo_GetRole_Call) RunAndReturn(run func(context.Context, string) (*models.Role, error)) *RoleRepo_GetRole_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetRoles provides a mock function with given fields: ctx
+func (_m *RoleRepo) GetRoles(ctx context.Context) ([]models.Role, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRoles")
+	}
+
+	var r0 []models.Role
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]models.Role, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []models.Role); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Role)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RoleRepo_GetRoles_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetRoles'
+type RoleRepo_GetRoles_Call struct {
+	*mock.Call
+}
+
+// GetRoles is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *RoleRepo_Expecter) GetRoles(ctx interface{}) *RoleRepo_GetRoles_Call {
+	return &RoleRepo_GetRoles_Call{Call: _e.mock.On("GetRoles", ctx)}
+}
+
+func (_c *RoleRepo_GetRoles_Call) Run(run func(ctx context.Context)) *RoleRepo_GetRoles_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *RoleRepo_GetRoles_Call) Return(_a0 []models.Role, _a1 error) *RoleRepo_GetRoles_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *RoleRepo_GetRoles_Call) RunAndReturn(run func(context.Context) ([]models.Role, error)) *RoleRepo_GetRoles_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetRolePermissions provides a mock function with given fields: ctx, role, permissions
+func (_m *RoleRepo) SetRolePermissions(ctx context.Context, role string, permissions []string) error {
+	ret := _m.Called(ctx, role, permissions)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetRolePermissions")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string) error); ok {
+		r0 = rf(ctx, role, permissions)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RoleRepo_SetRolePermissions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetRolePermissions'
+type RoleRepo_SetRolePermissions_Call struct {
+	*mock.Call
+}
+
+// SetRolePermissions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - role string
+//   - permissions []string
+func (_e *RoleRepo_Expecter) SetRolePermissions(ctx interface{}, role interface{}, permissions interface{}) *RoleRepo_SetRolePermissions_Call {
+	return &RoleRepo_SetRolePermissions_Call{Call: _e.mock.On("SetRolePermissions", ctx, role, permissions)}
+}
+
+func (_c *RoleRepo_SetRolePermissions_Call) Run(run func(ctx context.Context, role string, permissions []string)) *RoleRepo_SetRolePermissions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].([]string))
+	})
+	return _c
+}
+
+func (_c *RoleRepo_SetRolePermissions_Call) Return(_a0 error) *RoleRepo_SetRolePermissions_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *RoleRepo_SetRolePermissions_Call) RunAndReturn(run func(context.Context, string, []string) error) *RoleRepo_SetRolePermissions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewRoleRepo creates a new instance of RoleRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewRoleRepo(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *RoleRepo {
+	mock := &RoleRepo{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
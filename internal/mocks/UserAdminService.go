@@ -0,0 +1,442 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	models "github.com/forzeyy/avito-internship-spring-service/internal/models"
+	mock "github.com/stretchr/testify/mock"
+
+	services "github.com/forzeyy/avito-internship-spring-service/internal/services"
+)
+
+// UserAdminService is an autogenerated mock type for the UserAdminService type
+type UserAdminService struct {
+	mock.Mock
+}
+
+type UserAdminService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *UserAdminService) EXPECT() *UserAdminService_Expecter {
+	return &UserAdminService_Expecter{mock: &_m.Mock}
+}
+
+// ActivateUser provides a mock function with given fields: ctx, userID
+func (_m *UserAdminService) ActivateUser(ctx context.Context, userID string) (*models.User, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ActivateUser")
+	}
+
+	var r0 *models.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.User, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.User); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UserAdminService_ActivateUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ActivateUser'
+type UserAdminService_ActivateUser_Call struct {
+	*mock.Call
+}
+
+// ActivateUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+func (_e *UserAdminService_Expecter) ActivateUser(ctx interface{}, userID interface{}) *UserAdminService_ActivateUser_Call {
+	return &UserAdminService_ActivateUser_Call{Call: _e.mock.On("ActivateUser", ctx, userID)}
+}
+
+func (_c *UserAdminService_ActivateUser_Call) Run(run func(ctx context.Context, userID string)) *UserAdminService_ActivateUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *UserAdminService_ActivateUser_Call) Return(_a0 *models.User, _a1 error) *UserAdminService_ActivateUser_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *UserAdminService_ActivateUser_Call) RunAndReturn(run func(context.Context, string) (*models.User, error)) *UserAdminService_ActivateUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// BootstrapAdmin provides a mock function with given fields: ctx, email, password
+func (_m *UserAdminService) BootstrapAdmin(ctx context.Context, email string, password string) error {
+	ret := _m.Called(ctx, email, password)
+
+	if len(ret) == 0 {
+		panic("no return value specified for BootstrapAdmin")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, email, password)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UserAdminService_BootstrapAdmin_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'BootstrapAdmin'
+type UserAdminService_BootstrapAdmin_Call struct {
+	*mock.Call
+}
+
+// BootstrapAdmin is a helper method to define mock.On call
+//   - ctx context.Context
+//   - email string
+//   - password string
+func (_e *UserAdminService_Expecter) BootstrapAdmin(ctx interface{}, email interface{}, password interface{}) *UserAdminService_BootstrapAdmin_Call {
+	return &UserAdminService_BootstrapAdmin_Call{Call: _e.mock.On("BootstrapAdmin", ctx, email, password)}
+}
+
+func (_c *UserAdminService_BootstrapAdmin_Call) Run(run func(ctx context.Context, email string, password string)) *UserAdminService_BootstrapAdmin_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *UserAdminService_BootstrapAdmin_Call) Return(_a0 error) *UserAdminService_BootstrapAdmin_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *UserAdminService_BootstrapAdmin_Call) RunAndReturn(run func(context.Context, string, string) error) *UserAdminService_BootstrapAdmin_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ChangeRole provides a mock function with given fields: ctx, userID, role
+func (_m *UserAdminService) ChangeRole(ctx context.Context, userID string, role string) (*models.User, error) {
+	ret := _m.Called(ctx, userID, role)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ChangeRole")
+	}
+
+	var r0 *models.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*models.User, error)); ok {
+		return rf(ctx, userID, role)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *models.User); ok {
+		r0 = rf(ctx, userID, role)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, userID, role)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UserAdminService_ChangeRole_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ChangeRole'
+type UserAdminService_ChangeRole_Call struct {
+	*mock.Call
+}
+
+// ChangeRole is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - role string
+func (_e *UserAdminService_Expecter) ChangeRole(ctx interface{}, userID interface{}, role interface{}) *UserAdminService_ChangeRole_Call {
+	return &UserAdminService_ChangeRole_Call{Call: _e.mock.On("ChangeRole", ctx, userID, role)}
+}
+
+func (_c *UserAdminService_ChangeRole_Call) Run(run func(ctx context.Context, userID string, role string)) *UserAdminService_ChangeRole_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *UserAdminService_ChangeRole_Call) Return(_a0 *models.User, _a1 error) *UserAdminService_ChangeRole_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *UserAdminService_ChangeRole_Call) RunAndReturn(run func(context.Context, string, string) (*models.User, error)) *UserAdminService_ChangeRole_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeactivateUser provides a mock function with given fields: ctx, userID
+func (_m *UserAdminService) DeactivateUser(ctx context.Context, userID string) (*models.User, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeactivateUser")
+	}
+
+	var r0 *models.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.User, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.User); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UserAdminService_DeactivateUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeactivateUser'
+type UserAdminService_DeactivateUser_Call struct {
+	*mock.Call
+}
+
+// DeactivateUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+func (_e *UserAdminService_Expecter) DeactivateUser(ctx interface{}, userID interface{}) *UserAdminService_DeactivateUser_Call {
+	return &UserAdminService_DeactivateUser_Call{Call: _e.mock.On("DeactivateUser", ctx, userID)}
+}
+
+func (_c *UserAdminService_DeactivateUser_Call) Run(run func(ctx context.Context, userID string)) *UserAdminService_DeactivateUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *UserAdminService_DeactivateUser_Call) Return(_a0 *models.User, _a1 error) *UserAdminService_DeactivateUser_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *UserAdminService_DeactivateUser_Call) RunAndReturn(run func(context.Context, string) (*models.User, error)) *UserAdminService_DeactivateUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ForcePasswordReset provides a mock function with given fields: ctx, userID
+func (_m *UserAdminService) ForcePasswordReset(ctx context.Context, userID string) (*models.User, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ForcePasswordReset")
+	}
+
+	var r0 *models.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.User, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.User); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UserAdminService_ForcePasswordReset_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ForcePasswordReset'
+type UserAdminService_ForcePasswordReset_Call struct {
+	*mock.Call
+}
+
+// ForcePasswordReset is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+func (_e *UserAdminService_Expecter) ForcePasswordReset(ctx interface{}, userID interface{}) *UserAdminService_ForcePasswordReset_Call {
+	return &UserAdminService_ForcePasswordReset_Call{Call: _e.mock.On("ForcePasswordReset", ctx, userID)}
+}
+
+func (_c *UserAdminService_ForcePasswordReset_Call) Run(run func(ctx context.Context, userID string)) *UserAdminService_ForcePasswordReset_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *UserAdminService_ForcePasswordReset_Call) Return(_a0 *models.User, _a1 error) *UserAdminService_ForcePasswordReset_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *UserAdminService_ForcePasswordReset_Call) RunAndReturn(run func(context.Context, string) (*models.User, error)) *UserAdminService_ForcePasswordReset_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetUser provides a mock function with given fields: ctx, userID
+func (_m *UserAdminService) GetUser(ctx context.Context, userID string) (*models.User, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUser")
+	}
+
+	var r0 *models.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.User, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.User); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UserAdminService_GetUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUser'
+type UserAdminService_GetUser_Call struct {
+	*mock.Call
+}
+
+// GetUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+func (_e *UserAdminService_Expecter) GetUser(ctx interface{}, userID interface{}) *UserAdminService_GetUser_Call {
+	return &UserAdminService_GetUser_Call{Call: _e.mock.On("GetUser", ctx, userID)}
+}
+
+func (_c *UserAdminService_GetUser_Call) Run(run func(ctx context.Context, userID string)) *UserAdminService_GetUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *UserAdminService_GetUser_Call) Return(_a0 *models.User, _a1 error) *UserAdminService_GetUser_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *UserAdminService_GetUser_Call) RunAndReturn(run func(context.Context, string) (*models.User, error)) *UserAdminService_GetUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetUsers provides a mock function with given fields: ctx, params
+func (_m *UserAdminService) GetUsers(ctx context.Context, params services.UserListParams) ([]models.User, error) {
+	ret := _m.Called(ctx, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUsers")
+	}
+
+	var r0 []models.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, services.UserListParams) ([]models.User, error)); ok {
+		return rf(ctx, params)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, services.UserListParams) []models.User); ok {
+		r0 = rf(ctx, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, services.UserListParams) error); ok {
+		r1 = rf(ctx, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UserAdminService_GetUsers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUsers'
+type UserAdminService_GetUsers_Call struct {
+	*mock.Call
+}
+
+// GetUsers is a helper method to define mock.On call
+//   - ctx context.Context
+//   - params services.UserListParams
+func (_e *UserAdminService_Expecter) GetUsers(ctx interface{}, params interface{}) *UserAdminService_GetUsers_Call {
+	return &UserAdminService_GetUsers_Call{Call: _e.mock.On("GetUsers", ctx, params)}
+}
+
+func (_c *UserAdminService_GetUsers_Call) Run(run func(ctx context.Context, params services.UserListParams)) *UserAdminService_GetUsers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(services.UserListParams))
+	})
+	return _c
+}
+
+func (_c *UserAdminService_GetUsers_Call) Return(_a0 []models.User, _a1 error) *UserAdminService_GetUsers_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *UserAdminService_GetUsers_Call) RunAndReturn(run func(context.Context, services.UserListParams) ([]models.User, error)) *UserAdminService_GetUsers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewUserAdminService creates a new instance of UserAdminService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewUserAdminService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *UserAdminService {
+	mock := &UserAdminService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
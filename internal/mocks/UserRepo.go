@@ -7,6 +7,10 @@ import (
 
 	models "github.com/forzeyy/avito-internship-spring-service/internal/models"
 	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+
+	uuid "github.com/google/uuid"
 )
 
 // UserRepo is an autogenerated mock type for the UserRepo type
@@ -128,6 +132,318 @@ func (_c *UserRepo_GetUserByEmail_Call) RunAndReturn(run func(context.Context, s
 	return _c
 }
 
+// GetUserByID provides a mock function with given fields: ctx, id
+func (_m *UserRepo) GetUserByID(ctx context.Context, id uuid.UUID) (*models.User, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUserByID")
+	}
+
+	var r0 *models.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*models.User, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *models.User); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UserRepo_GetUserByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUserByID'
+type UserRepo_GetUserByID_Call struct {
+	*mock.Call
+}
+
+// GetUserByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+func (_e *UserRepo_Expecter) GetUserByID(ctx interface{}, id interface{}) *UserRepo_GetUserByID_Call {
+	return &UserRepo_GetUserByID_Call{Call: _e.mock.On("GetUserByID", ctx, id)}
+}
+
+func (_c *UserRepo_GetUserByID_Call) Run(run func(ctx context.Context, id uuid.UUID)) *UserRepo_GetUserByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *UserRepo_GetUserByID_Call) Return(_a0 *models.User, _a1 error) *UserRepo_GetUserByID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *UserRepo_GetUserByID_Call) RunAndReturn(run func(context.Context, uuid.UUID) (*models.User, error)) *UserRepo_GetUserByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetUsers provides a mock function with given fields: ctx, filter, page, limit
+func (_m *UserRepo) GetUsers(ctx context.Context, filter models.UserFilter, page int, limit int) ([]models.User, error) {
+	ret := _m.Called(ctx, filter, page, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUsers")
+	}
+
+	var r0 []models.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.UserFilter, int, int) ([]models.User, error)); ok {
+		return rf(ctx, filter, page, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, models.UserFilter, int, int) []models.User); ok {
+		r0 = rf(ctx, filter, page, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, models.UserFilter, int, int) error); ok {
+		r1 = rf(ctx, filter, page, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UserRepo_GetUsers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUsers'
+type UserRepo_GetUsers_Call struct {
+	*mock.Call
+}
+
+// GetUsers is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filter models.UserFilter
+//   - page int
+//   - limit int
+func (_e *UserRepo_Expecter) GetUsers(ctx interface{}, filter interface{}, page interface{}, limit interface{}) *UserRepo_GetUsers_Call {
+	return &UserRepo_GetUsers_Call{Call: _e.mock.On("GetUsers", ctx, filter, page, limit)}
+}
+
+func (_c *UserRepo_GetUsers_Call) Run(run func(ctx context.Context, filter models.UserFilter, page int, limit int)) *UserRepo_GetUsers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(models.UserFilter), args[2].(int), args[3].(int))
+	})
+	return _c
+}
+
+func (_c *UserRepo_GetUsers_Call) Return(_a0 []models.User, _a1 error) *UserRepo_GetUsers_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *UserRepo_GetUsers_Call) RunAndReturn(run func(context.Context, models.UserFilter, int, int) ([]models.User, error)) *UserRepo_GetUsers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetPasswordResetRequired provides a mock function with given fields: ctx, id, required
+func (_m *UserRepo) SetPasswordResetRequired(ctx context.Context, id uuid.UUID, required bool) error {
+	ret := _m.Called(ctx, id, required)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetPasswordResetRequired")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, bool) error); ok {
+		r0 = rf(ctx, id, required)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UserRepo_SetPasswordResetRequired_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetPasswordResetRequired'
+type UserRepo_SetPasswordResetRequired_Call struct {
+	*mock.Call
+}
+
+// SetPasswordResetRequired is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+//   - required bool
+func (_e *UserRepo_Expecter) SetPasswordResetRequired(ctx interface{}, id interface{}, required interface{}) *UserRepo_SetPasswordResetRequired_Call {
+	return &UserRepo_SetPasswordResetRequired_Call{Call: _e.mock.On("SetPasswordResetRequired", ctx, id, required)}
+}
+
+func (_c *UserRepo_SetPasswordResetRequired_Call) Run(run func(ctx context.Context, id uuid.UUID, required bool)) *UserRepo_SetPasswordResetRequired_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(bool))
+	})
+	return _c
+}
+
+func (_c *UserRepo_SetPasswordResetRequired_Call) Return(_a0 error) *UserRepo_SetPasswordResetRequired_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *UserRepo_SetPasswordResetRequired_Call) RunAndReturn(run func(context.Context, uuid.UUID, bool) error) *UserRepo_SetPasswordResetRequired_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetUserDeactivated provides a mock function with given fields: ctx, id, deactivatedAt
+func (_m *UserRepo) SetUserDeactivated(ctx context.Context, id uuid.UUID, deactivatedAt *time.Time) error {
+	ret := _m.Called(ctx, id, deactivatedAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetUserDeactivated")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, *time.Time) error); ok {
+		r0 = rf(ctx, id, deactivatedAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UserRepo_SetUserDeactivated_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetUserDeactivated'
+type UserRepo_SetUserDeactivated_Call struct {
+	*mock.Call
+}
+
+// SetUserDeactivated is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+//   - deactivatedAt *time.Time
+func (_e *UserRepo_Expecter) SetUserDeactivated(ctx interface{}, id interface{}, deactivatedAt interface{}) *UserRepo_SetUserDeactivated_Call {
+	return &UserRepo_SetUserDeactivated_Call{Call: _e.mock.On("SetUserDeactivated", ctx, id, deactivatedAt)}
+}
+
+func (_c *UserRepo_SetUserDeactivated_Call) Run(run func(ctx context.Context, id uuid.UUID, deactivatedAt *time.Time)) *UserRepo_SetUserDeactivated_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(*time.Time))
+	})
+	return _c
+}
+
+func (_c *UserRepo_SetUserDeactivated_Call) Return(_a0 error) *UserRepo_SetUserDeactivated_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *UserRepo_SetUserDeactivated_Call) RunAndReturn(run func(context.Context, uuid.UUID, *time.Time) error) *UserRepo_SetUserDeactivated_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdatePassword provides a mock function with given fields: ctx, id, passwordHash
+func (_m *UserRepo) UpdatePassword(ctx context.Context, id uuid.UUID, passwordHash string) error {
+	ret := _m.Called(ctx, id, passwordHash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdatePassword")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string) error); ok {
+		r0 = rf(ctx, id, passwordHash)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UserRepo_UpdatePassword_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdatePassword'
+type UserRepo_UpdatePassword_Call struct {
+	*mock.Call
+}
+
+// UpdatePassword is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+//   - passwordHash string
+func (_e *UserRepo_Expecter) UpdatePassword(ctx interface{}, id interface{}, passwordHash interface{}) *UserRepo_UpdatePassword_Call {
+	return &UserRepo_UpdatePassword_Call{Call: _e.mock.On("UpdatePassword", ctx, id, passwordHash)}
+}
+
+func (_c *UserRepo_UpdatePassword_Call) Run(run func(ctx context.Context, id uuid.UUID, passwordHash string)) *UserRepo_UpdatePassword_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *UserRepo_UpdatePassword_Call) Return(_a0 error) *UserRepo_UpdatePassword_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *UserRepo_UpdatePassword_Call) RunAndReturn(run func(context.Context, uuid.UUID, string) error) *UserRepo_UpdatePassword_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateUserRole provides a mock function with given fields: ctx, id, role
+func (_m *UserRepo) UpdateUserRole(ctx context.Context, id uuid.UUID, role string) error {
+	ret := _m.Called(ctx, id, role)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateUserRole")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string) error); ok {
+		r0 = rf(ctx, id, role)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UserRepo_UpdateUserRole_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateUserRole'
+type UserRepo_UpdateUserRole_Call struct {
+	*mock.Call
+}
+
+// UpdateUserRole is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+//   - role string
+func (_e *UserRepo_Expecter) UpdateUserRole(ctx interface{}, id interface{}, role interface{}) *UserRepo_UpdateUserRole_Call {
+	return &UserRepo_UpdateUserRole_Call{Call: _e.mock.On("UpdateUserRole", ctx, id, role)}
+}
+
+func (_c *UserRepo_UpdateUserRole_Call) Run(run func(ctx context.Context, id uuid.UUID, role string)) *UserRepo_UpdateUserRole_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *UserRepo_UpdateUserRole_Call) Return(_a0 error) *UserRepo_UpdateUserRole_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *UserRepo_UpdateUserRole_Call) RunAndReturn(run func(context.Context, uuid.UUID, string) error) *UserRepo_UpdateUserRole_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewUserRepo creates a new instance of UserRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewUserRepo(t interface {
@@ -5,6 +5,7 @@ package mocks
 import (
 	context "context"
 
+	models "github.com/forzeyy/avito-internship-spring-service/internal/models"
 	mock "github.com/stretchr/testify/mock"
 )
 
@@ -21,6 +22,54 @@ func (_m *UserService) EXPECT() *UserService_Expecter {
 	return &UserService_Expecter{mock: &_m.Mock}
 }
 
+// CheckAccess provides a mock function with given fields: ctx, userID, role
+func (_m *UserService) CheckAccess(ctx context.Context, userID string, role string) error {
+	ret := _m.Called(ctx, userID, role)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CheckAccess")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, userID, role)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UserService_CheckAccess_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CheckAccess'
+type UserService_CheckAccess_Call struct {
+	*mock.Call
+}
+
+// CheckAccess is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - role string
+func (_e *UserService_Expecter) CheckAccess(ctx interface{}, userID interface{}, role interface{}) *UserService_CheckAccess_Call {
+	return &UserService_CheckAccess_Call{Call: _e.mock.On("CheckAccess", ctx, userID, role)}
+}
+
+func (_c *UserService_CheckAccess_Call) Run(run func(ctx context.Context, userID string, role string)) *UserService_CheckAccess_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *UserService_CheckAccess_Call) Return(_a0 error) *UserService_CheckAccess_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *UserService_CheckAccess_Call) RunAndReturn(run func(context.Context, string, string) error) *UserService_CheckAccess_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // DummyLogin provides a mock function with given fields: ctx, role
 func (_m *UserService) DummyLogin(ctx context.Context, role string) (string, error) {
 	ret := _m.Called(ctx, role)
@@ -78,27 +127,135 @@ func (_c *UserService_DummyLogin_Call) RunAndReturn(run func(context.Context, st
 	return _c
 }
 
-// LoginUser provides a mock function with given fields: ctx, email, password
-func (_m *UserService) LoginUser(ctx context.Context, email string, password string) (string, error) {
-	ret := _m.Called(ctx, email, password)
+// ForgotPassword provides a mock function with given fields: ctx, email, ip
+func (_m *UserService) ForgotPassword(ctx context.Context, email string, ip string) error {
+	ret := _m.Called(ctx, email, ip)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ForgotPassword")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, email, ip)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UserService_ForgotPassword_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ForgotPassword'
+type UserService_ForgotPassword_Call struct {
+	*mock.Call
+}
+
+// ForgotPassword is a helper method to define mock.On call
+//   - ctx context.Context
+//   - email string
+//   - ip string
+func (_e *UserService_Expecter) ForgotPassword(ctx interface{}, email interface{}, ip interface{}) *UserService_ForgotPassword_Call {
+	return &UserService_ForgotPassword_Call{Call: _e.mock.On("ForgotPassword", ctx, email, ip)}
+}
+
+func (_c *UserService_ForgotPassword_Call) Run(run func(ctx context.Context, email string, ip string)) *UserService_ForgotPassword_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *UserService_ForgotPassword_Call) Return(_a0 error) *UserService_ForgotPassword_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *UserService_ForgotPassword_Call) RunAndReturn(run func(context.Context, string, string) error) *UserService_ForgotPassword_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetLockouts provides a mock function with given fields: ctx
+func (_m *UserService) GetLockouts(ctx context.Context) ([]models.LoginThrottle, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLockouts")
+	}
+
+	var r0 []models.LoginThrottle
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]models.LoginThrottle, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []models.LoginThrottle); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.LoginThrottle)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UserService_GetLockouts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLockouts'
+type UserService_GetLockouts_Call struct {
+	*mock.Call
+}
+
+// GetLockouts is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *UserService_Expecter) GetLockouts(ctx interface{}) *UserService_GetLockouts_Call {
+	return &UserService_GetLockouts_Call{Call: _e.mock.On("GetLockouts", ctx)}
+}
+
+func (_c *UserService_GetLockouts_Call) Run(run func(ctx context.Context)) *UserService_GetLockouts_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *UserService_GetLockouts_Call) Return(_a0 []models.LoginThrottle, _a1 error) *UserService_GetLockouts_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *UserService_GetLockouts_Call) RunAndReturn(run func(context.Context) ([]models.LoginThrottle, error)) *UserService_GetLockouts_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// LoginUser provides a mock function with given fields: ctx, email, password, ip
+func (_m *UserService) LoginUser(ctx context.Context, email string, password string, ip string) (*models.TokenPair, error) {
+	ret := _m.Called(ctx, email, password, ip)
 
 	if len(ret) == 0 {
 		panic("no return value specified for LoginUser")
 	}
 
-	var r0 string
+	var r0 *models.TokenPair
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) (string, error)); ok {
-		return rf(ctx, email, password)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) (*models.TokenPair, error)); ok {
+		return rf(ctx, email, password, ip)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) string); ok {
-		r0 = rf(ctx, email, password)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) *models.TokenPair); ok {
+		r0 = rf(ctx, email, password, ip)
 	} else {
-		r0 = ret.Get(0).(string)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.TokenPair)
+		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
-		r1 = rf(ctx, email, password)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = rf(ctx, email, password, ip)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -115,23 +272,130 @@ type UserService_LoginUser_Call struct {
 //   - ctx context.Context
 //   - email string
 //   - password string
-func (_e *UserService_Expecter) LoginUser(ctx interface{}, email interface{}, password interface{}) *UserService_LoginUser_Call {
-	return &UserService_LoginUser_Call{Call: _e.mock.On("LoginUser", ctx, email, password)}
+//   - ip string
+func (_e *UserService_Expecter) LoginUser(ctx interface{}, email interface{}, password interface{}, ip interface{}) *UserService_LoginUser_Call {
+	return &UserService_LoginUser_Call{Call: _e.mock.On("LoginUser", ctx, email, password, ip)}
 }
 
-func (_c *UserService_LoginUser_Call) Run(run func(ctx context.Context, email string, password string)) *UserService_LoginUser_Call {
+func (_c *UserService_LoginUser_Call) Run(run func(ctx context.Context, email string, password string, ip string)) *UserService_LoginUser_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string), args[2].(string))
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *UserService_LoginUser_Call) Return(_a0 *models.TokenPair, _a1 error) *UserService_LoginUser_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *UserService_LoginUser_Call) RunAndReturn(run func(context.Context, string, string, string) (*models.TokenPair, error)) *UserService_LoginUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Logout provides a mock function with given fields: ctx, refreshToken
+func (_m *UserService) Logout(ctx context.Context, refreshToken string) error {
+	ret := _m.Called(ctx, refreshToken)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Logout")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, refreshToken)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UserService_Logout_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Logout'
+type UserService_Logout_Call struct {
+	*mock.Call
+}
+
+// Logout is a helper method to define mock.On call
+//   - ctx context.Context
+//   - refreshToken string
+func (_e *UserService_Expecter) Logout(ctx interface{}, refreshToken interface{}) *UserService_Logout_Call {
+	return &UserService_Logout_Call{Call: _e.mock.On("Logout", ctx, refreshToken)}
+}
+
+func (_c *UserService_Logout_Call) Run(run func(ctx context.Context, refreshToken string)) *UserService_Logout_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *UserService_Logout_Call) Return(_a0 error) *UserService_Logout_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *UserService_Logout_Call) RunAndReturn(run func(context.Context, string) error) *UserService_Logout_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RefreshTokens provides a mock function with given fields: ctx, refreshToken
+func (_m *UserService) RefreshTokens(ctx context.Context, refreshToken string) (*models.TokenPair, error) {
+	ret := _m.Called(ctx, refreshToken)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RefreshTokens")
+	}
+
+	var r0 *models.TokenPair
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.TokenPair, error)); ok {
+		return rf(ctx, refreshToken)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.TokenPair); ok {
+		r0 = rf(ctx, refreshToken)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.TokenPair)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, refreshToken)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UserService_RefreshTokens_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RefreshTokens'
+type UserService_RefreshTokens_Call struct {
+	*mock.Call
+}
+
+// RefreshTokens is a helper method to define mock.On call
+//   - ctx context.Context
+//   - refreshToken string
+func (_e *UserService_Expecter) RefreshTokens(ctx interface{}, refreshToken interface{}) *UserService_RefreshTokens_Call {
+	return &UserService_RefreshTokens_Call{Call: _e.mock.On("RefreshTokens", ctx, refreshToken)}
+}
+
+func (_c *UserService_RefreshTokens_Call) Run(run func(ctx context.Context, refreshToken string)) *UserService_RefreshTokens_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
 	})
 	return _c
 }
 
-func (_c *UserService_LoginUser_Call) Return(_a0 string, _a1 error) *UserService_LoginUser_Call {
+func (_c *UserService_RefreshTokens_Call) Return(_a0 *models.TokenPair, _a1 error) *UserService_RefreshTokens_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *UserService_LoginUser_Call) RunAndReturn(run func(context.Context, string, string) (string, error)) *UserService_LoginUser_Call {
+func (_c *UserService_RefreshTokens_Call) RunAndReturn(run func(context.Context, string) (*models.TokenPair, error)) *UserService_RefreshTokens_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -185,6 +449,101 @@ func (_c *UserService_RegisterUser_Call) RunAndReturn(run func(context.Context,
 	return _c
 }
 
+// ResetPassword provides a mock function with given fields: ctx, token, password
+func (_m *UserService) ResetPassword(ctx context.Context, token string, password string) error {
+	ret := _m.Called(ctx, token, password)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ResetPassword")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, token, password)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UserService_ResetPassword_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ResetPassword'
+type UserService_ResetPassword_Call struct {
+	*mock.Call
+}
+
+// ResetPassword is a helper method to define mock.On call
+//   - ctx context.Context
+//   - token string
+//   - password string
+func (_e *UserService_Expecter) ResetPassword(ctx interface{}, token interface{}, password interface{}) *UserService_ResetPassword_Call {
+	return &UserService_ResetPassword_Call{Call: _e.mock.On("ResetPassword", ctx, token, password)}
+}
+
+func (_c *UserService_ResetPassword_Call) Run(run func(ctx context.Context, token string, password string)) *UserService_ResetPassword_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *UserService_ResetPassword_Call) Return(_a0 error) *UserService_ResetPassword_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *UserService_ResetPassword_Call) RunAndReturn(run func(context.Context, string, string) error) *UserService_ResetPassword_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UnlockUser provides a mock function with given fields: ctx, email
+func (_m *UserService) UnlockUser(ctx context.Context, email string) error {
+	ret := _m.Called(ctx, email)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UnlockUser")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, email)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UserService_UnlockUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UnlockUser'
+type UserService_UnlockUser_Call struct {
+	*mock.Call
+}
+
+// UnlockUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - email string
+func (_e *UserService_Expecter) UnlockUser(ctx interface{}, email interface{}) *UserService_UnlockUser_Call {
+	return &UserService_UnlockUser_Call{Call: _e.mock.On("UnlockUser", ctx, email)}
+}
+
+func (_c *UserService_UnlockUser_Call) Run(run func(ctx context.Context, email string)) *UserService_UnlockUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *UserService_UnlockUser_Call) Return(_a0 error) *UserService_UnlockUser_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *UserService_UnlockUser_Call) RunAndReturn(run func(context.Context, string) error) *UserService_UnlockUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewUserService creates a new instance of UserService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewUserService(t interface {
@@ -0,0 +1,63 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	AuditActionPVZCreate          = "pvz.create"
+	AuditActionPVZAssign          = "pvz.assign"
+	AuditActionPVZUnassign        = "pvz.unassign"
+	AuditActionReceptionOpen      = "reception.open"
+	AuditActionReceptionClose     = "reception.close"
+	AuditActionReceptionAutoClose = "reception.auto_close"
+	AuditActionProductAdd         = "product.add"
+	AuditActionProductBatchAdd    = "product.batch_add"
+	AuditActionProductDelete      = "product.delete"
+	AuditActionUserRegister       = "user.register"
+	AuditActionUserLogin          = "user.login"
+	AuditActionUserUnlock         = "user.unlock"
+	AuditActionUserRoleChange     = "user.role_change"
+	AuditActionUserDeactivate     = "user.deactivate"
+	AuditActionUserActivate       = "user.activate"
+	AuditActionUserForceReset     = "user.force_password_reset"
+	AuditActionUserResetRequest   = "user.password_reset_request"
+	AuditActionUserPasswordReset  = "user.password_reset"
+	AuditActionRoleUpdate         = "role.update"
+	AuditOutcomeSuccess           = "success"
+	AuditOutcomeFailure           = "failure"
+)
+
+// AuditTarget - объекты, которых касается действие; незаполненные поля не относятся к действию
+type AuditTarget struct {
+	PVZID       *uuid.UUID
+	ReceptionID *uuid.UUID
+	ProductID   *uuid.UUID
+	UserID      *uuid.UUID
+	Role        string
+}
+
+type AuditEntry struct {
+	ID        uuid.UUID
+	ActorID   *uuid.UUID
+	ActorRole string
+	Action    string
+	Target    AuditTarget
+	Details   map[string]any
+	RequestID string
+	Outcome   string
+	Error     string
+	CreatedAt time.Time
+}
+
+type AuditFilter struct {
+	ActorID     *uuid.UUID
+	Action      *string
+	PVZID       *uuid.UUID
+	ReceptionID *uuid.UUID
+	Outcome     *string
+	From        *time.Time
+	To          *time.Time
+}
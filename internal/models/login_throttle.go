@@ -0,0 +1,21 @@
+package models
+
+import "time"
+
+const (
+	LoginThrottleScopeEmail = "email"
+	LoginThrottleScopeIP    = "ip"
+
+	// счетчики запросов сброса пароля ведутся отдельно от попыток входа
+	LoginThrottleScopeResetEmail = "reset_email"
+	LoginThrottleScopeResetIP    = "reset_ip"
+)
+
+// LoginThrottle - счетчик неудачных попыток входа или запросов сброса пароля для одного email или IP адреса
+type LoginThrottle struct {
+	Scope        string
+	Key          string
+	FailedCount  int
+	LastFailedAt time.Time
+	LockedUntil  *time.Time
+}
@@ -11,4 +11,41 @@ type Product struct {
 	DateTime    time.Time
 	Type        string
 	ReceptionID uuid.UUID
+	CreatedBy   *uuid.UUID
+	// Barcode - штрихкод посылки; у товаров, принятых без него, nil
+	Barcode *string
+}
+
+// BarcodeScope - в каких пределах штрихкод товара должен быть уникален
+type BarcodeScope string
+
+const (
+	BarcodeScopeReception BarcodeScope = "reception"
+	BarcodeScopeGlobal    BarcodeScope = "global"
+)
+
+// NewProduct - позиция пакета со сканера; пустой Barcode означает, что штрихкод не отсканирован
+type NewProduct struct {
+	Type    string
+	Barcode string
+}
+
+// ProductLocation - где и когда была принята посылка
+type ProductLocation struct {
+	Product         Product
+	PVZID           uuid.UUID
+	City            string
+	ReceptionStatus string
+}
+
+// ProductBatch - итог пакетного добавления: товары с недопустимым типом пропускаются, остальные добавляются
+type ProductBatch struct {
+	ReceptionID uuid.UUID
+	Items       []ProductBatchItem
+}
+
+type ProductBatchItem struct {
+	Index   int
+	Product *Product
+	Error   string
 }
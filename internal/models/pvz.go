@@ -11,3 +11,29 @@ type PVZ struct {
 	RegDate time.Time
 	City    string
 }
+
+type PVZWithReceptions struct {
+	PVZ        PVZ
+	Receptions []ReceptionWithProducts
+}
+
+// PVZCursor - позиция в списке ПВЗ для keyset-пагинации
+type PVZCursor struct {
+	RegDate time.Time
+	ID      uuid.UUID
+}
+
+type PVZDetails struct {
+	PVZ           PVZ
+	OpenReception *Reception
+	History       []ReceptionWithProductCount
+}
+
+// PVZAssignment - закрепление сотрудника за ПВЗ
+type PVZAssignment struct {
+	PVZID      uuid.UUID
+	UserID     uuid.UUID
+	Email      string
+	AssignedBy *uuid.UUID
+	AssignedAt time.Time
+}
@@ -0,0 +1,33 @@
+package models
+
+const (
+	PermissionPVZRead         = "pvz:read"
+	PermissionPVZCreate       = "pvz:create"
+	PermissionPVZAssign       = "pvz:assign"
+	PermissionReceptionRead   = "reception:read"
+	PermissionReceptionCreate = "reception:create"
+	PermissionReceptionClose  = "reception:close"
+	PermissionProductAdd      = "product:add"
+	PermissionProductDelete   = "product:delete"
+	PermissionAuditRead       = "audit:read"
+	PermissionUserUnlock      = "user:unlock"
+	PermissionRoleManage      = "role:manage"
+	PermissionUserManage      = "user:manage"
+)
+
+// RoleAdmin - роль, у которой нельзя отнять право на изменение ролей, иначе управлять правами станет некому
+const RoleAdmin = "admin"
+
+// RoleSeniorEmployee - старший сотрудник ПВЗ; как и обычный сотрудник, работает только на закрепленных за ним ПВЗ
+const RoleSeniorEmployee = "senior_employee"
+
+type Role struct {
+	Name        string
+	Description string
+	Permissions []string
+}
+
+type Permission struct {
+	Name        string
+	Description string
+}
@@ -7,9 +7,42 @@ import (
 )
 
 type Reception struct {
-	ID       uuid.UUID
-	DateTime time.Time
-	PVZID    uuid.UUID
-	Status   string
-	ClosedAt *time.Time
+	ID        uuid.UUID
+	DateTime  time.Time
+	PVZID     uuid.UUID
+	Status    string
+	ClosedAt  *time.Time
+	CreatedBy *uuid.UUID
+	ClosedBy  *uuid.UUID
+	// AutoClosed - приемку закрыла фоновая задача, потому что в нее долго не добавляли товары
+	AutoClosed bool
+	// Summary сохраняется при закрытии приемки; у открытых приемок его нет
+	Summary *ReceptionSummary
+}
+
+// ReceptionSummary - итог приемки, который сотрудник подтверждает при закрытии
+type ReceptionSummary struct {
+	ProductCount   int
+	ProductsByType map[string]int
+	FirstScanAt    *time.Time
+	LastScanAt     *time.Time
+}
+
+type ReceptionWithProducts struct {
+	Reception Reception
+	Products  []Product
+}
+
+type ReceptionWithProductCount struct {
+	Reception    Reception
+	ProductCount int
+}
+
+type ReceptionFilter struct {
+	PVZID       *uuid.UUID
+	Status      *string
+	CreatedFrom *time.Time
+	CreatedTo   *time.Time
+	ClosedFrom  *time.Time
+	ClosedTo    *time.Time
 }
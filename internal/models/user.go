@@ -6,10 +6,23 @@ import (
 	"github.com/google/uuid"
 )
 
+// RoleEmployee - единственная роль, доступная при самостоятельной регистрации
+const RoleEmployee = "employee"
+
 type User struct {
 	ID           uuid.UUID
 	Email        string
 	PasswordHash string
 	Role         string
 	CreatedAt    time.Time
+	// DeactivatedAt задан у отключенной учетной записи: с ней нельзя войти, а выданные токены не принимаются
+	DeactivatedAt *time.Time
+	// PasswordResetRequired выставляет администратор: до смены пароля вход и выданные токены запрещены
+	PasswordResetRequired bool
+}
+
+type UserFilter struct {
+	Email  *string
+	Role   *string
+	Active *bool
 }
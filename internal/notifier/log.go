@@ -0,0 +1,32 @@
+package notifier
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
+
+// LogNotifier вместо отправки пишет письма в w (файл или стандартный вывод). Только для локальной разработки:
+// в письмах есть токены сброса пароля
+type LogNotifier struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+func NewLogNotifier(w io.Writer) *LogNotifier {
+	return &LogNotifier{w: w}
+}
+
+func (ln *LogNotifier) Send(ctx context.Context, msg Message) error {
+	ln.mu.Lock()
+	defer ln.mu.Unlock()
+
+	_, err := fmt.Fprintf(ln.w, "--- письмо %s\nTo: %s\nSubject: %s\n\n%s\n",
+		time.Now().Format(time.RFC3339), msg.To, msg.Subject, msg.Body)
+	if err != nil {
+		return fmt.Errorf("не удалось записать письмо: %v", err)
+	}
+	return nil
+}
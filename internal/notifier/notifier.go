@@ -0,0 +1,14 @@
+// Package notifier доставляет пользователям служебные письма, например токены сброса пароля
+package notifier
+
+import "context"
+
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+type Notifier interface {
+	Send(ctx context.Context, msg Message) error
+}
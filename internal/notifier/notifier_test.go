@@ -0,0 +1,31 @@
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLogNotifier_Send(t *testing.T) {
+	var buf bytes.Buffer
+	n := NewLogNotifier(&buf)
+
+	err := n.Send(context.Background(), Message{To: "user@mail.ru", Subject: "Сброс пароля", Body: "токен: abc"})
+
+	assert.NoError(t, err)
+	assert.Contains(t, buf.String(), "To: user@mail.ru")
+	assert.Contains(t, buf.String(), "токен: abc")
+}
+
+func TestBuildMessage(t *testing.T) {
+	raw := string(buildMessage("noreply@pvz.local", Message{To: "user@mail.ru", Subject: "Сброс пароля", Body: "строка 1\nстрока 2"}))
+
+	headers, body, found := strings.Cut(raw, "\r\n\r\n")
+	assert.True(t, found)
+	assert.Contains(t, headers, "To: user@mail.ru")
+	assert.Contains(t, headers, "Subject: =?utf-8?q?")
+	assert.Equal(t, "строка 1\r\nстрока 2", body)
+}
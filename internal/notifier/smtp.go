@@ -0,0 +1,58 @@
+package notifier
+
+import (
+	"context"
+	"fmt"
+	"mime"
+	"net"
+	"net/smtp"
+	"strings"
+)
+
+type SMTPConfig struct {
+	Host     string
+	Port     string
+	Username string
+	Password string
+	From     string
+}
+
+// SMTPNotifier отправляет письма через SMTP сервер. Авторизация PLAIN включается, если задан Username;
+// net/smtp передает пароль только по TLS (после STARTTLS) или на localhost
+type SMTPNotifier struct {
+	cfg SMTPConfig
+}
+
+func NewSMTPNotifier(cfg SMTPConfig) *SMTPNotifier {
+	return &SMTPNotifier{cfg: cfg}
+}
+
+func (sn *SMTPNotifier) Send(ctx context.Context, msg Message) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	var auth smtp.Auth
+	if sn.cfg.Username != "" {
+		auth = smtp.PlainAuth("", sn.cfg.Username, sn.cfg.Password, sn.cfg.Host)
+	}
+
+	addr := net.JoinHostPort(sn.cfg.Host, sn.cfg.Port)
+	if err := smtp.SendMail(addr, auth, sn.cfg.From, []string{msg.To}, buildMessage(sn.cfg.From, msg)); err != nil {
+		return fmt.Errorf("не удалось отправить письмо на %s: %v", msg.To, err)
+	}
+	return nil
+}
+
+func buildMessage(from string, msg Message) []byte {
+	var b strings.Builder
+	b.WriteString("From: " + from + "\r\n")
+	b.WriteString("To: " + msg.To + "\r\n")
+	b.WriteString("Subject: " + mime.QEncoding.Encode("utf-8", msg.Subject) + "\r\n")
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	b.WriteString("Content-Transfer-Encoding: 8bit\r\n")
+	b.WriteString("\r\n")
+	b.WriteString(strings.ReplaceAll(msg.Body, "\n", "\r\n"))
+	return []byte(b.String())
+}
@@ -0,0 +1,138 @@
+package repos
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/forzeyy/avito-internship-spring-service/internal/models"
+)
+
+type AuditRepo interface {
+	CreateEntry(ctx context.Context, entry *models.AuditEntry) error
+	GetEntries(ctx context.Context, filter models.AuditFilter, page, limit int) ([]models.AuditEntry, error)
+}
+
+type auditRepo struct {
+	db DB
+}
+
+func NewAuditRepo(db DB) AuditRepo {
+	return &auditRepo{db: db}
+}
+
+func (ar *auditRepo) CreateEntry(ctx context.Context, entry *models.AuditEntry) error {
+	query := `
+		INSERT INTO audit_log (id, actor_id, actor_role, action, pvz_id, reception_id, product_id, user_id, role, details, request_id, outcome, error_message, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
+	`
+	_, err := getDB(ctx, ar.db).Exec(ctx, query,
+		entry.ID,
+		entry.ActorID,
+		entry.ActorRole,
+		entry.Action,
+		entry.Target.PVZID,
+		entry.Target.ReceptionID,
+		entry.Target.ProductID,
+		entry.Target.UserID,
+		entry.Target.Role,
+		entry.Details,
+		entry.RequestID,
+		entry.Outcome,
+		entry.Error,
+		entry.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("не удалось записать событие аудита: %v", err)
+	}
+	return nil
+}
+
+func (ar *auditRepo) GetEntries(ctx context.Context, filter models.AuditFilter, page, limit int) ([]models.AuditEntry, error) {
+	var (
+		query = `
+			SELECT id, actor_id, actor_role, action, pvz_id, reception_id, product_id, user_id, role, details, request_id, outcome, error_message, created_at
+			FROM audit_log
+			WHERE TRUE
+		`
+		args     []any
+		argIndex = 1
+	)
+
+	if filter.ActorID != nil {
+		query += fmt.Sprintf(" AND actor_id = $%d", argIndex)
+		args = append(args, *filter.ActorID)
+		argIndex++
+	}
+	if filter.Action != nil {
+		query += fmt.Sprintf(" AND action = $%d", argIndex)
+		args = append(args, *filter.Action)
+		argIndex++
+	}
+	if filter.PVZID != nil {
+		query += fmt.Sprintf(" AND pvz_id = $%d", argIndex)
+		args = append(args, *filter.PVZID)
+		argIndex++
+	}
+	if filter.ReceptionID != nil {
+		query += fmt.Sprintf(" AND reception_id = $%d", argIndex)
+		args = append(args, *filter.ReceptionID)
+		argIndex++
+	}
+	if filter.Outcome != nil {
+		query += fmt.Sprintf(" AND outcome = $%d", argIndex)
+		args = append(args, *filter.Outcome)
+		argIndex++
+	}
+	if filter.From != nil {
+		query += fmt.Sprintf(" AND created_at >= $%d", argIndex)
+		args = append(args, filter.From)
+		argIndex++
+	}
+	if filter.To != nil {
+		query += fmt.Sprintf(" AND created_at <= $%d", argIndex)
+		args = append(args, filter.To)
+		argIndex++
+	}
+
+	query += fmt.Sprintf(`
+		ORDER BY created_at DESC, id DESC
+		LIMIT $%d OFFSET $%d
+	`, argIndex, argIndex+1)
+	args = append(args, limit, (page-1)*limit)
+
+	rows, err := getDB(ctx, ar.db).Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("не удалось получить журнал аудита: %w", err)
+	}
+	defer rows.Close()
+
+	var entries []models.AuditEntry
+	for rows.Next() {
+		var entry models.AuditEntry
+		err := rows.Scan(
+			&entry.ID,
+			&entry.ActorID,
+			&entry.ActorRole,
+			&entry.Action,
+			&entry.Target.PVZID,
+			&entry.Target.ReceptionID,
+			&entry.Target.ProductID,
+			&entry.Target.UserID,
+			&entry.Target.Role,
+			&entry.Details,
+			&entry.RequestID,
+			&entry.Outcome,
+			&entry.Error,
+			&entry.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("ошибка при сканировании строки: %w", err)
+		}
+		entries = append(entries, entry)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("ошибка чтения строк: %v", err)
+	}
+	return entries, nil
+}
@@ -0,0 +1,100 @@
+package repos_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/forzeyy/avito-internship-spring-service/internal/models"
+	"github.com/forzeyy/avito-internship-spring-service/internal/repos"
+	"github.com/google/uuid"
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+// CreateEntry
+func TestCreateAuditEntry_Success(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	assert.NoError(t, err)
+	defer mock.Close()
+
+	repo := repos.NewAuditRepo(mock)
+
+	actorID, pvzID := uuid.New(), uuid.New()
+	entry := &models.AuditEntry{
+		ID:        uuid.New(),
+		ActorID:   &actorID,
+		ActorRole: "moderator",
+		Action:    models.AuditActionPVZCreate,
+		Target:    models.AuditTarget{PVZID: &pvzID},
+		RequestID: "req-1",
+		Outcome:   models.AuditOutcomeSuccess,
+		CreatedAt: time.Now(),
+	}
+
+	mock.ExpectExec("INSERT INTO audit_log").
+		WithArgs(entry.ID, entry.ActorID, "moderator", entry.Action, entry.Target.PVZID, (*uuid.UUID)(nil), (*uuid.UUID)(nil), (*uuid.UUID)(nil), "", map[string]any(nil), "req-1", entry.Outcome, "", entry.CreatedAt).
+		WillReturnResult(pgxmock.NewResult("INSERT", 1))
+
+	err = repo.CreateEntry(context.Background(), entry)
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCreateAuditEntry_RoleDetails(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	assert.NoError(t, err)
+	defer mock.Close()
+
+	repo := repos.NewAuditRepo(mock)
+
+	actorID := uuid.New()
+	details := map[string]any{"permissions": []string{models.PermissionPVZRead}}
+	entry := &models.AuditEntry{
+		ID:        uuid.New(),
+		ActorID:   &actorID,
+		ActorRole: models.RoleAdmin,
+		Action:    models.AuditActionRoleUpdate,
+		Target:    models.AuditTarget{Role: "auditor"},
+		Details:   details,
+		Outcome:   models.AuditOutcomeSuccess,
+		CreatedAt: time.Now(),
+	}
+
+	mock.ExpectExec("INSERT INTO audit_log").
+		WithArgs(entry.ID, entry.ActorID, models.RoleAdmin, entry.Action, (*uuid.UUID)(nil), (*uuid.UUID)(nil), (*uuid.UUID)(nil), (*uuid.UUID)(nil), "auditor", details, "", entry.Outcome, "", entry.CreatedAt).
+		WillReturnResult(pgxmock.NewResult("INSERT", 1))
+
+	err = repo.CreateEntry(context.Background(), entry)
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// GetEntries
+func TestGetAuditEntries_Filter(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	assert.NoError(t, err)
+	defer mock.Close()
+
+	repo := repos.NewAuditRepo(mock)
+
+	pvzID := uuid.New()
+	outcome := models.AuditOutcomeFailure
+	id := uuid.New()
+	rows := pgxmock.NewRows([]string{"id", "actor_id", "actor_role", "action", "pvz_id", "reception_id", "product_id", "user_id", "role", "details", "request_id", "outcome", "error_message", "created_at"}).
+		AddRow(id, (*uuid.UUID)(nil), "", models.AuditActionReceptionClose, &pvzID, (*uuid.UUID)(nil), (*uuid.UUID)(nil), (*uuid.UUID)(nil), "", map[string]any(nil), "req-2", outcome, "последняя открытая приемка не найдена", time.Now())
+
+	mock.ExpectQuery(`AND pvz_id = \$1 AND outcome = \$2`).
+		WithArgs(pvzID, outcome, 10, 10).
+		WillReturnRows(rows)
+
+	entries, err := repo.GetEntries(context.Background(), models.AuditFilter{PVZID: &pvzID, Outcome: &outcome}, 2, 10)
+	assert.NoError(t, err)
+	if assert.Len(t, entries, 1) {
+		assert.Equal(t, id, entries[0].ID)
+		assert.Equal(t, &pvzID, entries[0].Target.PVZID)
+		assert.Nil(t, entries[0].ActorID)
+		assert.Equal(t, "последняя открытая приемка не найдена", entries[0].Error)
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
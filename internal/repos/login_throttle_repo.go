@@ -0,0 +1,138 @@
+package repos
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/forzeyy/avito-internship-spring-service/internal/models"
+	"github.com/jackc/pgx/v5"
+)
+
+type LoginThrottleRepo interface {
+	GetLoginThrottle(ctx context.Context, scope, key string) (*models.LoginThrottle, error)
+	RegisterLoginFailure(ctx context.Context, scope, key string, at, resetBefore time.Time) (*models.LoginThrottle, error)
+	LockLogin(ctx context.Context, scope, key string, until time.Time) error
+	ResetLoginThrottle(ctx context.Context, scope, key string) error
+	GetActiveLockouts(ctx context.Context, now time.Time) ([]models.LoginThrottle, error)
+}
+
+type loginThrottleRepo struct {
+	db DB
+}
+
+func NewLoginThrottleRepo(db DB) LoginThrottleRepo {
+	return &loginThrottleRepo{db: db}
+}
+
+func (lr *loginThrottleRepo) GetLoginThrottle(ctx context.Context, scope, key string) (*models.LoginThrottle, error) {
+	var throttle models.LoginThrottle
+
+	query := `
+		SELECT scope, key, failed_count, last_failed_at, locked_until
+		FROM login_throttles
+		WHERE scope = $1 AND key = $2
+	`
+	err := getDB(ctx, lr.db).QueryRow(ctx, query, scope, key).Scan(
+		&throttle.Scope,
+		&throttle.Key,
+		&throttle.FailedCount,
+		&throttle.LastFailedAt,
+		&throttle.LockedUntil,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("не удалось получить счетчик попыток входа: %v", err)
+	}
+	return &throttle, nil
+}
+
+// RegisterLoginFailure увеличивает счетчик неудачных попыток одним запросом, чтобы параллельные попытки не терялись.
+// Если последняя неудача была раньше resetBefore, счетчик и блокировка начинаются заново
+func (lr *loginThrottleRepo) RegisterLoginFailure(ctx context.Context, scope, key string, at, resetBefore time.Time) (*models.LoginThrottle, error) {
+	var throttle models.LoginThrottle
+
+	query := `
+		INSERT INTO login_throttles (scope, key, failed_count, last_failed_at)
+		VALUES ($1, $2, 1, $3)
+		ON CONFLICT (scope, key) DO UPDATE SET
+			failed_count = CASE WHEN login_throttles.last_failed_at < $4 THEN 1 ELSE login_throttles.failed_count + 1 END,
+			locked_until = CASE WHEN login_throttles.last_failed_at < $4 THEN NULL ELSE login_throttles.locked_until END,
+			last_failed_at = $3
+		RETURNING scope, key, failed_count, last_failed_at, locked_until
+	`
+	err := getDB(ctx, lr.db).QueryRow(ctx, query, scope, key, at, resetBefore).Scan(
+		&throttle.Scope,
+		&throttle.Key,
+		&throttle.FailedCount,
+		&throttle.LastFailedAt,
+		&throttle.LockedUntil,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("не удалось учесть неудачную попытку входа: %v", err)
+	}
+	return &throttle, nil
+}
+
+func (lr *loginThrottleRepo) LockLogin(ctx context.Context, scope, key string, until time.Time) error {
+	query := `
+		UPDATE login_throttles
+		SET locked_until = $3
+		WHERE scope = $1 AND key = $2
+	`
+	_, err := getDB(ctx, lr.db).Exec(ctx, query, scope, key, until)
+	if err != nil {
+		return fmt.Errorf("не удалось заблокировать вход: %v", err)
+	}
+	return nil
+}
+
+func (lr *loginThrottleRepo) ResetLoginThrottle(ctx context.Context, scope, key string) error {
+	query := `
+		DELETE FROM login_throttles
+		WHERE scope = $1 AND key = $2
+	`
+	_, err := getDB(ctx, lr.db).Exec(ctx, query, scope, key)
+	if err != nil {
+		return fmt.Errorf("не удалось сбросить счетчик попыток входа: %v", err)
+	}
+	return nil
+}
+
+func (lr *loginThrottleRepo) GetActiveLockouts(ctx context.Context, now time.Time) ([]models.LoginThrottle, error) {
+	query := `
+		SELECT scope, key, failed_count, last_failed_at, locked_until
+		FROM login_throttles
+		WHERE locked_until > $1
+		ORDER BY locked_until DESC
+	`
+	rows, err := getDB(ctx, lr.db).Query(ctx, query, now)
+	if err != nil {
+		return nil, fmt.Errorf("не удалось получить блокировки входа: %w", err)
+	}
+	defer rows.Close()
+
+	var lockouts []models.LoginThrottle
+	for rows.Next() {
+		var throttle models.LoginThrottle
+		err := rows.Scan(
+			&throttle.Scope,
+			&throttle.Key,
+			&throttle.FailedCount,
+			&throttle.LastFailedAt,
+			&throttle.LockedUntil,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("ошибка при сканировании строки: %w", err)
+		}
+		lockouts = append(lockouts, throttle)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("ошибка чтения строк: %v", err)
+	}
+	return lockouts, nil
+}
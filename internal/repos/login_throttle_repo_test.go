@@ -0,0 +1,78 @@
+package repos_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/forzeyy/avito-internship-spring-service/internal/models"
+	"github.com/forzeyy/avito-internship-spring-service/internal/repos"
+	"github.com/jackc/pgx/v5"
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+// RegisterLoginFailure
+func TestRegisterLoginFailure_Success(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	assert.NoError(t, err)
+	defer mock.Close()
+
+	repo := repos.NewLoginThrottleRepo(mock)
+
+	now := time.Now()
+	resetBefore := now.Add(-15 * time.Minute)
+	rows := pgxmock.NewRows([]string{"scope", "key", "failed_count", "last_failed_at", "locked_until"}).
+		AddRow(models.LoginThrottleScopeEmail, "user@mail.ru", 4, now, (*time.Time)(nil))
+
+	mock.ExpectQuery("INSERT INTO login_throttles (.+) ON CONFLICT").
+		WithArgs(models.LoginThrottleScopeEmail, "user@mail.ru", now, resetBefore).
+		WillReturnRows(rows)
+
+	throttle, err := repo.RegisterLoginFailure(context.Background(), models.LoginThrottleScopeEmail, "user@mail.ru", now, resetBefore)
+	assert.NoError(t, err)
+	assert.Equal(t, 4, throttle.FailedCount)
+	assert.Nil(t, throttle.LockedUntil)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// GetLoginThrottle
+func TestGetLoginThrottle_NotFound(t *testing.T) {
+	mock, _ := pgxmock.NewPool()
+	defer mock.Close()
+
+	repo := repos.NewLoginThrottleRepo(mock)
+
+	mock.ExpectQuery("SELECT (.+) FROM login_throttles").
+		WithArgs(models.LoginThrottleScopeIP, "10.0.0.1").
+		WillReturnError(pgx.ErrNoRows)
+
+	throttle, err := repo.GetLoginThrottle(context.Background(), models.LoginThrottleScopeIP, "10.0.0.1")
+	assert.NoError(t, err)
+	assert.Nil(t, throttle)
+}
+
+// GetActiveLockouts
+func TestGetActiveLockouts_Success(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	assert.NoError(t, err)
+	defer mock.Close()
+
+	repo := repos.NewLoginThrottleRepo(mock)
+
+	now := time.Now()
+	lockedUntil := now.Add(10 * time.Minute)
+	rows := pgxmock.NewRows([]string{"scope", "key", "failed_count", "last_failed_at", "locked_until"}).
+		AddRow(models.LoginThrottleScopeEmail, "user@mail.ru", 10, now, &lockedUntil)
+
+	mock.ExpectQuery("SELECT (.+) FROM login_throttles WHERE locked_until > \\$1").
+		WithArgs(now).
+		WillReturnRows(rows)
+
+	lockouts, err := repo.GetActiveLockouts(context.Background(), now)
+	assert.NoError(t, err)
+	if assert.Len(t, lockouts, 1) {
+		assert.Equal(t, &lockedUntil, lockouts[0].LockedUntil)
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,80 @@
+package repos
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/forzeyy/avito-internship-spring-service/internal/models"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+type PasswordResetRepo interface {
+	CreateResetToken(ctx context.Context, token *models.PasswordResetToken) error
+	LockResetToken(ctx context.Context, tokenHash string) (*models.PasswordResetToken, error)
+	RevokeUserResetTokens(ctx context.Context, userID uuid.UUID) error
+}
+
+type passwordResetRepo struct {
+	db DB
+}
+
+func NewPasswordResetRepo(db DB) PasswordResetRepo {
+	return &passwordResetRepo{db: db}
+}
+
+func (pr *passwordResetRepo) CreateResetToken(ctx context.Context, token *models.PasswordResetToken) error {
+	query := `
+		INSERT INTO password_reset_tokens (id, user_id, token_hash, expires_at, created_at)
+		VALUES ($1, $2, $3, $4, $5)
+	`
+	_, err := getDB(ctx, pr.db).Exec(ctx, query, token.ID, token.UserID, token.TokenHash, token.ExpiresAt, token.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("не удалось сохранить токен сброса пароля: %v", err)
+	}
+	return nil
+}
+
+// LockResetToken блокирует токен до конца транзакции, чтобы один токен нельзя было использовать дважды параллельными запросами.
+// Вызывается внутри TxManager.WithTx.
+func (pr *passwordResetRepo) LockResetToken(ctx context.Context, tokenHash string) (*models.PasswordResetToken, error) {
+	var token models.PasswordResetToken
+
+	query := `
+		SELECT id, user_id, token_hash, expires_at, created_at, used_at
+		FROM password_reset_tokens
+		WHERE token_hash = $1
+		FOR UPDATE
+	`
+	err := getDB(ctx, pr.db).QueryRow(ctx, query, tokenHash).Scan(
+		&token.ID,
+		&token.UserID,
+		&token.TokenHash,
+		&token.ExpiresAt,
+		&token.CreatedAt,
+		&token.UsedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("не удалось получить токен сброса пароля: %v", err)
+	}
+	return &token, nil
+}
+
+// RevokeUserResetTokens помечает использованными все действующие токены пользователя:
+// после нового запроса или успешного сброса старые письма перестают работать
+func (pr *passwordResetRepo) RevokeUserResetTokens(ctx context.Context, userID uuid.UUID) error {
+	query := `
+		UPDATE password_reset_tokens
+		SET used_at = NOW()
+		WHERE user_id = $1 AND used_at IS NULL
+	`
+	_, err := getDB(ctx, pr.db).Exec(ctx, query, userID)
+	if err != nil {
+		return fmt.Errorf("не удалось отозвать токены сброса пароля: %v", err)
+	}
+	return nil
+}
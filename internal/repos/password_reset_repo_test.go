@@ -0,0 +1,110 @@
+package repos_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/forzeyy/avito-internship-spring-service/internal/models"
+	"github.com/forzeyy/avito-internship-spring-service/internal/repos"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+// CreateResetToken
+func TestCreateResetToken_Success(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	assert.NoError(t, err)
+	defer mock.Close()
+
+	repo := repos.NewPasswordResetRepo(mock)
+
+	token := &models.PasswordResetToken{
+		ID:        uuid.New(),
+		UserID:    uuid.New(),
+		TokenHash: "hash",
+		ExpiresAt: time.Now().Add(time.Hour),
+		CreatedAt: time.Now(),
+	}
+
+	mock.ExpectExec("INSERT INTO password_reset_tokens").
+		WithArgs(token.ID, token.UserID, token.TokenHash, token.ExpiresAt, token.CreatedAt).
+		WillReturnResult(pgxmock.NewResult("INSERT", 1))
+
+	err = repo.CreateResetToken(context.Background(), token)
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// LockResetToken
+func TestLockResetToken_Success(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	assert.NoError(t, err)
+	defer mock.Close()
+
+	repo := repos.NewPasswordResetRepo(mock)
+
+	id, userID := uuid.New(), uuid.New()
+	rows := pgxmock.NewRows([]string{"id", "user_id", "token_hash", "expires_at", "created_at", "used_at"}).
+		AddRow(id, userID, "hash", time.Now().Add(time.Hour), time.Now(), (*time.Time)(nil))
+
+	mock.ExpectQuery("SELECT (.+) FROM password_reset_tokens WHERE token_hash = \\$1 FOR UPDATE").
+		WithArgs("hash").
+		WillReturnRows(rows)
+
+	token, err := repo.LockResetToken(context.Background(), "hash")
+	assert.NoError(t, err)
+	assert.Equal(t, userID, token.UserID)
+	assert.Nil(t, token.UsedAt)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestLockResetToken_NotFound(t *testing.T) {
+	mock, _ := pgxmock.NewPool()
+	defer mock.Close()
+
+	repo := repos.NewPasswordResetRepo(mock)
+
+	mock.ExpectQuery("SELECT (.+) FROM password_reset_tokens").
+		WithArgs("missing").
+		WillReturnError(pgx.ErrNoRows)
+
+	token, err := repo.LockResetToken(context.Background(), "missing")
+	assert.NoError(t, err)
+	assert.Nil(t, token)
+}
+
+// RevokeUserResetTokens
+func TestRevokeUserResetTokens_Success(t *testing.T) {
+	mock, _ := pgxmock.NewPool()
+	defer mock.Close()
+
+	repo := repos.NewPasswordResetRepo(mock)
+	userID := uuid.New()
+
+	mock.ExpectExec("UPDATE password_reset_tokens SET used_at = NOW\\(\\) WHERE user_id = \\$1 AND used_at IS NULL").
+		WithArgs(userID).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 2))
+
+	err := repo.RevokeUserResetTokens(context.Background(), userID)
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRevokeUserResetTokens_DBError(t *testing.T) {
+	mock, _ := pgxmock.NewPool()
+	defer mock.Close()
+
+	repo := repos.NewPasswordResetRepo(mock)
+	userID := uuid.New()
+
+	mock.ExpectExec("UPDATE password_reset_tokens").
+		WithArgs(userID).
+		WillReturnError(errors.New("db error"))
+
+	err := repo.RevokeUserResetTokens(context.Background(), userID)
+	assert.ErrorContains(t, err, "не удалось отозвать токены сброса пароля")
+}
@@ -4,14 +4,34 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
+	"github.com/forzeyy/avito-internship-spring-service/internal/apperrors"
 	"github.com/forzeyy/avito-internship-spring-service/internal/models"
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 )
 
+var (
+	ErrNoProductsToDelete = apperrors.Conflict("нет товаров для удаления")
+	ErrProductNotFound    = apperrors.NotFound("товар не найден")
+	// ErrDuplicateBarcode возвращается, когда уникальный индекс не дал принять посылку повторно в ту же приемку
+	ErrDuplicateBarcode = apperrors.Conflict("товар с таким штрихкодом уже принят")
+)
+
+const receptionBarcodeUniqueIndex = "uniq_products_reception_id_barcode"
+
 type ProductRepo interface {
 	AddProduct(ctx context.Context, product *models.Product) error
+	AddProducts(ctx context.Context, products []models.Product) error
 	DeleteLastProduct(ctx context.Context, pvzID uuid.UUID) error
+	GetProductByID(ctx context.Context, id uuid.UUID) (*models.Product, error)
+	DeleteProduct(ctx context.Context, id uuid.UUID) error
+	GetProductsByReceptionIDs(ctx context.Context, receptionIDs []uuid.UUID) ([]models.Product, error)
+	LockBarcodes(ctx context.Context, barcodes []string) error
+	FindBarcodes(ctx context.Context, barcodes []string, receptionID *uuid.UUID) ([]string, error)
+	GetProductsByBarcode(ctx context.Context, barcode string) ([]models.ProductLocation, error)
 }
 
 type productRepo struct {
@@ -24,16 +44,54 @@ func NewProductRepo(db DB) ProductRepo {
 
 func (pr *productRepo) AddProduct(ctx context.Context, product *models.Product) error {
 	query := `
-		INSERT INTO products (id, type, reception_id, received_at)
-		VALUES ($1, $2, $3, $4)
+		INSERT INTO products (id, type, reception_id, received_at, created_by, barcode)
+		VALUES ($1, $2, $3, $4, $5, $6)
 	`
-	_, err := pr.db.Exec(ctx, query, product.ID, product.Type, product.ReceptionID, product.DateTime)
+	_, err := getDB(ctx, pr.db).Exec(ctx, query, product.ID, product.Type, product.ReceptionID, product.DateTime, product.CreatedBy, product.Barcode)
 	if err != nil {
+		if isDuplicateBarcode(err) {
+			return ErrDuplicateBarcode
+		}
 		return fmt.Errorf("не удалось добавить продукт: %v", err)
 	}
 	return nil
 }
 
+// AddProducts вставляет пакет товаров одним запросом: колонки передаются массивами и разворачиваются через unnest
+func (pr *productRepo) AddProducts(ctx context.Context, products []models.Product) error {
+	if len(products) == 0 {
+		return nil
+	}
+
+	ids := make([]uuid.UUID, len(products))
+	types := make([]string, len(products))
+	receptionIDs := make([]uuid.UUID, len(products))
+	receivedAt := make([]time.Time, len(products))
+	createdBy := make([]*uuid.UUID, len(products))
+	barcodes := make([]*string, len(products))
+	for i, product := range products {
+		ids[i] = product.ID
+		types[i] = product.Type
+		receptionIDs[i] = product.ReceptionID
+		receivedAt[i] = product.DateTime
+		createdBy[i] = product.CreatedBy
+		barcodes[i] = product.Barcode
+	}
+
+	query := `
+		INSERT INTO products (id, type, reception_id, received_at, created_by, barcode)
+		SELECT * FROM unnest($1::uuid[], $2::varchar[], $3::uuid[], $4::timestamp[], $5::uuid[], $6::varchar[])
+	`
+	_, err := getDB(ctx, pr.db).Exec(ctx, query, ids, types, receptionIDs, receivedAt, createdBy, barcodes)
+	if err != nil {
+		if isDuplicateBarcode(err) {
+			return ErrDuplicateBarcode
+		}
+		return fmt.Errorf("не удалось добавить товары: %v", err)
+	}
+	return nil
+}
+
 func (pr *productRepo) DeleteLastProduct(ctx context.Context, pvzID uuid.UUID) error {
 	query := `
         WITH last_product AS (
@@ -53,12 +111,179 @@ func (pr *productRepo) DeleteLastProduct(ctx context.Context, pvzID uuid.UUID) e
         WHERE id = (SELECT id FROM last_product)
     `
 
-	result, err := pr.db.Exec(ctx, query, pvzID)
+	result, err := getDB(ctx, pr.db).Exec(ctx, query, pvzID)
 	if err != nil {
 		return fmt.Errorf("не удалось удалить последний товар: %w", err)
 	}
 	if result.RowsAffected() == 0 {
-		return errors.New("нет товаров для удаления")
+		return ErrNoProductsToDelete
 	}
 	return nil
 }
+
+func (pr *productRepo) GetProductByID(ctx context.Context, id uuid.UUID) (*models.Product, error) {
+	var product models.Product
+
+	query := `
+		SELECT id, type, reception_id, received_at, created_by, barcode
+		FROM products
+		WHERE id = $1
+	`
+	err := getDB(ctx, pr.db).QueryRow(ctx, query, id).Scan(
+		&product.ID,
+		&product.Type,
+		&product.ReceptionID,
+		&product.DateTime,
+		&product.CreatedBy,
+		&product.Barcode,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("не удалось получить товар: %v", err)
+	}
+	return &product, nil
+}
+
+func (pr *productRepo) DeleteProduct(ctx context.Context, id uuid.UUID) error {
+	query := `
+		DELETE FROM products
+		WHERE id = $1
+	`
+	result, err := getDB(ctx, pr.db).Exec(ctx, query, id)
+	if err != nil {
+		return fmt.Errorf("не удалось удалить товар: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return ErrProductNotFound
+	}
+	return nil
+}
+
+func (pr *productRepo) GetProductsByReceptionIDs(ctx context.Context, receptionIDs []uuid.UUID) ([]models.Product, error) {
+	query := `
+		SELECT id, type, reception_id, received_at, barcode
+		FROM products
+		WHERE reception_id = ANY($1)
+		ORDER BY received_at
+	`
+	rows, err := getDB(ctx, pr.db).Query(ctx, query, receptionIDs)
+	if err != nil {
+		return nil, fmt.Errorf("не удалось получить товары: %w", err)
+	}
+	defer rows.Close()
+
+	var products []models.Product
+	for rows.Next() {
+		var product models.Product
+		err := rows.Scan(&product.ID, &product.Type, &product.ReceptionID, &product.DateTime, &product.Barcode)
+		if err != nil {
+			return nil, fmt.Errorf("ошибка при сканировании строки: %w", err)
+		}
+		products = append(products, product)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("ошибка чтения строк: %v", err)
+	}
+	return products, nil
+}
+
+// LockBarcodes берет транзакционные advisory-блокировки на штрихкоды, чтобы при глобальной уникальности
+// одну посылку нельзя было одновременно принять в двух ПВЗ. Блокировки берутся в порядке сортировки, чтобы
+// пакеты с пересекающимися штрихкодами не ждали друг друга по кругу. Вызывается внутри TxManager.WithTx
+func (pr *productRepo) LockBarcodes(ctx context.Context, barcodes []string) error {
+	if len(barcodes) == 0 {
+		return nil
+	}
+
+	query := `
+		SELECT pg_advisory_xact_lock(hashtextextended(b.barcode, 0))
+		FROM (SELECT DISTINCT unnest($1::varchar[]) AS barcode ORDER BY 1) b
+	`
+	_, err := getDB(ctx, pr.db).Exec(ctx, query, barcodes)
+	if err != nil {
+		return fmt.Errorf("не удалось заблокировать штрихкоды: %v", err)
+	}
+	return nil
+}
+
+// FindBarcodes возвращает штрихкоды из barcodes, которые уже есть у товаров приемки receptionID,
+// а если receptionID nil - у любых товаров
+func (pr *productRepo) FindBarcodes(ctx context.Context, barcodes []string, receptionID *uuid.UUID) ([]string, error) {
+	if len(barcodes) == 0 {
+		return nil, nil
+	}
+
+	query := `
+		SELECT DISTINCT barcode
+		FROM products
+		WHERE barcode = ANY($1) AND ($2::uuid IS NULL OR reception_id = $2)
+	`
+	rows, err := getDB(ctx, pr.db).Query(ctx, query, barcodes, receptionID)
+	if err != nil {
+		return nil, fmt.Errorf("не удалось проверить штрихкоды: %w", err)
+	}
+	defer rows.Close()
+
+	var found []string
+	for rows.Next() {
+		var barcode string
+		if err := rows.Scan(&barcode); err != nil {
+			return nil, fmt.Errorf("ошибка при сканировании строки: %w", err)
+		}
+		found = append(found, barcode)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("ошибка чтения строк: %v", err)
+	}
+	return found, nil
+}
+
+// GetProductsByBarcode возвращает все приемы посылки со штрихкодом, начиная с последнего
+func (pr *productRepo) GetProductsByBarcode(ctx context.Context, barcode string) ([]models.ProductLocation, error) {
+	query := `
+		SELECT p.id, p.type, p.reception_id, p.received_at, p.barcode, r.pvz_id, r.status, v.city
+		FROM products p
+		JOIN receptions r ON r.id = p.reception_id
+		JOIN pvzs v ON v.id = r.pvz_id
+		WHERE p.barcode = $1
+		ORDER BY p.received_at DESC, p.id DESC
+	`
+	rows, err := getDB(ctx, pr.db).Query(ctx, query, barcode)
+	if err != nil {
+		return nil, fmt.Errorf("не удалось найти товар по штрихкоду: %w", err)
+	}
+	defer rows.Close()
+
+	var locations []models.ProductLocation
+	for rows.Next() {
+		var location models.ProductLocation
+		err := rows.Scan(
+			&location.Product.ID,
+			&location.Product.Type,
+			&location.Product.ReceptionID,
+			&location.Product.DateTime,
+			&location.Product.Barcode,
+			&location.PVZID,
+			&location.ReceptionStatus,
+			&location.City,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("ошибка при сканировании строки: %w", err)
+		}
+		locations = append(locations, location)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("ошибка чтения строк: %v", err)
+	}
+	return locations, nil
+}
+
+func isDuplicateBarcode(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "23505" && pgErr.ConstraintName == receptionBarcodeUniqueIndex
+}
@@ -9,6 +9,8 @@ import (
 	"github.com/forzeyy/avito-internship-spring-service/internal/models"
 	"github.com/forzeyy/avito-internship-spring-service/internal/repos"
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/pashagolub/pgxmock/v4"
 	"github.com/stretchr/testify/assert"
 )
@@ -29,7 +31,7 @@ func TestAddProduct_Success(t *testing.T) {
 	}
 
 	mock.ExpectExec("INSERT INTO products").
-		WithArgs(product.ID, product.Type, product.ReceptionID, product.DateTime).
+		WithArgs(product.ID, product.Type, product.ReceptionID, product.DateTime, product.CreatedBy, product.Barcode).
 		WillReturnResult(pgxmock.NewResult("INSERT", 1))
 
 	err = repo.AddProduct(context.Background(), product)
@@ -52,7 +54,7 @@ func TestAddProduct_Error(t *testing.T) {
 	}
 
 	mock.ExpectExec("INSERT INTO products").
-		WithArgs(product.ID, product.Type, product.ReceptionID, product.DateTime).
+		WithArgs(product.ID, product.Type, product.ReceptionID, product.DateTime, product.CreatedBy, product.Barcode).
 		WillReturnError(errors.New("insert failed"))
 
 	err = repo.AddProduct(context.Background(), product)
@@ -61,6 +63,75 @@ func TestAddProduct_Error(t *testing.T) {
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
+func TestAddProduct_DuplicateBarcode(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	assert.NoError(t, err)
+	defer mock.Close()
+
+	repo := repos.NewProductRepo(mock)
+
+	barcode := "4601234567890"
+	product := &models.Product{
+		ID:          uuid.New(),
+		Type:        "обувь",
+		ReceptionID: uuid.New(),
+		DateTime:    time.Now(),
+		Barcode:     &barcode,
+	}
+
+	mock.ExpectExec("INSERT INTO products").
+		WithArgs(product.ID, product.Type, product.ReceptionID, product.DateTime, product.CreatedBy, product.Barcode).
+		WillReturnError(&pgconn.PgError{Code: "23505", ConstraintName: "uniq_products_reception_id_barcode"})
+
+	err = repo.AddProduct(context.Background(), product)
+	assert.ErrorIs(t, err, repos.ErrDuplicateBarcode)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// AddProducts
+func TestAddProducts_Success(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	assert.NoError(t, err)
+	defer mock.Close()
+
+	repo := repos.NewProductRepo(mock)
+
+	createdBy := uuid.New()
+	receptionID := uuid.New()
+	now := time.Now()
+	barcode := "4601234567890"
+	products := []models.Product{
+		{ID: uuid.New(), Type: "обувь", ReceptionID: receptionID, DateTime: now, CreatedBy: &createdBy, Barcode: &barcode},
+		{ID: uuid.New(), Type: "одежда", ReceptionID: receptionID, DateTime: now.Add(time.Microsecond), CreatedBy: &createdBy},
+	}
+
+	mock.ExpectExec("INSERT INTO products (.+) SELECT \\* FROM unnest").
+		WithArgs(
+			[]uuid.UUID{products[0].ID, products[1].ID},
+			[]string{"обувь", "одежда"},
+			[]uuid.UUID{receptionID, receptionID},
+			[]time.Time{products[0].DateTime, products[1].DateTime},
+			[]*uuid.UUID{&createdBy, &createdBy},
+			[]*string{&barcode, nil},
+		).
+		WillReturnResult(pgxmock.NewResult("INSERT", 2))
+
+	err = repo.AddProducts(context.Background(), products)
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAddProducts_Empty(t *testing.T) {
+	mock, _ := pgxmock.NewPool()
+	defer mock.Close()
+
+	repo := repos.NewProductRepo(mock)
+
+	err := repo.AddProducts(context.Background(), nil)
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
 // DeleteLastProduct
 func TestDeleteLastProduct_Success(t *testing.T) {
 	mock, err := pgxmock.NewPool()
@@ -116,3 +187,174 @@ func TestDeleteLastProduct_ExecError(t *testing.T) {
 	assert.Contains(t, err.Error(), "не удалось удалить последний товар")
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
+
+// GetProductByID
+func TestGetProductByID_Success(t *testing.T) {
+	mock, _ := pgxmock.NewPool()
+	defer mock.Close()
+
+	repo := repos.NewProductRepo(mock)
+
+	id, receptionID := uuid.New(), uuid.New()
+	rows := pgxmock.NewRows([]string{"id", "type", "reception_id", "received_at", "created_by", "barcode"}).
+		AddRow(id, "обувь", receptionID, time.Now(), (*uuid.UUID)(nil), (*string)(nil))
+
+	mock.ExpectQuery("SELECT (.+) FROM products WHERE id = \\$1").
+		WithArgs(id).
+		WillReturnRows(rows)
+
+	product, err := repo.GetProductByID(context.Background(), id)
+	assert.NoError(t, err)
+	assert.Equal(t, receptionID, product.ReceptionID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetProductByID_NotFound(t *testing.T) {
+	mock, _ := pgxmock.NewPool()
+	defer mock.Close()
+
+	repo := repos.NewProductRepo(mock)
+
+	id := uuid.New()
+	mock.ExpectQuery("SELECT (.+) FROM products").
+		WithArgs(id).
+		WillReturnError(pgx.ErrNoRows)
+
+	product, err := repo.GetProductByID(context.Background(), id)
+	assert.NoError(t, err)
+	assert.Nil(t, product)
+}
+
+// DeleteProduct
+func TestDeleteProduct_NotFound(t *testing.T) {
+	mock, _ := pgxmock.NewPool()
+	defer mock.Close()
+
+	repo := repos.NewProductRepo(mock)
+
+	id := uuid.New()
+	mock.ExpectExec("DELETE FROM products WHERE id = \\$1").
+		WithArgs(id).
+		WillReturnResult(pgxmock.NewResult("DELETE", 0))
+
+	err := repo.DeleteProduct(context.Background(), id)
+	assert.ErrorIs(t, err, repos.ErrProductNotFound)
+}
+
+// GetProductsByReceptionIDs
+func TestGetProductsByReceptionIDs_Success(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	assert.NoError(t, err)
+	defer mock.Close()
+
+	repo := repos.NewProductRepo(mock)
+
+	receptionIDs := []uuid.UUID{uuid.New()}
+
+	barcode := "4601234567890"
+	rows := pgxmock.NewRows([]string{"id", "type", "reception_id", "received_at", "barcode"}).
+		AddRow(uuid.New(), "обувь", receptionIDs[0], time.Now(), &barcode).
+		AddRow(uuid.New(), "одежда", receptionIDs[0], time.Now(), (*string)(nil))
+
+	mock.ExpectQuery("SELECT (.+) FROM products WHERE reception_id = ANY").
+		WithArgs(receptionIDs).
+		WillReturnRows(rows)
+
+	result, err := repo.GetProductsByReceptionIDs(context.Background(), receptionIDs)
+	assert.NoError(t, err)
+	assert.Len(t, result, 2)
+	assert.Equal(t, "обувь", result[0].Type)
+	assert.Equal(t, &barcode, result[0].Barcode)
+	assert.Nil(t, result[1].Barcode)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetProductsByReceptionIDs_Error(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	assert.NoError(t, err)
+	defer mock.Close()
+
+	repo := repos.NewProductRepo(mock)
+
+	receptionIDs := []uuid.UUID{uuid.New()}
+
+	mock.ExpectQuery("SELECT (.+) FROM products WHERE reception_id = ANY").
+		WithArgs(receptionIDs).
+		WillReturnError(errors.New("select failed"))
+
+	result, err := repo.GetProductsByReceptionIDs(context.Background(), receptionIDs)
+	assert.Error(t, err)
+	assert.Nil(t, result)
+	assert.Contains(t, err.Error(), "не удалось получить товары")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// LockBarcodes
+func TestLockBarcodes(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	assert.NoError(t, err)
+	defer mock.Close()
+
+	repo := repos.NewProductRepo(mock)
+
+	barcodes := []string{"B-2", "A-1"}
+	mock.ExpectExec("SELECT pg_advisory_xact_lock(.+)ORDER BY 1").
+		WithArgs(barcodes).
+		WillReturnResult(pgxmock.NewResult("SELECT", 2))
+
+	assert.NoError(t, repo.LockBarcodes(context.Background(), barcodes))
+	assert.NoError(t, repo.LockBarcodes(context.Background(), nil))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// FindBarcodes
+func TestFindBarcodes_InReception(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	assert.NoError(t, err)
+	defer mock.Close()
+
+	repo := repos.NewProductRepo(mock)
+
+	receptionID := uuid.New()
+	barcodes := []string{"4601234567890", "4601234567891"}
+
+	mock.ExpectQuery("SELECT DISTINCT barcode FROM products WHERE barcode = ANY\\(\\$1\\) AND \\(\\$2::uuid IS NULL OR reception_id = \\$2\\)").
+		WithArgs(barcodes, &receptionID).
+		WillReturnRows(pgxmock.NewRows([]string{"barcode"}).AddRow("4601234567891"))
+
+	found, err := repo.FindBarcodes(context.Background(), barcodes, &receptionID)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"4601234567891"}, found)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// GetProductsByBarcode
+func TestGetProductsByBarcode_Success(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	assert.NoError(t, err)
+	defer mock.Close()
+
+	repo := repos.NewProductRepo(mock)
+
+	barcode := "4601234567890"
+	pvzID := uuid.New()
+	receivedAt := time.Now()
+
+	rows := pgxmock.NewRows([]string{"id", "type", "reception_id", "received_at", "barcode", "pvz_id", "status", "city"}).
+		AddRow(uuid.New(), "одежда", uuid.New(), receivedAt, &barcode, pvzID, "close", "Казань")
+
+	mock.ExpectQuery("SELECT (.+) FROM products p JOIN receptions r (.+) JOIN pvzs v (.+) WHERE p.barcode = \\$1").
+		WithArgs(barcode).
+		WillReturnRows(rows)
+
+	locations, err := repo.GetProductsByBarcode(context.Background(), barcode)
+	assert.NoError(t, err)
+	if assert.Len(t, locations, 1) {
+		assert.Equal(t, pvzID, locations[0].PVZID)
+		assert.Equal(t, "Казань", locations[0].City)
+		assert.Equal(t, "close", locations[0].ReceptionStatus)
+		assert.Equal(t, receivedAt, locations[0].Product.DateTime)
+		assert.Equal(t, &barcode, locations[0].Product.Barcode)
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,103 @@
+package repos
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/forzeyy/avito-internship-spring-service/internal/apperrors"
+	"github.com/forzeyy/avito-internship-spring-service/internal/models"
+	"github.com/google/uuid"
+)
+
+var ErrAssignmentNotFound = apperrors.NotFound("сотрудник не закреплен за этим ПВЗ")
+
+type PVZAssignmentRepo interface {
+	AssignEmployee(ctx context.Context, assignment *models.PVZAssignment) error
+	UnassignEmployee(ctx context.Context, pvzID, userID uuid.UUID) error
+	GetAssignments(ctx context.Context, pvzID uuid.UUID) ([]models.PVZAssignment, error)
+	IsAssigned(ctx context.Context, pvzID, userID uuid.UUID) (bool, error)
+}
+
+type pvzAssignmentRepo struct {
+	db DB
+}
+
+func NewPVZAssignmentRepo(db DB) PVZAssignmentRepo {
+	return &pvzAssignmentRepo{db: db}
+}
+
+// AssignEmployee закрепляет сотрудника за ПВЗ; повторное закрепление ничего не меняет
+func (ar *pvzAssignmentRepo) AssignEmployee(ctx context.Context, assignment *models.PVZAssignment) error {
+	query := `
+		INSERT INTO pvz_employees (pvz_id, user_id, assigned_by, assigned_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (pvz_id, user_id) DO NOTHING
+	`
+	_, err := getDB(ctx, ar.db).Exec(ctx, query, assignment.PVZID, assignment.UserID, assignment.AssignedBy, assignment.AssignedAt)
+	if err != nil {
+		return fmt.Errorf("не удалось закрепить сотрудника за ПВЗ: %v", err)
+	}
+	return nil
+}
+
+func (ar *pvzAssignmentRepo) UnassignEmployee(ctx context.Context, pvzID, userID uuid.UUID) error {
+	query := `
+		DELETE FROM pvz_employees
+		WHERE pvz_id = $1 AND user_id = $2
+	`
+	result, err := getDB(ctx, ar.db).Exec(ctx, query, pvzID, userID)
+	if err != nil {
+		return fmt.Errorf("не удалось открепить сотрудника от ПВЗ: %v", err)
+	}
+	if result.RowsAffected() == 0 {
+		return ErrAssignmentNotFound
+	}
+	return nil
+}
+
+func (ar *pvzAssignmentRepo) GetAssignments(ctx context.Context, pvzID uuid.UUID) ([]models.PVZAssignment, error) {
+	query := `
+		SELECT pe.pvz_id, pe.user_id, u.email, pe.assigned_by, pe.assigned_at
+		FROM pvz_employees pe
+		JOIN users u ON u.id = pe.user_id
+		WHERE pe.pvz_id = $1
+		ORDER BY u.email
+	`
+	rows, err := getDB(ctx, ar.db).Query(ctx, query, pvzID)
+	if err != nil {
+		return nil, fmt.Errorf("не удалось получить сотрудников ПВЗ: %w", err)
+	}
+	defer rows.Close()
+
+	var assignments []models.PVZAssignment
+	for rows.Next() {
+		var assignment models.PVZAssignment
+		err := rows.Scan(&assignment.PVZID, &assignment.UserID, &assignment.Email, &assignment.AssignedBy, &assignment.AssignedAt)
+		if err != nil {
+			return nil, fmt.Errorf("ошибка при сканировании строки: %w", err)
+		}
+		assignments = append(assignments, assignment)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("ошибка чтения строк: %v", err)
+	}
+	return assignments, nil
+}
+
+func (ar *pvzAssignmentRepo) IsAssigned(ctx context.Context, pvzID, userID uuid.UUID) (bool, error) {
+	var assigned bool
+
+	query := `
+		SELECT EXISTS (
+			SELECT 1
+			FROM pvz_employees
+			WHERE pvz_id = $1 AND user_id = $2
+		)
+	`
+	err := getDB(ctx, ar.db).QueryRow(ctx, query, pvzID, userID).Scan(&assigned)
+	if err != nil {
+		return false, fmt.Errorf("не удалось проверить закрепление за ПВЗ: %v", err)
+	}
+	return assigned, nil
+}
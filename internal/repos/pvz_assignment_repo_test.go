@@ -0,0 +1,73 @@
+package repos_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/forzeyy/avito-internship-spring-service/internal/models"
+	"github.com/forzeyy/avito-internship-spring-service/internal/repos"
+	"github.com/google/uuid"
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+// AssignEmployee
+func TestAssignEmployee_Success(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	assert.NoError(t, err)
+	defer mock.Close()
+
+	repo := repos.NewPVZAssignmentRepo(mock)
+
+	moderatorID := uuid.New()
+	assignment := &models.PVZAssignment{
+		PVZID:      uuid.New(),
+		UserID:     uuid.New(),
+		AssignedBy: &moderatorID,
+		AssignedAt: time.Now(),
+	}
+
+	mock.ExpectExec("INSERT INTO pvz_employees").
+		WithArgs(assignment.PVZID, assignment.UserID, assignment.AssignedBy, assignment.AssignedAt).
+		WillReturnResult(pgxmock.NewResult("INSERT", 1))
+
+	err = repo.AssignEmployee(context.Background(), assignment)
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// UnassignEmployee
+func TestUnassignEmployee_NotFound(t *testing.T) {
+	mock, _ := pgxmock.NewPool()
+	defer mock.Close()
+
+	repo := repos.NewPVZAssignmentRepo(mock)
+
+	pvzID, userID := uuid.New(), uuid.New()
+	mock.ExpectExec("DELETE FROM pvz_employees").
+		WithArgs(pvzID, userID).
+		WillReturnResult(pgxmock.NewResult("DELETE", 0))
+
+	err := repo.UnassignEmployee(context.Background(), pvzID, userID)
+	assert.ErrorIs(t, err, repos.ErrAssignmentNotFound)
+}
+
+// IsAssigned
+func TestIsAssigned(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	assert.NoError(t, err)
+	defer mock.Close()
+
+	repo := repos.NewPVZAssignmentRepo(mock)
+
+	pvzID, userID := uuid.New(), uuid.New()
+	mock.ExpectQuery("SELECT EXISTS").
+		WithArgs(pvzID, userID).
+		WillReturnRows(pgxmock.NewRows([]string{"exists"}).AddRow(true))
+
+	assigned, err := repo.IsAssigned(context.Background(), pvzID, userID)
+	assert.NoError(t, err)
+	assert.True(t, assigned)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -2,15 +2,20 @@ package repos
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
 	"github.com/forzeyy/avito-internship-spring-service/internal/models"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 )
 
 type PVZRepo interface {
 	CreatePVZ(ctx context.Context, pvz *models.PVZ) error
+	GetPVZByID(ctx context.Context, id uuid.UUID) (*models.PVZ, error)
 	GetPVZs(ctx context.Context, startDate, endDate *time.Time, page, limit int) ([]models.PVZ, error)
+	GetPVZsAfter(ctx context.Context, startDate, endDate *time.Time, after *models.PVZCursor, limit int) ([]models.PVZ, error)
 }
 
 type pvzRepo struct {
@@ -26,14 +31,67 @@ func (pr *pvzRepo) CreatePVZ(ctx context.Context, pvz *models.PVZ) error {
 		INSERT INTO pvzs (id, city, reg_date)
 		VALUES ($1, $2, $3)
 	`
-	_, err := pr.db.Exec(ctx, query, pvz.ID, pvz.City, pvz.RegDate)
+	_, err := getDB(ctx, pr.db).Exec(ctx, query, pvz.ID, pvz.City, pvz.RegDate)
 	if err != nil {
 		return fmt.Errorf("не удалось создать ПВЗ: %v", err)
 	}
 	return nil
 }
 
+func (pr *pvzRepo) GetPVZByID(ctx context.Context, id uuid.UUID) (*models.PVZ, error) {
+	var pvz models.PVZ
+
+	query := `
+		SELECT id, city, reg_date
+		FROM pvzs
+		WHERE id = $1
+	`
+	err := getDB(ctx, pr.db).QueryRow(ctx, query, id).Scan(&pvz.ID, &pvz.City, &pvz.RegDate)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("не удалось получить ПВЗ: %v", err)
+	}
+	return &pvz, nil
+}
+
 func (pr *pvzRepo) GetPVZs(ctx context.Context, startDate, endDate *time.Time, page, limit int) ([]models.PVZ, error) {
+	query, args := pvzListQuery(startDate, endDate)
+	argIndex := len(args) + 1
+
+	query += fmt.Sprintf(`
+        ORDER BY reg_date DESC, id DESC
+        LIMIT $%d OFFSET $%d
+    `, argIndex, argIndex+1)
+
+	args = append(args, limit, (page-1)*limit)
+
+	return pr.queryPVZs(ctx, query, args...)
+}
+
+// GetPVZsAfter возвращает страницу ПВЗ, следующих за курсором в порядке (reg_date, id) по убыванию
+func (pr *pvzRepo) GetPVZsAfter(ctx context.Context, startDate, endDate *time.Time, after *models.PVZCursor, limit int) ([]models.PVZ, error) {
+	query, args := pvzListQuery(startDate, endDate)
+	argIndex := len(args) + 1
+
+	if after != nil {
+		query += fmt.Sprintf(" AND (reg_date, id) < ($%d, $%d)", argIndex, argIndex+1)
+		args = append(args, after.RegDate, after.ID)
+		argIndex += 2
+	}
+
+	query += fmt.Sprintf(`
+        ORDER BY reg_date DESC, id DESC
+        LIMIT $%d
+    `, argIndex)
+
+	args = append(args, limit)
+
+	return pr.queryPVZs(ctx, query, args...)
+}
+
+func pvzListQuery(startDate, endDate *time.Time) (string, []any) {
 	var (
 		query = `
 			SELECT id, city, reg_date
@@ -44,26 +102,26 @@ func (pr *pvzRepo) GetPVZs(ctx context.Context, startDate, endDate *time.Time, p
 		argIndex = 1
 	)
 
-	if startDate != nil {
-		query += fmt.Sprintf(" AND EXISTS (SELECT 1 FROM receptions WHERE receptions.pvz_id = pvzs.id AND receptions.created_at >= $%d)", argIndex)
-		args = append(args, startDate)
-		argIndex++
-	}
-
-	if endDate != nil {
-		query += fmt.Sprintf(" AND EXISTS (SELECT 1 FROM receptions WHERE receptions.pvz_id = pvzs.id AND receptions.created_at <= $%d)", argIndex)
-		args = append(args, endDate)
-		argIndex++
+	// ПВЗ попадает в выборку, если у него есть приемка в заданном диапазоне
+	if startDate != nil || endDate != nil {
+		query += " AND EXISTS (SELECT 1 FROM receptions WHERE receptions.pvz_id = pvzs.id"
+		if startDate != nil {
+			query += fmt.Sprintf(" AND receptions.created_at >= $%d", argIndex)
+			args = append(args, startDate)
+			argIndex++
+		}
+		if endDate != nil {
+			query += fmt.Sprintf(" AND receptions.created_at <= $%d", argIndex)
+			args = append(args, endDate)
+		}
+		query += ")"
 	}
 
-	query += fmt.Sprintf(`
-        ORDER BY reg_date DESC
-        LIMIT $%d OFFSET $%d
-    `, argIndex, argIndex+1)
-
-	args = append(args, limit, (page-1)*limit)
+	return query, args
+}
 
-	rows, err := pr.db.Query(ctx, query, args...)
+func (pr *pvzRepo) queryPVZs(ctx context.Context, query string, args ...any) ([]models.PVZ, error) {
+	rows, err := getDB(ctx, pr.db).Query(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("ошибка при получении списка ПВЗ: %w", err)
 	}
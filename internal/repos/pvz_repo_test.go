@@ -9,6 +9,7 @@ import (
 	"github.com/forzeyy/avito-internship-spring-service/internal/models"
 	"github.com/forzeyy/avito-internship-spring-service/internal/repos"
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/pashagolub/pgxmock/v4"
 	"github.com/stretchr/testify/assert"
 )
@@ -89,3 +90,85 @@ func TestGetPVZs_Success(t *testing.T) {
 	assert.Equal(t, expected.City, result[0].City)
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
+
+// GetPVZsAfter
+func TestGetPVZsAfter_WithCursor(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	assert.NoError(t, err)
+	defer mock.Close()
+
+	repo := repos.NewPVZRepo(mock)
+
+	after := &models.PVZCursor{RegDate: time.Now(), ID: uuid.New()}
+
+	rows := pgxmock.NewRows([]string{"id", "city", "reg_date"}).
+		AddRow(uuid.New(), "Казань", after.RegDate.Add(-time.Hour))
+
+	mock.ExpectQuery(`\(reg_date, id\) < \(\$1, \$2\)`).
+		WithArgs(after.RegDate, after.ID, 10).
+		WillReturnRows(rows)
+
+	result, err := repo.GetPVZsAfter(context.Background(), nil, nil, after, 10)
+	assert.NoError(t, err)
+	assert.Len(t, result, 1)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetPVZsAfter_FirstPage(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	assert.NoError(t, err)
+	defer mock.Close()
+
+	repo := repos.NewPVZRepo(mock)
+
+	start := time.Now().Add(-24 * time.Hour)
+
+	mock.ExpectQuery("ORDER BY reg_date DESC, id DESC").
+		WithArgs(&start, 10).
+		WillReturnRows(pgxmock.NewRows([]string{"id", "city", "reg_date"}))
+
+	result, err := repo.GetPVZsAfter(context.Background(), &start, nil, nil, 10)
+	assert.NoError(t, err)
+	assert.Empty(t, result)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// GetPVZByID
+func TestGetPVZByID_Success(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	assert.NoError(t, err)
+	defer mock.Close()
+
+	repo := repos.NewPVZRepo(mock)
+
+	id := uuid.New()
+	rows := pgxmock.NewRows([]string{"id", "city", "reg_date"}).
+		AddRow(id, "Москва", time.Now())
+
+	mock.ExpectQuery("SELECT id, city, reg_date FROM pvzs WHERE id").
+		WithArgs(id).
+		WillReturnRows(rows)
+
+	result, err := repo.GetPVZByID(context.Background(), id)
+	assert.NoError(t, err)
+	assert.Equal(t, id, result.ID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetPVZByID_NotFound(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	assert.NoError(t, err)
+	defer mock.Close()
+
+	repo := repos.NewPVZRepo(mock)
+
+	id := uuid.New()
+	mock.ExpectQuery("SELECT id, city, reg_date FROM pvzs WHERE id").
+		WithArgs(id).
+		WillReturnError(pgx.ErrNoRows)
+
+	result, err := repo.GetPVZByID(context.Background(), id)
+	assert.NoError(t, err)
+	assert.Nil(t, result)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
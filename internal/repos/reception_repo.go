@@ -4,16 +4,30 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
+	"github.com/forzeyy/avito-internship-spring-service/internal/apperrors"
 	"github.com/forzeyy/avito-internship-spring-service/internal/models"
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 )
 
+// ErrOpenReceptionExists возвращается, когда уникальный индекс не дал открыть вторую приемку в ПВЗ
+var ErrOpenReceptionExists = apperrors.Conflict("в ПВЗ уже есть незакрытая приемка")
+
+const openReceptionUniqueIndex = "uniq_receptions_pvz_in_progress"
+
 type ReceptionRepo interface {
 	CreateReception(ctx context.Context, reception *models.Reception) error
 	GetLastOpenReception(ctx context.Context, pvzID uuid.UUID) (*models.Reception, error)
-	CloseLastReception(ctx context.Context, pvzID uuid.UUID) (*models.Reception, error)
+	LockLastOpenReception(ctx context.Context, pvzID uuid.UUID) (*models.Reception, error)
+	CloseLastReception(ctx context.Context, pvzID, closedBy uuid.UUID, closedAt time.Time) (*models.Reception, error)
+	GetReceptionsByPVZIDs(ctx context.Context, pvzIDs []uuid.UUID, startDate, endDate *time.Time) ([]models.Reception, error)
+	GetClosedReceptions(ctx context.Context, pvzID uuid.UUID, page, limit int) ([]models.ReceptionWithProductCount, error)
+	GetReceptions(ctx context.Context, filter models.ReceptionFilter, page, limit int) ([]models.Reception, error)
+	GetReceptionByID(ctx context.Context, id uuid.UUID) (*models.Reception, error)
+	CloseStaleReceptions(ctx context.Context, inactiveSince, closedAt time.Time, limit int) ([]models.Reception, error)
 }
 
 type receptionRepo struct {
@@ -26,48 +40,107 @@ func NewReceptionRepo(db DB) ReceptionRepo {
 
 func (rr *receptionRepo) CreateReception(ctx context.Context, reception *models.Reception) error {
 	query := `
-		INSERT INTO receptions (id, pvz_id, status, created_at)
-		VALUES ($1, $2, $3, $4)
+		INSERT INTO receptions (id, pvz_id, status, created_at, created_by)
+		VALUES ($1, $2, $3, $4, $5)
 	`
-	_, err := rr.db.Exec(ctx, query, reception.ID, reception.PVZID, reception.Status, reception.DateTime)
+	_, err := getDB(ctx, rr.db).Exec(ctx, query, reception.ID, reception.PVZID, reception.Status, reception.DateTime, reception.CreatedBy)
 	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" && pgErr.ConstraintName == openReceptionUniqueIndex {
+			return ErrOpenReceptionExists
+		}
 		return fmt.Errorf("не удалось создать приемку: %v", err)
 	}
 	return nil
 }
 
-func (rr *receptionRepo) CloseLastReception(ctx context.Context, pvzID uuid.UUID) (*models.Reception, error) {
-	var reception models.Reception
+// CloseLastReception закрывает открытую приемку ПВЗ и сохраняет ее итог. Итог считается отдельным запросом
+// после блокировки приемки, чтобы в него попали товары, добавленные, пока закрытие ждало блокировку.
+// closedAt берется с часов сервиса, как и время создания приемки, иначе при разных часовых поясах сервиса и базы
+// длительность приемки посчитается неверно. Вызывается внутри TxManager.WithTx.
+func (rr *receptionRepo) CloseLastReception(ctx context.Context, pvzID, closedBy uuid.UUID, closedAt time.Time) (*models.Reception, error) {
+	reception, err := rr.LockLastOpenReception(ctx, pvzID)
+	if err != nil || reception == nil {
+		return nil, err
+	}
+	return rr.closeReception(ctx, reception.ID, &closedBy, false, closedAt, time.Time{})
+}
+
+// closeReception закрывает заблокированную приемку и считает итог по ее товарам. При автозакрытии приемка
+// закрывается, только если и после блокировки в нее не добавляли товары с inactiveSince; иначе возвращается nil.
+func (rr *receptionRepo) closeReception(ctx context.Context, id uuid.UUID, closedBy *uuid.UUID, autoClosed bool, closedAt, inactiveSince time.Time) (*models.Reception, error) {
+	var (
+		reception      models.Reception
+		productCount   *int
+		productsByType map[string]int
+		firstScanAt    *time.Time
+		lastScanAt     *time.Time
+	)
 
 	query := `
-		WITH last_reception AS (
-			SELECT id
-			FROM receptions
-			WHERE pvz_id = $1 and status = 'in_progress'
-			ORDER BY created_at DESC
-			LIMIT 1
-		)
-		UPDATE receptions
-		SET status = 'close', closed_at = NOW()
-		WHERE id = (SELECT id FROM last_reception)
-		RETURNING id, pvz_id, status, created_at, closed_at
+		UPDATE receptions r
+		SET status = 'close',
+			closed_at = $4,
+			closed_by = $2,
+			auto_closed = $3,
+			product_count = s.product_count,
+			products_by_type = s.products_by_type,
+			first_scan_at = s.first_scan_at,
+			last_scan_at = s.last_scan_at
+		FROM (
+			SELECT COALESCE(SUM(t.cnt), 0)::INTEGER AS product_count,
+				COALESCE(jsonb_object_agg(t.type, t.cnt), '{}'::JSONB) AS products_by_type,
+				MIN(t.first_at) AS first_scan_at,
+				MAX(t.last_at) AS last_scan_at
+			FROM (
+				SELECT type, COUNT(*) AS cnt, MIN(received_at) AS first_at, MAX(received_at) AS last_at
+				FROM products
+				WHERE reception_id = $1
+				GROUP BY type
+			) t
+		) s
+		WHERE r.id = $1
+			AND (NOT $3 OR COALESCE(s.last_scan_at, r.created_at) < $5)
+		RETURNING r.id, r.pvz_id, r.status, r.created_at, r.closed_at, r.created_by, r.closed_by, r.auto_closed,
+			r.product_count, r.products_by_type, r.first_scan_at, r.last_scan_at
 	`
-	err := rr.db.QueryRow(ctx, query, pvzID).Scan(
+	err := getDB(ctx, rr.db).QueryRow(ctx, query, id, closedBy, autoClosed, closedAt, inactiveSince).Scan(
 		&reception.ID,
 		&reception.PVZID,
 		&reception.Status,
 		&reception.DateTime,
 		&reception.ClosedAt,
+		&reception.CreatedBy,
+		&reception.ClosedBy,
+		&reception.AutoClosed,
+		&productCount,
+		&productsByType,
+		&firstScanAt,
+		&lastScanAt,
 	)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, nil
 		}
-		return nil, fmt.Errorf("не удалось закрыть последнюю приемку: %v", err)
+		return nil, fmt.Errorf("не удалось закрыть приемку: %v", err)
 	}
+	reception.Summary = newReceptionSummary(productCount, productsByType, firstScanAt, lastScanAt)
 	return &reception, nil
 }
 
+// newReceptionSummary собирает итог из колонок приемки; nil, если итог еще не сохранен
+func newReceptionSummary(productCount *int, productsByType map[string]int, firstScanAt, lastScanAt *time.Time) *models.ReceptionSummary {
+	if productCount == nil {
+		return nil
+	}
+	return &models.ReceptionSummary{
+		ProductCount:   *productCount,
+		ProductsByType: productsByType,
+		FirstScanAt:    firstScanAt,
+		LastScanAt:     lastScanAt,
+	}
+}
+
 func (rr *receptionRepo) GetLastOpenReception(ctx context.Context, pvzID uuid.UUID) (*models.Reception, error) {
 	var reception models.Reception
 
@@ -78,7 +151,7 @@ func (rr *receptionRepo) GetLastOpenReception(ctx context.Context, pvzID uuid.UU
 		ORDER BY created_at DESC
 		LIMIT 1
 	`
-	err := rr.db.QueryRow(ctx, query, pvzID).Scan(
+	err := getDB(ctx, rr.db).QueryRow(ctx, query, pvzID).Scan(
 		&reception.ID,
 		&reception.PVZID,
 		&reception.Status,
@@ -93,3 +166,302 @@ func (rr *receptionRepo) GetLastOpenReception(ctx context.Context, pvzID uuid.UU
 	}
 	return &reception, nil
 }
+
+// LockLastOpenReception блокирует открытую приемку до конца транзакции, чтобы ее не закрыли параллельно.
+// Вызывается внутри TxManager.WithTx.
+func (rr *receptionRepo) LockLastOpenReception(ctx context.Context, pvzID uuid.UUID) (*models.Reception, error) {
+	var reception models.Reception
+
+	query := `
+		SELECT id, pvz_id, status, created_at, closed_at
+		FROM receptions
+		WHERE pvz_id = $1 AND status = 'in_progress'
+		ORDER BY created_at DESC
+		LIMIT 1
+		FOR UPDATE
+	`
+	err := getDB(ctx, rr.db).QueryRow(ctx, query, pvzID).Scan(
+		&reception.ID,
+		&reception.PVZID,
+		&reception.Status,
+		&reception.DateTime,
+		&reception.ClosedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("не удалось заблокировать открытую приемку: %v", err)
+	}
+	return &reception, nil
+}
+
+func (rr *receptionRepo) GetReceptionsByPVZIDs(ctx context.Context, pvzIDs []uuid.UUID, startDate, endDate *time.Time) ([]models.Reception, error) {
+	var (
+		query = `
+			SELECT id, pvz_id, status, created_at, closed_at
+			FROM receptions
+			WHERE pvz_id = ANY($1)
+		`
+		args     = []any{pvzIDs}
+		argIndex = 2
+	)
+
+	if startDate != nil {
+		query += fmt.Sprintf(" AND created_at >= $%d", argIndex)
+		args = append(args, startDate)
+		argIndex++
+	}
+
+	if endDate != nil {
+		query += fmt.Sprintf(" AND created_at <= $%d", argIndex)
+		args = append(args, endDate)
+	}
+
+	query += " ORDER BY created_at DESC"
+
+	rows, err := getDB(ctx, rr.db).Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("не удалось получить приемки: %w", err)
+	}
+	defer rows.Close()
+
+	var receptions []models.Reception
+	for rows.Next() {
+		var reception models.Reception
+		err := rows.Scan(
+			&reception.ID,
+			&reception.PVZID,
+			&reception.Status,
+			&reception.DateTime,
+			&reception.ClosedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("ошибка при сканировании строки: %w", err)
+		}
+		receptions = append(receptions, reception)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("ошибка чтения строк: %v", err)
+	}
+	return receptions, nil
+}
+
+func (rr *receptionRepo) GetClosedReceptions(ctx context.Context, pvzID uuid.UUID, page, limit int) ([]models.ReceptionWithProductCount, error) {
+	query := `
+		SELECT r.id, r.pvz_id, r.status, r.created_at, r.closed_at, COUNT(p.id)
+		FROM receptions r
+		LEFT JOIN products p ON p.reception_id = r.id
+		WHERE r.pvz_id = $1 AND r.status = 'close'
+		GROUP BY r.id
+		ORDER BY r.closed_at DESC, r.id DESC
+		LIMIT $2 OFFSET $3
+	`
+	rows, err := getDB(ctx, rr.db).Query(ctx, query, pvzID, limit, (page-1)*limit)
+	if err != nil {
+		return nil, fmt.Errorf("не удалось получить историю приемок: %w", err)
+	}
+	defer rows.Close()
+
+	var receptions []models.ReceptionWithProductCount
+	for rows.Next() {
+		var item models.ReceptionWithProductCount
+		err := rows.Scan(
+			&item.Reception.ID,
+			&item.Reception.PVZID,
+			&item.Reception.Status,
+			&item.Reception.DateTime,
+			&item.Reception.ClosedAt,
+			&item.ProductCount,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("ошибка при сканировании строки: %w", err)
+		}
+		receptions = append(receptions, item)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("ошибка чтения строк: %v", err)
+	}
+	return receptions, nil
+}
+
+func (rr *receptionRepo) GetReceptions(ctx context.Context, filter models.ReceptionFilter, page, limit int) ([]models.Reception, error) {
+	var (
+		query = `
+			SELECT id, pvz_id, status, created_at, closed_at, auto_closed,
+				product_count, products_by_type, first_scan_at, last_scan_at
+			FROM receptions
+			WHERE TRUE
+		`
+		args     []any
+		argIndex = 1
+	)
+
+	if filter.PVZID != nil {
+		query += fmt.Sprintf(" AND pvz_id = $%d", argIndex)
+		args = append(args, *filter.PVZID)
+		argIndex++
+	}
+	if filter.Status != nil {
+		query += fmt.Sprintf(" AND status = $%d", argIndex)
+		args = append(args, *filter.Status)
+		argIndex++
+	}
+	if filter.CreatedFrom != nil {
+		query += fmt.Sprintf(" AND created_at >= $%d", argIndex)
+		args = append(args, filter.CreatedFrom)
+		argIndex++
+	}
+	if filter.CreatedTo != nil {
+		query += fmt.Sprintf(" AND created_at <= $%d", argIndex)
+		args = append(args, filter.CreatedTo)
+		argIndex++
+	}
+	if filter.ClosedFrom != nil {
+		query += fmt.Sprintf(" AND closed_at >= $%d", argIndex)
+		args = append(args, filter.ClosedFrom)
+		argIndex++
+	}
+	if filter.ClosedTo != nil {
+		query += fmt.Sprintf(" AND closed_at <= $%d", argIndex)
+		args = append(args, filter.ClosedTo)
+		argIndex++
+	}
+
+	query += fmt.Sprintf(`
+		ORDER BY created_at DESC, id DESC
+		LIMIT $%d OFFSET $%d
+	`, argIndex, argIndex+1)
+	args = append(args, limit, (page-1)*limit)
+
+	rows, err := getDB(ctx, rr.db).Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("не удалось получить приемки: %w", err)
+	}
+	defer rows.Close()
+
+	var receptions []models.Reception
+	for rows.Next() {
+		var (
+			reception      models.Reception
+			productCount   *int
+			productsByType map[string]int
+			firstScanAt    *time.Time
+			lastScanAt     *time.Time
+		)
+		err := rows.Scan(
+			&reception.ID,
+			&reception.PVZID,
+			&reception.Status,
+			&reception.DateTime,
+			&reception.ClosedAt,
+			&reception.AutoClosed,
+			&productCount,
+			&productsByType,
+			&firstScanAt,
+			&lastScanAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("ошибка при сканировании строки: %w", err)
+		}
+		reception.Summary = newReceptionSummary(productCount, productsByType, firstScanAt, lastScanAt)
+		receptions = append(receptions, reception)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("ошибка чтения строк: %v", err)
+	}
+	return receptions, nil
+}
+
+func (rr *receptionRepo) GetReceptionByID(ctx context.Context, id uuid.UUID) (*models.Reception, error) {
+	var (
+		reception      models.Reception
+		productCount   *int
+		productsByType map[string]int
+		firstScanAt    *time.Time
+		lastScanAt     *time.Time
+	)
+
+	query := `
+		SELECT id, pvz_id, status, created_at, closed_at, auto_closed,
+			product_count, products_by_type, first_scan_at, last_scan_at
+		FROM receptions
+		WHERE id = $1
+	`
+	err := getDB(ctx, rr.db).QueryRow(ctx, query, id).Scan(
+		&reception.ID,
+		&reception.PVZID,
+		&reception.Status,
+		&reception.DateTime,
+		&reception.ClosedAt,
+		&reception.AutoClosed,
+		&productCount,
+		&productsByType,
+		&firstScanAt,
+		&lastScanAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("не удалось получить приемку: %v", err)
+	}
+	reception.Summary = newReceptionSummary(productCount, productsByType, firstScanAt, lastScanAt)
+	return &reception, nil
+}
+
+// CloseStaleReceptions закрывает до limit открытых приемок, в которые с inactiveSince не добавляли товары
+// (а если товаров нет - созданных раньше inactiveSince), и сохраняет их итоги. SKIP LOCKED пропускает приемки,
+// заблокированные добавлением товара или другим экземпляром сервиса, поэтому несколько экземпляров не закроют
+// одну приемку дважды. Выборка видит товары только на момент своего начала, поэтому неактивность перепроверяется
+// при закрытии уже заблокированной приемки, и приемки, в которые успели добавить товар, остаются открытыми.
+// Вызывается внутри TxManager.WithTx.
+func (rr *receptionRepo) CloseStaleReceptions(ctx context.Context, inactiveSince, closedAt time.Time, limit int) ([]models.Reception, error) {
+	query := `
+		SELECT r.id
+		FROM receptions r
+		WHERE r.status = 'in_progress'
+			AND COALESCE(
+				(SELECT MAX(p.received_at) FROM products p WHERE p.reception_id = r.id),
+				r.created_at
+			) < $1
+		ORDER BY r.created_at
+		LIMIT $2
+		FOR UPDATE OF r SKIP LOCKED
+	`
+	rows, err := getDB(ctx, rr.db).Query(ctx, query, inactiveSince, limit)
+	if err != nil {
+		return nil, fmt.Errorf("не удалось найти неактивные приемки: %w", err)
+	}
+	defer rows.Close()
+
+	var ids []uuid.UUID
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("ошибка при сканировании строки: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("ошибка чтения строк: %v", err)
+	}
+	// в транзакции следующий запрос можно выполнить только после закрытия результата
+	rows.Close()
+
+	receptions := make([]models.Reception, 0, len(ids))
+	for _, id := range ids {
+		reception, err := rr.closeReception(ctx, id, nil, true, closedAt, inactiveSince)
+		if err != nil {
+			return nil, err
+		}
+		if reception == nil {
+			continue
+		}
+		receptions = append(receptions, *reception)
+	}
+	return receptions, nil
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/forzeyy/avito-internship-spring-service/internal/repos"
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/pashagolub/pgxmock/v4"
 	"github.com/stretchr/testify/assert"
 )
@@ -22,15 +23,17 @@ func TestCreateReception_Success(t *testing.T) {
 
 	repo := repos.NewReceptionRepo(mock)
 
+	createdBy := uuid.New()
 	reception := &models.Reception{
-		ID:       uuid.New(),
-		PVZID:    uuid.New(),
-		Status:   "in_progress",
-		DateTime: time.Now(),
+		ID:        uuid.New(),
+		PVZID:     uuid.New(),
+		Status:    "in_progress",
+		DateTime:  time.Now(),
+		CreatedBy: &createdBy,
 	}
 
 	mock.ExpectExec("INSERT INTO receptions").
-		WithArgs(reception.ID, reception.PVZID, reception.Status, reception.DateTime).
+		WithArgs(reception.ID, reception.PVZID, reception.Status, reception.DateTime, reception.CreatedBy).
 		WillReturnResult(pgxmock.NewResult("INSERT", 1))
 
 	err = repo.CreateReception(context.Background(), reception)
@@ -47,29 +50,38 @@ func TestCloseLastReception_Success(t *testing.T) {
 	repo := repos.NewReceptionRepo(mock)
 
 	pvzID := uuid.New()
+	userID := uuid.New()
+	receptionID := uuid.New()
 	now := time.Now()
+	createdAt := now.Add(-time.Hour)
+	firstScanAt := now.Add(-50 * time.Minute)
 
-	expected := models.Reception{
-		ID:       uuid.New(),
-		PVZID:    pvzID,
-		Status:   "close",
-		DateTime: now.Add(-time.Hour),
-		ClosedAt: &now,
-	}
+	mock.ExpectQuery("SELECT (.+) FROM receptions WHERE pvz_id = \\$1 AND status = 'in_progress'(.+)FOR UPDATE").
+		WithArgs(pvzID).
+		WillReturnRows(pgxmock.NewRows([]string{"id", "pvz_id", "status", "created_at", "closed_at"}).
+			AddRow(receptionID, pvzID, "in_progress", createdAt, (*time.Time)(nil)))
 
 	rows := pgxmock.NewRows([]string{
-		"id", "pvz_id", "status", "created_at", "closed_at",
-	}).AddRow(expected.ID, expected.PVZID, expected.Status, expected.DateTime, expected.ClosedAt)
+		"id", "pvz_id", "status", "created_at", "closed_at", "created_by", "closed_by", "auto_closed",
+		"product_count", "products_by_type", "first_scan_at", "last_scan_at",
+	}).AddRow(receptionID, pvzID, "close", createdAt, &now, (*uuid.UUID)(nil), &userID, false,
+		&[]int{3}[0], map[string]int{"обувь": 2, "одежда": 1}, &firstScanAt, &now)
 
-	mock.ExpectQuery("WITH last_reception AS").
-		WithArgs(pvzID).
+	mock.ExpectQuery("UPDATE receptions r SET status = 'close'(.+)jsonb_object_agg(.+)WHERE r.id = \\$1").
+		WithArgs(receptionID, &userID, false, now, time.Time{}).
 		WillReturnRows(rows)
 
-	result, err := repo.CloseLastReception(context.Background(), pvzID)
+	result, err := repo.CloseLastReception(context.Background(), pvzID, userID, now)
 	assert.NoError(t, err)
 	assert.NotNil(t, result)
-	assert.Equal(t, expected.ID, result.ID)
-	assert.Equal(t, expected.Status, result.Status)
+	assert.Equal(t, receptionID, result.ID)
+	assert.Equal(t, "close", result.Status)
+	assert.Equal(t, &userID, result.ClosedBy)
+	if assert.NotNil(t, result.Summary) {
+		assert.Equal(t, 3, result.Summary.ProductCount)
+		assert.Equal(t, 2, result.Summary.ProductsByType["обувь"])
+		assert.Equal(t, &firstScanAt, result.Summary.FirstScanAt)
+	}
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
@@ -81,17 +93,55 @@ func TestCloseLastReception_NotFound(t *testing.T) {
 	repo := repos.NewReceptionRepo(mock)
 
 	pvzID := uuid.New()
+	userID := uuid.New()
 
-	mock.ExpectQuery("WITH last_reception AS").
+	mock.ExpectQuery("SELECT (.+) FROM receptions(.+)FOR UPDATE").
 		WithArgs(pvzID).
 		WillReturnError(pgx.ErrNoRows)
 
-	result, err := repo.CloseLastReception(context.Background(), pvzID)
+	result, err := repo.CloseLastReception(context.Background(), pvzID, userID, time.Now())
 	assert.NoError(t, err)
 	assert.Nil(t, result)
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
+func TestCloseLastReception_ClosedAtFromCaller(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	assert.NoError(t, err)
+	defer mock.Close()
+
+	repo := repos.NewReceptionRepo(mock)
+
+	pvzID := uuid.New()
+	userID := uuid.New()
+	receptionID := uuid.New()
+	// часы сервиса и базы расходятся: приемка создана по часам сервиса в UTC+3,
+	// и время закрытия должно прийти с тех же часов, а не из NOW() базы
+	serviceClock := time.FixedZone("UTC+3", 3*60*60)
+	createdAt := time.Date(2025, 4, 10, 12, 0, 0, 0, serviceClock)
+	closedAt := createdAt.Add(15 * time.Minute)
+
+	mock.ExpectQuery("SELECT (.+) FROM receptions(.+)FOR UPDATE").
+		WithArgs(pvzID).
+		WillReturnRows(pgxmock.NewRows([]string{"id", "pvz_id", "status", "created_at", "closed_at"}).
+			AddRow(receptionID, pvzID, "in_progress", createdAt, (*time.Time)(nil)))
+
+	mock.ExpectQuery(`UPDATE receptions r SET status = 'close',\s+closed_at = \$4,`).
+		WithArgs(receptionID, &userID, false, closedAt, time.Time{}).
+		WillReturnRows(pgxmock.NewRows([]string{
+			"id", "pvz_id", "status", "created_at", "closed_at", "created_by", "closed_by", "auto_closed",
+			"product_count", "products_by_type", "first_scan_at", "last_scan_at",
+		}).AddRow(receptionID, pvzID, "close", createdAt, &closedAt, (*uuid.UUID)(nil), &userID, false,
+			&[]int{0}[0], map[string]int{}, (*time.Time)(nil), (*time.Time)(nil)))
+
+	result, err := repo.CloseLastReception(context.Background(), pvzID, userID, closedAt)
+	assert.NoError(t, err)
+	if assert.NotNil(t, result) && assert.NotNil(t, result.ClosedAt) {
+		assert.Equal(t, 15*time.Minute, result.ClosedAt.Sub(result.DateTime))
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
 func TestCreateReception_Failure(t *testing.T) {
 	mock, err := pgxmock.NewPool()
 	assert.NoError(t, err)
@@ -107,7 +157,7 @@ func TestCreateReception_Failure(t *testing.T) {
 	}
 
 	mock.ExpectExec("INSERT INTO receptions").
-		WithArgs(reception.ID, reception.PVZID, reception.Status, reception.DateTime).
+		WithArgs(reception.ID, reception.PVZID, reception.Status, reception.DateTime, reception.CreatedBy).
 		WillReturnError(errors.New("insert failed"))
 
 	err = repo.CreateReception(context.Background(), reception)
@@ -184,3 +234,229 @@ func TestGetLastOpenReception_QueryError(t *testing.T) {
 	assert.Contains(t, err.Error(), "не удалось получить последнюю открытую приемку")
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
+
+// GetReceptionsByPVZIDs
+func TestGetReceptionsByPVZIDs_Success(t *testing.T) {
+	ctx := context.Background()
+	mock, err := pgxmock.NewPool()
+	assert.NoError(t, err)
+	defer mock.Close()
+
+	repo := repos.NewReceptionRepo(mock)
+
+	pvzIDs := []uuid.UUID{uuid.New(), uuid.New()}
+	start := time.Now().Add(-24 * time.Hour)
+	end := time.Now()
+
+	rows := pgxmock.NewRows([]string{"id", "pvz_id", "status", "created_at", "closed_at"}).
+		AddRow(uuid.New(), pvzIDs[0], "in_progress", end, nil).
+		AddRow(uuid.New(), pvzIDs[1], "close", start, &end)
+
+	mock.ExpectQuery("SELECT id, pvz_id, status, created_at, closed_at FROM receptions WHERE pvz_id = ANY").
+		WithArgs(pvzIDs, &start, &end).
+		WillReturnRows(rows)
+
+	result, err := repo.GetReceptionsByPVZIDs(ctx, pvzIDs, &start, &end)
+
+	assert.NoError(t, err)
+	assert.Len(t, result, 2)
+	assert.Equal(t, pvzIDs[1], result[1].PVZID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetReceptionsByPVZIDs_QueryError(t *testing.T) {
+	ctx := context.Background()
+	mock, err := pgxmock.NewPool()
+	assert.NoError(t, err)
+	defer mock.Close()
+
+	repo := repos.NewReceptionRepo(mock)
+
+	pvzIDs := []uuid.UUID{uuid.New()}
+
+	mock.ExpectQuery("SELECT id, pvz_id, status, created_at, closed_at FROM receptions WHERE pvz_id = ANY").
+		WithArgs(pvzIDs).
+		WillReturnError(errors.New("unexpected db error"))
+
+	result, err := repo.GetReceptionsByPVZIDs(ctx, pvzIDs, nil, nil)
+
+	assert.Error(t, err)
+	assert.Nil(t, result)
+	assert.Contains(t, err.Error(), "не удалось получить приемки")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// GetClosedReceptions
+func TestGetClosedReceptions_Success(t *testing.T) {
+	ctx := context.Background()
+	mock, err := pgxmock.NewPool()
+	assert.NoError(t, err)
+	defer mock.Close()
+
+	repo := repos.NewReceptionRepo(mock)
+
+	pvzID := uuid.New()
+	now := time.Now()
+
+	rows := pgxmock.NewRows([]string{"id", "pvz_id", "status", "created_at", "closed_at", "count"}).
+		AddRow(uuid.New(), pvzID, "close", now.Add(-time.Hour), &now, 12)
+
+	mock.ExpectQuery("LEFT JOIN products").
+		WithArgs(pvzID, 10, 10).
+		WillReturnRows(rows)
+
+	result, err := repo.GetClosedReceptions(ctx, pvzID, 2, 10)
+
+	assert.NoError(t, err)
+	assert.Len(t, result, 1)
+	assert.Equal(t, 12, result[0].ProductCount)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// GetReceptions
+func TestGetReceptions_WithFilter(t *testing.T) {
+	ctx := context.Background()
+	mock, err := pgxmock.NewPool()
+	assert.NoError(t, err)
+	defer mock.Close()
+
+	repo := repos.NewReceptionRepo(mock)
+
+	pvzID := uuid.New()
+	status := "in_progress"
+	createdFrom := time.Now().Add(-time.Hour)
+
+	rows := pgxmock.NewRows([]string{
+		"id", "pvz_id", "status", "created_at", "closed_at", "auto_closed",
+		"product_count", "products_by_type", "first_scan_at", "last_scan_at",
+	}).AddRow(uuid.New(), pvzID, status, time.Now(), nil, false, (*int)(nil), map[string]int(nil), (*time.Time)(nil), (*time.Time)(nil))
+
+	mock.ExpectQuery(`AND pvz_id = \$1 AND status = \$2 AND created_at >= \$3`).
+		WithArgs(pvzID, status, &createdFrom, 10, 0).
+		WillReturnRows(rows)
+
+	result, err := repo.GetReceptions(ctx, models.ReceptionFilter{
+		PVZID:       &pvzID,
+		Status:      &status,
+		CreatedFrom: &createdFrom,
+	}, 1, 10)
+
+	assert.NoError(t, err)
+	assert.Len(t, result, 1)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// GetReceptionByID
+func TestGetReceptionByID_NotFound(t *testing.T) {
+	ctx := context.Background()
+	mock, err := pgxmock.NewPool()
+	assert.NoError(t, err)
+	defer mock.Close()
+
+	repo := repos.NewReceptionRepo(mock)
+	id := uuid.New()
+
+	mock.ExpectQuery("SELECT (.+) FROM receptions WHERE id").
+		WithArgs(id).
+		WillReturnError(pgx.ErrNoRows)
+
+	rec, err := repo.GetReceptionByID(ctx, id)
+
+	assert.NoError(t, err)
+	assert.Nil(t, rec)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCreateReception_OpenReceptionExists(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	assert.NoError(t, err)
+	defer mock.Close()
+
+	repo := repos.NewReceptionRepo(mock)
+
+	reception := &models.Reception{
+		ID:       uuid.New(),
+		PVZID:    uuid.New(),
+		Status:   "in_progress",
+		DateTime: time.Now(),
+	}
+
+	mock.ExpectExec("INSERT INTO receptions").
+		WithArgs(reception.ID, reception.PVZID, reception.Status, reception.DateTime, reception.CreatedBy).
+		WillReturnError(&pgconn.PgError{Code: "23505", ConstraintName: "uniq_receptions_pvz_in_progress"})
+
+	err = repo.CreateReception(context.Background(), reception)
+	assert.ErrorIs(t, err, repos.ErrOpenReceptionExists)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// CloseStaleReceptions
+func TestCloseStaleReceptions_Success(t *testing.T) {
+	mock, _ := pgxmock.NewPool()
+	defer mock.Close()
+
+	repo := repos.NewReceptionRepo(mock)
+
+	receptionID := uuid.New()
+	inactiveSince := time.Now().Add(-12 * time.Hour)
+	closedAt := time.Now()
+
+	mock.ExpectQuery(`SELECT r.id FROM receptions r(.+)FOR UPDATE OF r SKIP LOCKED`).
+		WithArgs(inactiveSince, 100).
+		WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(receptionID))
+
+	// в приемке нет товаров, поэтому итог пустой
+	mock.ExpectQuery("UPDATE receptions r SET status = 'close'").
+		WithArgs(receptionID, (*uuid.UUID)(nil), true, closedAt, inactiveSince).
+		WillReturnRows(pgxmock.NewRows([]string{
+			"id", "pvz_id", "status", "created_at", "closed_at", "created_by", "closed_by", "auto_closed",
+			"product_count", "products_by_type", "first_scan_at", "last_scan_at",
+		}).AddRow(receptionID, uuid.New(), "close", inactiveSince.Add(-time.Hour), &closedAt, (*uuid.UUID)(nil), (*uuid.UUID)(nil), true,
+			&[]int{0}[0], map[string]int{}, (*time.Time)(nil), (*time.Time)(nil)))
+
+	receptions, err := repo.CloseStaleReceptions(context.Background(), inactiveSince, closedAt, 100)
+	assert.NoError(t, err)
+	if assert.Len(t, receptions, 1) {
+		assert.True(t, receptions[0].AutoClosed)
+		assert.Equal(t, "close", receptions[0].Status)
+		assert.Equal(t, 0, receptions[0].Summary.ProductCount)
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCloseStaleReceptions_ActivityAfterSelect(t *testing.T) {
+	mock, _ := pgxmock.NewPool()
+	defer mock.Close()
+
+	repo := repos.NewReceptionRepo(mock)
+
+	staleID := uuid.New()
+	activeID := uuid.New()
+	inactiveSince := time.Now().Add(-12 * time.Hour)
+	closedAt := time.Now()
+
+	mock.ExpectQuery(`SELECT r.id FROM receptions r(.+)FOR UPDATE OF r SKIP LOCKED`).
+		WithArgs(inactiveSince, 100).
+		WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(staleID).AddRow(activeID))
+
+	closeColumns := []string{
+		"id", "pvz_id", "status", "created_at", "closed_at", "created_by", "closed_by", "auto_closed",
+		"product_count", "products_by_type", "first_scan_at", "last_scan_at",
+	}
+	mock.ExpectQuery(`UPDATE receptions r(.+)WHERE r.id = \$1\s+AND \(NOT \$3 OR COALESCE\(s.last_scan_at, r.created_at\) < \$5\)`).
+		WithArgs(staleID, (*uuid.UUID)(nil), true, closedAt, inactiveSince).
+		WillReturnRows(pgxmock.NewRows(closeColumns).
+			AddRow(staleID, uuid.New(), "close", inactiveSince.Add(-time.Hour), &closedAt, (*uuid.UUID)(nil), (*uuid.UUID)(nil), true,
+				&[]int{0}[0], map[string]int{}, (*time.Time)(nil), (*time.Time)(nil)))
+	// товар добавили после выборки, но до блокировки: перепроверка не дает закрыть приемку
+	mock.ExpectQuery("UPDATE receptions r SET status = 'close'").
+		WithArgs(activeID, (*uuid.UUID)(nil), true, closedAt, inactiveSince).
+		WillReturnRows(pgxmock.NewRows(closeColumns))
+
+	receptions, err := repo.CloseStaleReceptions(context.Background(), inactiveSince, closedAt, 100)
+	assert.NoError(t, err)
+	if assert.Len(t, receptions, 1) {
+		assert.Equal(t, staleID, receptions[0].ID)
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,107 @@
+package repos
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/forzeyy/avito-internship-spring-service/internal/models"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+type RefreshTokenRepo interface {
+	CreateRefreshToken(ctx context.Context, token *models.RefreshToken) error
+	LockRefreshToken(ctx context.Context, tokenHash string) (*models.RefreshToken, error)
+	RevokeRefreshToken(ctx context.Context, id uuid.UUID) error
+	RevokeRefreshTokenFamily(ctx context.Context, familyID uuid.UUID) error
+	RevokeUserRefreshTokens(ctx context.Context, userID uuid.UUID) error
+}
+
+type refreshTokenRepo struct {
+	db DB
+}
+
+func NewRefreshTokenRepo(db DB) RefreshTokenRepo {
+	return &refreshTokenRepo{db: db}
+}
+
+func (rr *refreshTokenRepo) CreateRefreshToken(ctx context.Context, token *models.RefreshToken) error {
+	query := `
+		INSERT INTO refresh_tokens (id, user_id, family_id, token_hash, expires_at, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`
+	_, err := getDB(ctx, rr.db).Exec(ctx, query, token.ID, token.UserID, token.FamilyID, token.TokenHash, token.ExpiresAt, token.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("не удалось сохранить refresh токен: %v", err)
+	}
+	return nil
+}
+
+// LockRefreshToken блокирует токен до конца транзакции, чтобы два параллельных обновления не получили новую пару по одному токену.
+// Вызывается внутри TxManager.WithTx.
+func (rr *refreshTokenRepo) LockRefreshToken(ctx context.Context, tokenHash string) (*models.RefreshToken, error) {
+	var token models.RefreshToken
+
+	query := `
+		SELECT id, user_id, family_id, token_hash, expires_at, created_at, revoked_at
+		FROM refresh_tokens
+		WHERE token_hash = $1
+		FOR UPDATE
+	`
+	err := getDB(ctx, rr.db).QueryRow(ctx, query, tokenHash).Scan(
+		&token.ID,
+		&token.UserID,
+		&token.FamilyID,
+		&token.TokenHash,
+		&token.ExpiresAt,
+		&token.CreatedAt,
+		&token.RevokedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("не удалось получить refresh токен: %v", err)
+	}
+	return &token, nil
+}
+
+func (rr *refreshTokenRepo) RevokeRefreshToken(ctx context.Context, id uuid.UUID) error {
+	query := `
+		UPDATE refresh_tokens
+		SET revoked_at = NOW()
+		WHERE id = $1 AND revoked_at IS NULL
+	`
+	_, err := getDB(ctx, rr.db).Exec(ctx, query, id)
+	if err != nil {
+		return fmt.Errorf("не удалось отозвать refresh токен: %v", err)
+	}
+	return nil
+}
+
+func (rr *refreshTokenRepo) RevokeRefreshTokenFamily(ctx context.Context, familyID uuid.UUID) error {
+	query := `
+		UPDATE refresh_tokens
+		SET revoked_at = NOW()
+		WHERE family_id = $1 AND revoked_at IS NULL
+	`
+	_, err := getDB(ctx, rr.db).Exec(ctx, query, familyID)
+	if err != nil {
+		return fmt.Errorf("не удалось отозвать сессию: %v", err)
+	}
+	return nil
+}
+
+func (rr *refreshTokenRepo) RevokeUserRefreshTokens(ctx context.Context, userID uuid.UUID) error {
+	query := `
+		UPDATE refresh_tokens
+		SET revoked_at = NOW()
+		WHERE user_id = $1 AND revoked_at IS NULL
+	`
+	_, err := getDB(ctx, rr.db).Exec(ctx, query, userID)
+	if err != nil {
+		return fmt.Errorf("не удалось отозвать сессии пользователя: %v", err)
+	}
+	return nil
+}
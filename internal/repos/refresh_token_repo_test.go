@@ -0,0 +1,97 @@
+package repos_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/forzeyy/avito-internship-spring-service/internal/models"
+	"github.com/forzeyy/avito-internship-spring-service/internal/repos"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+// CreateRefreshToken
+func TestCreateRefreshToken_Success(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	assert.NoError(t, err)
+	defer mock.Close()
+
+	repo := repos.NewRefreshTokenRepo(mock)
+
+	token := &models.RefreshToken{
+		ID:        uuid.New(),
+		UserID:    uuid.New(),
+		FamilyID:  uuid.New(),
+		TokenHash: "hash",
+		ExpiresAt: time.Now().Add(time.Hour),
+		CreatedAt: time.Now(),
+	}
+
+	mock.ExpectExec("INSERT INTO refresh_tokens").
+		WithArgs(token.ID, token.UserID, token.FamilyID, token.TokenHash, token.ExpiresAt, token.CreatedAt).
+		WillReturnResult(pgxmock.NewResult("INSERT", 1))
+
+	err = repo.CreateRefreshToken(context.Background(), token)
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// LockRefreshToken
+func TestLockRefreshToken_Success(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	assert.NoError(t, err)
+	defer mock.Close()
+
+	repo := repos.NewRefreshTokenRepo(mock)
+
+	id, userID, familyID := uuid.New(), uuid.New(), uuid.New()
+	expiresAt := time.Now().Add(time.Hour)
+	rows := pgxmock.NewRows([]string{"id", "user_id", "family_id", "token_hash", "expires_at", "created_at", "revoked_at"}).
+		AddRow(id, userID, familyID, "hash", expiresAt, time.Now(), (*time.Time)(nil))
+
+	mock.ExpectQuery("SELECT (.+) FROM refresh_tokens WHERE token_hash = \\$1 FOR UPDATE").
+		WithArgs("hash").
+		WillReturnRows(rows)
+
+	token, err := repo.LockRefreshToken(context.Background(), "hash")
+	assert.NoError(t, err)
+	assert.Equal(t, familyID, token.FamilyID)
+	assert.Nil(t, token.RevokedAt)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestLockRefreshToken_NotFound(t *testing.T) {
+	mock, _ := pgxmock.NewPool()
+	defer mock.Close()
+
+	repo := repos.NewRefreshTokenRepo(mock)
+
+	mock.ExpectQuery("SELECT (.+) FROM refresh_tokens").
+		WithArgs("missing").
+		WillReturnError(pgx.ErrNoRows)
+
+	token, err := repo.LockRefreshToken(context.Background(), "missing")
+	assert.NoError(t, err)
+	assert.Nil(t, token)
+}
+
+// RevokeRefreshTokenFamily
+func TestRevokeRefreshTokenFamily(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	assert.NoError(t, err)
+	defer mock.Close()
+
+	repo := repos.NewRefreshTokenRepo(mock)
+
+	familyID := uuid.New()
+	mock.ExpectExec("UPDATE refresh_tokens SET revoked_at = NOW\\(\\) WHERE family_id = \\$1 AND revoked_at IS NULL").
+		WithArgs(familyID).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 3))
+
+	err = repo.RevokeRefreshTokenFamily(context.Background(), familyID)
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
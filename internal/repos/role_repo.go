@@ -0,0 +1,120 @@
+package repos
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/forzeyy/avito-internship-spring-service/internal/models"
+	"github.com/jackc/pgx/v5"
+)
+
+type RoleRepo interface {
+	GetRoles(ctx context.Context) ([]models.Role, error)
+	GetRole(ctx context.Context, name string) (*models.Role, error)
+	GetPermissions(ctx context.Context) ([]models.Permission, error)
+	SetRolePermissions(ctx context.Context, role string, permissions []string) error
+}
+
+type roleRepo struct {
+	db DB
+}
+
+func NewRoleRepo(db DB) RoleRepo {
+	return &roleRepo{db: db}
+}
+
+func (rr *roleRepo) GetRoles(ctx context.Context) ([]models.Role, error) {
+	query := `
+		SELECT r.name, r.description, COALESCE(array_agg(rp.permission ORDER BY rp.permission) FILTER (WHERE rp.permission IS NOT NULL), '{}')
+		FROM roles r
+		LEFT JOIN role_permissions rp ON rp.role = r.name
+		GROUP BY r.name, r.description
+		ORDER BY r.name
+	`
+	rows, err := getDB(ctx, rr.db).Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("не удалось получить роли: %w", err)
+	}
+	defer rows.Close()
+
+	var roles []models.Role
+	for rows.Next() {
+		var role models.Role
+		if err := rows.Scan(&role.Name, &role.Description, &role.Permissions); err != nil {
+			return nil, fmt.Errorf("ошибка при сканировании строки: %w", err)
+		}
+		roles = append(roles, role)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("ошибка чтения строк: %v", err)
+	}
+	return roles, nil
+}
+
+func (rr *roleRepo) GetRole(ctx context.Context, name string) (*models.Role, error) {
+	var role models.Role
+
+	query := `
+		SELECT r.name, r.description, COALESCE(array_agg(rp.permission ORDER BY rp.permission) FILTER (WHERE rp.permission IS NOT NULL), '{}')
+		FROM roles r
+		LEFT JOIN role_permissions rp ON rp.role = r.name
+		WHERE r.name = $1
+		GROUP BY r.name, r.description
+	`
+	err := getDB(ctx, rr.db).QueryRow(ctx, query, name).Scan(&role.Name, &role.Description, &role.Permissions)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("не удалось получить роль: %v", err)
+	}
+	return &role, nil
+}
+
+func (rr *roleRepo) GetPermissions(ctx context.Context) ([]models.Permission, error) {
+	query := `
+		SELECT name, description
+		FROM permissions
+		ORDER BY name
+	`
+	rows, err := getDB(ctx, rr.db).Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("не удалось получить права: %w", err)
+	}
+	defer rows.Close()
+
+	var permissions []models.Permission
+	for rows.Next() {
+		var permission models.Permission
+		if err := rows.Scan(&permission.Name, &permission.Description); err != nil {
+			return nil, fmt.Errorf("ошибка при сканировании строки: %w", err)
+		}
+		permissions = append(permissions, permission)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("ошибка чтения строк: %v", err)
+	}
+	return permissions, nil
+}
+
+// SetRolePermissions заменяет весь набор прав роли. Вызывается внутри TxManager.WithTx,
+// чтобы между удалением и вставкой роль не осталась без прав
+func (rr *roleRepo) SetRolePermissions(ctx context.Context, role string, permissions []string) error {
+	db := getDB(ctx, rr.db)
+
+	if _, err := db.Exec(ctx, `DELETE FROM role_permissions WHERE role = $1`, role); err != nil {
+		return fmt.Errorf("не удалось удалить права роли: %v", err)
+	}
+
+	query := `
+		INSERT INTO role_permissions (role, permission)
+		SELECT $1, unnest($2::varchar[])
+	`
+	if _, err := db.Exec(ctx, query, role, permissions); err != nil {
+		return fmt.Errorf("не удалось сохранить права роли: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+package repos_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/forzeyy/avito-internship-spring-service/internal/models"
+	"github.com/forzeyy/avito-internship-spring-service/internal/repos"
+	"github.com/jackc/pgx/v5"
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+// GetRoles
+func TestGetRoles_Success(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	assert.NoError(t, err)
+	defer mock.Close()
+
+	repo := repos.NewRoleRepo(mock)
+
+	rows := pgxmock.NewRows([]string{"name", "description", "permissions"}).
+		AddRow("auditor", "Аудитор", []string{models.PermissionAuditRead, models.PermissionPVZRead}).
+		AddRow("employee", "Сотрудник ПВЗ", []string{})
+
+	mock.ExpectQuery("FROM roles r LEFT JOIN role_permissions").WillReturnRows(rows)
+
+	roles, err := repo.GetRoles(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, []models.Role{
+		{Name: "auditor", Description: "Аудитор", Permissions: []string{models.PermissionAuditRead, models.PermissionPVZRead}},
+		{Name: "employee", Description: "Сотрудник ПВЗ", Permissions: []string{}},
+	}, roles)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// GetRole
+func TestGetRole_NotFound(t *testing.T) {
+	mock, _ := pgxmock.NewPool()
+	defer mock.Close()
+
+	repo := repos.NewRoleRepo(mock)
+
+	mock.ExpectQuery("FROM roles r").
+		WithArgs("ghost").
+		WillReturnError(pgx.ErrNoRows)
+
+	role, err := repo.GetRole(context.Background(), "ghost")
+	assert.NoError(t, err)
+	assert.Nil(t, role)
+}
+
+// SetRolePermissions
+func TestSetRolePermissions_ReplacesSet(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	assert.NoError(t, err)
+	defer mock.Close()
+
+	repo := repos.NewRoleRepo(mock)
+
+	permissions := []string{models.PermissionPVZRead, models.PermissionReceptionRead}
+	mock.ExpectExec("DELETE FROM role_permissions WHERE role = \\$1").
+		WithArgs("auditor").
+		WillReturnResult(pgxmock.NewResult("DELETE", 3))
+	mock.ExpectExec("INSERT INTO role_permissions").
+		WithArgs("auditor", permissions).
+		WillReturnResult(pgxmock.NewResult("INSERT", 2))
+
+	err = repo.SetRolePermissions(context.Background(), "auditor", permissions)
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,65 @@
+package repos
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// TxManager выполняет несколько вызовов репозиториев в одной транзакции.
+// Репозитории берут транзакцию из контекста, переданного в fn.
+type TxManager interface {
+	WithTx(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+type TxBeginner interface {
+	BeginTx(ctx context.Context, txOptions pgx.TxOptions) (pgx.Tx, error)
+}
+
+type txKey struct{}
+
+type txManager struct {
+	db TxBeginner
+}
+
+func NewTxManager(db TxBeginner) TxManager {
+	return &txManager{db: db}
+}
+
+func (tm *txManager) WithTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	// вложенный вызов присоединяется к уже открытой транзакции
+	if _, ok := ctx.Value(txKey{}).(pgx.Tx); ok {
+		return fn(ctx)
+	}
+
+	tx, err := tm.db.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return fmt.Errorf("не удалось начать транзакцию: %w", err)
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			_ = tx.Rollback(ctx)
+			panic(p)
+		}
+	}()
+
+	if err := fn(context.WithValue(ctx, txKey{}, tx)); err != nil {
+		_ = tx.Rollback(ctx)
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("не удалось зафиксировать транзакцию: %w", err)
+	}
+	return nil
+}
+
+// getDB возвращает транзакцию из контекста, если она есть, иначе переданное подключение
+func getDB(ctx context.Context, db DB) DB {
+	if tx, ok := ctx.Value(txKey{}).(pgx.Tx); ok {
+		return tx
+	}
+	return db
+}
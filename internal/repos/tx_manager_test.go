@@ -0,0 +1,108 @@
+package repos_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/forzeyy/avito-internship-spring-service/internal/models"
+	"github.com/forzeyy/avito-internship-spring-service/internal/repos"
+	"github.com/google/uuid"
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithTx_Commit(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	assert.NoError(t, err)
+	defer mock.Close()
+
+	txManager := repos.NewTxManager(mock)
+	recRepo := repos.NewReceptionRepo(mock)
+	prodRepo := repos.NewProductRepo(mock)
+
+	pvzID := uuid.New()
+	receptionID := uuid.New()
+	product := &models.Product{ID: uuid.New(), Type: "обувь", ReceptionID: receptionID, DateTime: time.Now()}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("FOR UPDATE").
+		WithArgs(pvzID).
+		WillReturnRows(pgxmock.NewRows([]string{"id", "pvz_id", "status", "created_at", "closed_at"}).
+			AddRow(receptionID, pvzID, "in_progress", time.Now(), nil))
+	mock.ExpectExec("INSERT INTO products").
+		WithArgs(product.ID, product.Type, product.ReceptionID, product.DateTime, product.CreatedBy, product.Barcode).
+		WillReturnResult(pgxmock.NewResult("INSERT", 1))
+	mock.ExpectCommit()
+
+	err = txManager.WithTx(context.Background(), func(ctx context.Context) error {
+		reception, err := recRepo.LockLastOpenReception(ctx, pvzID)
+		if err != nil {
+			return err
+		}
+		assert.Equal(t, receptionID, reception.ID)
+		return prodRepo.AddProduct(ctx, product)
+	})
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestWithTx_RollbackOnError(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	assert.NoError(t, err)
+	defer mock.Close()
+
+	txManager := repos.NewTxManager(mock)
+
+	mock.ExpectBegin()
+	mock.ExpectRollback()
+
+	err = txManager.WithTx(context.Background(), func(ctx context.Context) error {
+		return errors.New("fn failed")
+	})
+
+	assert.EqualError(t, err, "fn failed")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestWithTx_Nested(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	assert.NoError(t, err)
+	defer mock.Close()
+
+	txManager := repos.NewTxManager(mock)
+
+	mock.ExpectBegin()
+	mock.ExpectCommit()
+
+	err = txManager.WithTx(context.Background(), func(ctx context.Context) error {
+		return txManager.WithTx(ctx, func(ctx context.Context) error {
+			return nil
+		})
+	})
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestWithTx_BeginError(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	assert.NoError(t, err)
+	defer mock.Close()
+
+	txManager := repos.NewTxManager(mock)
+
+	mock.ExpectBegin().WillReturnError(errors.New("begin failed"))
+
+	called := false
+	err = txManager.WithTx(context.Background(), func(ctx context.Context) error {
+		called = true
+		return nil
+	})
+
+	assert.Error(t, err)
+	assert.False(t, called)
+	assert.Contains(t, err.Error(), "не удалось начать транзакцию")
+}
@@ -2,16 +2,26 @@ package repos
 
 import (
 	"context"
-	"errors"
 	"fmt"
+	"time"
 
+	"github.com/forzeyy/avito-internship-spring-service/internal/apperrors"
 	"github.com/forzeyy/avito-internship-spring-service/internal/models"
+	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
 )
 
+var ErrUserNotFound = apperrors.NotFound("пользователь не найден")
+
 type UserRepo interface {
 	CreateUser(ctx context.Context, user *models.User) error
 	GetUserByEmail(ctx context.Context, email string) (*models.User, error)
+	GetUserByID(ctx context.Context, id uuid.UUID) (*models.User, error)
+	GetUsers(ctx context.Context, filter models.UserFilter, page, limit int) ([]models.User, error)
+	UpdateUserRole(ctx context.Context, id uuid.UUID, role string) error
+	SetUserDeactivated(ctx context.Context, id uuid.UUID, deactivatedAt *time.Time) error
+	SetPasswordResetRequired(ctx context.Context, id uuid.UUID, required bool) error
+	UpdatePassword(ctx context.Context, id uuid.UUID, passwordHash string) error
 }
 
 type userRepo struct {
@@ -24,14 +34,14 @@ func NewUserRepo(db DB) UserRepo {
 
 func (ur *userRepo) CreateUser(ctx context.Context, user *models.User) error {
 	if user.PasswordHash == "" {
-		return errors.New("пароль пользователя не может быть пустым")
+		return apperrors.Validation("пароль пользователя не может быть пустым")
 	}
 
 	query := `
-		INSERT INTO users (email, password_hash, role, created_at)
-		VALUES ($1, $2, $3, $4)
+		INSERT INTO users (id, email, password_hash, role, created_at)
+		VALUES ($1, $2, $3, $4, $5)
 	`
-	_, err := ur.db.Exec(ctx, query, user.Email, user.PasswordHash, user.Role, user.CreatedAt)
+	_, err := getDB(ctx, ur.db).Exec(ctx, query, user.ID, user.Email, user.PasswordHash, user.Role, user.CreatedAt)
 	if err != nil {
 		return fmt.Errorf("не удалось создать пользователя: %v", err)
 	}
@@ -42,18 +52,165 @@ func (ur *userRepo) GetUserByEmail(ctx context.Context, email string) (*models.U
 	var user models.User
 
 	query := `
-        SELECT id, email, password_hash, role, created_at
+        SELECT id, email, password_hash, role, created_at, deactivated_at, password_reset_required
         FROM users
         WHERE email = $1
     `
-	row := ur.db.QueryRow(ctx, query, email)
+	row := getDB(ctx, ur.db).QueryRow(ctx, query, email)
+
+	err := row.Scan(&user.ID, &user.Email, &user.PasswordHash, &user.Role, &user.CreatedAt, &user.DeactivatedAt, &user.PasswordResetRequired)
+	if err == pgx.ErrNoRows {
+		return nil, ErrUserNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("не удалось получить пользователя: %v", err)
+	}
+	return &user, nil
+}
+
+func (ur *userRepo) GetUserByID(ctx context.Context, id uuid.UUID) (*models.User, error) {
+	var user models.User
+
+	query := `
+        SELECT id, email, password_hash, role, created_at, deactivated_at, password_reset_required
+        FROM users
+        WHERE id = $1
+    `
+	row := getDB(ctx, ur.db).QueryRow(ctx, query, id)
 
-	err := row.Scan(&user.ID, &user.Email, &user.PasswordHash, &user.Role, &user.CreatedAt)
+	err := row.Scan(&user.ID, &user.Email, &user.PasswordHash, &user.Role, &user.CreatedAt, &user.DeactivatedAt, &user.PasswordResetRequired)
 	if err == pgx.ErrNoRows {
-		return nil, errors.New("пользователь не найден")
+		return nil, ErrUserNotFound
 	}
 	if err != nil {
 		return nil, fmt.Errorf("не удалось получить пользователя: %v", err)
 	}
 	return &user, nil
 }
+
+func (ur *userRepo) GetUsers(ctx context.Context, filter models.UserFilter, page, limit int) ([]models.User, error) {
+	var (
+		query = `
+			SELECT id, email, password_hash, role, created_at, deactivated_at, password_reset_required
+			FROM users
+			WHERE TRUE
+		`
+		args     []any
+		argIndex = 1
+	)
+
+	if filter.Email != nil {
+		query += fmt.Sprintf(" AND email ILIKE '%%' || $%d || '%%'", argIndex)
+		args = append(args, *filter.Email)
+		argIndex++
+	}
+	if filter.Role != nil {
+		query += fmt.Sprintf(" AND role = $%d", argIndex)
+		args = append(args, *filter.Role)
+		argIndex++
+	}
+	if filter.Active != nil {
+		if *filter.Active {
+			query += " AND deactivated_at IS NULL"
+		} else {
+			query += " AND deactivated_at IS NOT NULL"
+		}
+	}
+
+	query += fmt.Sprintf(`
+		ORDER BY email
+		LIMIT $%d OFFSET $%d
+	`, argIndex, argIndex+1)
+	args = append(args, limit, (page-1)*limit)
+
+	rows, err := getDB(ctx, ur.db).Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("не удалось получить пользователей: %w", err)
+	}
+	defer rows.Close()
+
+	var users []models.User
+	for rows.Next() {
+		var user models.User
+		err := rows.Scan(&user.ID, &user.Email, &user.PasswordHash, &user.Role, &user.CreatedAt, &user.DeactivatedAt, &user.PasswordResetRequired)
+		if err != nil {
+			return nil, fmt.Errorf("ошибка при сканировании строки: %w", err)
+		}
+		users = append(users, user)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("ошибка чтения строк: %v", err)
+	}
+	return users, nil
+}
+
+func (ur *userRepo) UpdateUserRole(ctx context.Context, id uuid.UUID, role string) error {
+	query := `
+		UPDATE users
+		SET role = $2
+		WHERE id = $1
+	`
+	result, err := getDB(ctx, ur.db).Exec(ctx, query, id, role)
+	if err != nil {
+		return fmt.Errorf("не удалось изменить роль пользователя: %v", err)
+	}
+	if result.RowsAffected() == 0 {
+		return ErrUserNotFound
+	}
+	return nil
+}
+
+// SetUserDeactivated отключает учетную запись, если deactivatedAt задан, и включает ее снова при nil
+func (ur *userRepo) SetUserDeactivated(ctx context.Context, id uuid.UUID, deactivatedAt *time.Time) error {
+	query := `
+		UPDATE users
+		SET deactivated_at = $2
+		WHERE id = $1
+	`
+	result, err := getDB(ctx, ur.db).Exec(ctx, query, id, deactivatedAt)
+	if err != nil {
+		return fmt.Errorf("не удалось изменить статус пользователя: %v", err)
+	}
+	if result.RowsAffected() == 0 {
+		return ErrUserNotFound
+	}
+	return nil
+}
+
+func (ur *userRepo) SetPasswordResetRequired(ctx context.Context, id uuid.UUID, required bool) error {
+	query := `
+		UPDATE users
+		SET password_reset_required = $2
+		WHERE id = $1
+	`
+	result, err := getDB(ctx, ur.db).Exec(ctx, query, id, required)
+	if err != nil {
+		return fmt.Errorf("не удалось изменить требование сброса пароля: %v", err)
+	}
+	if result.RowsAffected() == 0 {
+		return ErrUserNotFound
+	}
+	return nil
+}
+
+// UpdatePassword заменяет хеш пароля и снимает требование сброса, выставленное администратором
+func (ur *userRepo) UpdatePassword(ctx context.Context, id uuid.UUID, passwordHash string) error {
+	if passwordHash == "" {
+		return apperrors.Validation("пароль пользователя не может быть пустым")
+	}
+
+	query := `
+		UPDATE users
+		SET password_hash = $2, password_reset_required = FALSE
+		WHERE id = $1
+	`
+	result, err := getDB(ctx, ur.db).Exec(ctx, query, id, passwordHash)
+	if err != nil {
+		return fmt.Errorf("не удалось изменить пароль: %v", err)
+	}
+	if result.RowsAffected() == 0 {
+		return ErrUserNotFound
+	}
+	return nil
+}
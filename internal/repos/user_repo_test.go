@@ -30,7 +30,7 @@ func TestCreateUser_Success(t *testing.T) {
 	}
 
 	mock.ExpectExec("INSERT INTO users").
-		WithArgs(user.Email, user.PasswordHash, user.Role, user.CreatedAt).
+		WithArgs(user.ID, user.Email, user.PasswordHash, user.Role, user.CreatedAt).
 		WillReturnResult(pgxmock.NewResult("INSERT", 1))
 
 	err = repo.CreateUser(context.Background(), user)
@@ -71,10 +71,10 @@ func TestGetUserByEmail_Success(t *testing.T) {
 		CreatedAt:    time.Now(),
 	}
 
-	rows := pgxmock.NewRows([]string{"id", "email", "password_hash", "role", "created_at"}).
-		AddRow(user.ID, user.Email, user.PasswordHash, user.Role, user.CreatedAt)
+	rows := pgxmock.NewRows([]string{"id", "email", "password_hash", "role", "created_at", "deactivated_at", "password_reset_required"}).
+		AddRow(user.ID, user.Email, user.PasswordHash, user.Role, user.CreatedAt, user.DeactivatedAt, user.PasswordResetRequired)
 
-	mock.ExpectQuery("SELECT id, email, password_hash, role, created_at FROM users").
+	mock.ExpectQuery("SELECT id, email, password_hash, role, created_at, deactivated_at, password_reset_required FROM users").
 		WithArgs(user.Email).
 		WillReturnRows(rows)
 
@@ -90,7 +90,7 @@ func TestGetUserByEmail_NotFound(t *testing.T) {
 
 	repo := repos.NewUserRepo(mock)
 
-	mock.ExpectQuery("SELECT id, email, password_hash, role, created_at FROM users").
+	mock.ExpectQuery("SELECT id, email, password_hash, role, created_at, deactivated_at, password_reset_required FROM users").
 		WithArgs("404@mail.ru").
 		WillReturnError(pgx.ErrNoRows)
 
@@ -98,3 +98,79 @@ func TestGetUserByEmail_NotFound(t *testing.T) {
 	assert.Nil(t, result)
 	assert.EqualError(t, err, "пользователь не найден")
 }
+
+// GetUserByID
+func TestGetUserByID_NotFound(t *testing.T) {
+	mock, _ := pgxmock.NewPool()
+	defer mock.Close()
+
+	repo := repos.NewUserRepo(mock)
+
+	id := uuid.New()
+	mock.ExpectQuery("SELECT id, email, password_hash, role, created_at, deactivated_at, password_reset_required FROM users").
+		WithArgs(id).
+		WillReturnError(pgx.ErrNoRows)
+
+	result, err := repo.GetUserByID(context.Background(), id)
+	assert.Nil(t, result)
+	assert.ErrorIs(t, err, repos.ErrUserNotFound)
+}
+
+// GetUsers
+func TestGetUsers_Filter(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	assert.NoError(t, err)
+	defer mock.Close()
+
+	repo := repos.NewUserRepo(mock)
+
+	deactivatedAt := time.Now()
+	rows := pgxmock.NewRows([]string{"id", "email", "password_hash", "role", "created_at", "deactivated_at", "password_reset_required"}).
+		AddRow(uuid.New(), "ivan@mail.ru", "hash", "moderator", time.Now(), &deactivatedAt, false)
+
+	email, role, active := "ivan", "moderator", false
+	mock.ExpectQuery(`AND email ILIKE '%' \|\| \$1 \|\| '%' AND role = \$2 AND deactivated_at IS NOT NULL`).
+		WithArgs(email, role, 10, 10).
+		WillReturnRows(rows)
+
+	users, err := repo.GetUsers(context.Background(), models.UserFilter{Email: &email, Role: &role, Active: &active}, 2, 10)
+	assert.NoError(t, err)
+	if assert.Len(t, users, 1) {
+		assert.Equal(t, "ivan@mail.ru", users[0].Email)
+		assert.NotNil(t, users[0].DeactivatedAt)
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// SetUserDeactivated
+func TestSetUserDeactivated_NotFound(t *testing.T) {
+	mock, _ := pgxmock.NewPool()
+	defer mock.Close()
+
+	repo := repos.NewUserRepo(mock)
+
+	id := uuid.New()
+	mock.ExpectExec("UPDATE users SET deactivated_at").
+		WithArgs(id, (*time.Time)(nil)).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 0))
+
+	err := repo.SetUserDeactivated(context.Background(), id, nil)
+	assert.ErrorIs(t, err, repos.ErrUserNotFound)
+}
+
+// UpdatePassword
+func TestUpdatePassword_Success(t *testing.T) {
+	mock, _ := pgxmock.NewPool()
+	defer mock.Close()
+
+	repo := repos.NewUserRepo(mock)
+
+	id := uuid.New()
+	mock.ExpectExec("UPDATE users SET password_hash = \\$2, password_reset_required = FALSE WHERE id = \\$1").
+		WithArgs(id, "new_hash").
+		WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+
+	err := repo.UpdatePassword(context.Background(), id, "new_hash")
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -1,55 +1,256 @@
 package routes
 
 import (
+	"context"
+	"fmt"
+	"os"
+
 	"github.com/forzeyy/avito-internship-spring-service/internal/config"
 	"github.com/forzeyy/avito-internship-spring-service/internal/database"
+	"github.com/forzeyy/avito-internship-spring-service/internal/dto"
+	"github.com/forzeyy/avito-internship-spring-service/internal/grpcserver"
 	"github.com/forzeyy/avito-internship-spring-service/internal/handlers"
 	"github.com/forzeyy/avito-internship-spring-service/internal/middleware"
+	"github.com/forzeyy/avito-internship-spring-service/internal/models"
+	"github.com/forzeyy/avito-internship-spring-service/internal/notifier"
+	"github.com/forzeyy/avito-internship-spring-service/internal/pb/pvz_v1"
 	"github.com/forzeyy/avito-internship-spring-service/internal/repos"
 	"github.com/forzeyy/avito-internship-spring-service/internal/services"
 	"github.com/forzeyy/avito-internship-spring-service/internal/utils"
+	"github.com/forzeyy/avito-internship-spring-service/internal/worker"
 	"github.com/labstack/echo/v4"
+	"google.golang.org/grpc"
 )
 
-func InitRoutes(e *echo.Echo, db *database.DB, cfg *config.Config) {
+func InitRoutes(e *echo.Echo, db *database.DB, cfg *config.Config) error {
+	spec, err := dto.GetSwagger()
+	if err != nil {
+		return fmt.Errorf("не удалось загрузить спецификацию API: %v", err)
+	}
+	validator, err := middleware.OpenAPIValidator(spec)
+	if err != nil {
+		return err
+	}
+
+	keys, err := loadSigningKeys(cfg)
+	if err != nil {
+		return err
+	}
+	mailer, err := newNotifier(cfg)
+	if err != nil {
+		return err
+	}
+
+	txManager := repos.NewTxManager(db)
+	auditRepo := repos.NewAuditRepo(db)
+
 	// auth
 	userRepo := repos.NewUserRepo(db)
-	userSvc := services.NewUserService(userRepo, cfg.JWTSecret, utils.DefaultAuthUtil{})
+	refreshTokenRepo := repos.NewRefreshTokenRepo(db)
+	loginThrottleRepo := repos.NewLoginThrottleRepo(db)
+	passwordResetRepo := repos.NewPasswordResetRepo(db)
+	userSvc := services.NewUserService(userRepo, refreshTokenRepo, loginThrottleRepo, passwordResetRepo, txManager, auditRepo, utils.DefaultAuthUtil{Keys: keys}, mailer, cfg.IsDevelopment())
 	authHandler := handlers.NewAuthHandler(userSvc)
-
-	// pvz
-	pvzRepo := repos.NewPVZRepo(db)
-	pvzSvc := services.NewPVZService(pvzRepo)
-	pvzHandler := handlers.NewPVZHandler(pvzSvc)
+	jwksHandler := handlers.NewJWKSHandler(keys)
 
 	// reception
 	receptionRepo := repos.NewReceptionRepo(db)
-	receptionSvc := services.NewReceptionService(receptionRepo)
+	productRepo := repos.NewProductRepo(db)
+	assignmentRepo := repos.NewPVZAssignmentRepo(db)
+	receptionSvc := services.NewReceptionService(receptionRepo, productRepo, assignmentRepo, txManager, auditRepo, cfg.IsDevelopment())
 	receptionHandler := handlers.NewReceptionHandler(receptionSvc)
 
 	// product
-	productRepo := repos.NewProductRepo(db)
-	productSvc := services.NewProductService(productRepo, receptionRepo)
+	productSvc := services.NewProductService(productRepo, receptionRepo, assignmentRepo, txManager, auditRepo, barcodeScope(cfg), cfg.IsDevelopment())
 	productHandler := handlers.NewProductHandler(productSvc)
 
+	// pvz
+	pvzRepo := repos.NewPVZRepo(db)
+	pvzSvc := services.NewPVZService(pvzRepo, receptionRepo, productRepo, txManager, auditRepo)
+	pvzHandler := handlers.NewPVZHandler(pvzSvc)
+	assignmentSvc := services.NewPVZAssignmentService(assignmentRepo, pvzRepo, userRepo, txManager, auditRepo)
+	assignmentHandler := handlers.NewPVZAssignmentHandler(assignmentSvc)
+
+	// audit
+	auditSvc := services.NewAuditService(auditRepo)
+	auditHandler := handlers.NewAuditHandler(auditSvc)
+
+	// rbac
+	roleRepo := repos.NewRoleRepo(db)
+	rbacSvc := services.NewRBACService(roleRepo, txManager, auditRepo)
+	rbacHandler := handlers.NewRBACHandler(rbacSvc)
+
+	// user management
+	userAdminSvc := services.NewUserAdminService(userRepo, roleRepo, refreshTokenRepo, txManager, auditRepo)
+	userHandler := handlers.NewUserHandler(userAdminSvc)
+
+	can := func(permission string) echo.MiddlewareFunc {
+		return middleware.RequirePermission(rbacSvc, permission)
+	}
+
+	e.HTTPErrorHandler = handlers.ErrorHandler
+	e.IPExtractor = ipExtractor(cfg)
+	e.Use(middleware.Metrics(), middleware.RequestID())
+
 	// open routes (auth)
-	e.POST("/dummyLogin", authHandler.DummyLogin)
-	e.POST("/login", authHandler.LoginUser)
-	e.POST("/register", authHandler.RegisterUser)
+	// тестовый токен выдается любому, поэтому маршрут есть только в development
+	if cfg.IsDevelopment() {
+		e.POST("/dummyLogin", authHandler.DummyLogin, validator)
+	}
+	e.POST("/login", authHandler.LoginUser, validator)
+	e.POST("/token", authHandler.IssueTokens, validator)
+	e.POST("/register", authHandler.RegisterUser, validator)
+	e.POST("/token/refresh", authHandler.RefreshTokens, validator)
+	e.POST("/logout", authHandler.Logout, validator)
+	e.POST("/password/forgot", authHandler.ForgotPassword, validator)
+	e.POST("/password/reset", authHandler.ResetPassword, validator)
+	e.GET("/.well-known/jwks.json", jwksHandler.GetJWKS)
 
 	// protected routes
 	protected := e.Group("")
-	protected.Use(middleware.JWTMiddleware(cfg.JWTSecret), middleware.WithRole())
+	protected.Use(middleware.JWTMiddleware(keys), middleware.WithRole(), middleware.RequireActiveUser(userSvc), validator)
 
 	// pvz
-	protected.GET("/pvz", pvzHandler.GetPVZs)
-	protected.POST("/pvz", pvzHandler.CreatePVZ, middleware.OnlyModerator())
+	protected.GET("/pvz", pvzHandler.GetPVZs, can(models.PermissionPVZRead))
+	protected.GET("/pvz/:pvzId", pvzHandler.GetPVZDetails, can(models.PermissionPVZRead))
+	protected.POST("/pvz", pvzHandler.CreatePVZ, can(models.PermissionPVZCreate))
+	protected.GET("/pvz/:pvzId/employees", assignmentHandler.GetAssignments, can(models.PermissionPVZAssign))
+	protected.POST("/pvz/:pvzId/employees", assignmentHandler.AssignEmployee, can(models.PermissionPVZAssign))
+	protected.DELETE("/pvz/:pvzId/employees/:userId", assignmentHandler.UnassignEmployee, can(models.PermissionPVZAssign))
 
 	// reception
-	protected.POST("/receptions", receptionHandler.CreateReception, middleware.OnlyEmployee())
-	protected.POST("/pvz/:pvzId/close_last_reception", receptionHandler.CloseLastReception, middleware.OnlyEmployee())
+	protected.GET("/receptions", receptionHandler.GetReceptions, can(models.PermissionReceptionRead))
+	protected.GET("/receptions/:receptionId", receptionHandler.GetReception, can(models.PermissionReceptionRead))
+	protected.POST("/receptions", receptionHandler.CreateReception, can(models.PermissionReceptionCreate))
+	protected.POST("/pvz/:pvzId/close_last_reception", receptionHandler.CloseLastReception, can(models.PermissionReceptionClose))
 
 	// product
-	protected.POST("/products", productHandler.AddProduct, middleware.OnlyEmployee())
-	protected.POST("/pvz/:pvzId/delete_last_product", productHandler.DeleteLastProduct, middleware.OnlyEmployee())
+	protected.POST("/products", productHandler.AddProduct, can(models.PermissionProductAdd))
+	protected.POST("/products/batch", productHandler.AddProducts, can(models.PermissionProductAdd))
+	protected.GET("/products/by-barcode/:code", productHandler.GetProductsByBarcode, can(models.PermissionPVZRead))
+	protected.POST("/pvz/:pvzId/delete_last_product", productHandler.DeleteLastProduct, can(models.PermissionProductDelete))
+	protected.DELETE("/products/:productId", productHandler.DeleteProduct, can(models.PermissionProductDelete))
+
+	// users
+	protected.GET("/users/lockouts", authHandler.GetLockouts, can(models.PermissionUserUnlock))
+	protected.POST("/users/unlock", authHandler.UnlockUser, can(models.PermissionUserUnlock))
+	protected.GET("/users", userHandler.GetUsers, can(models.PermissionUserManage))
+	protected.GET("/users/:userId", userHandler.GetUser, can(models.PermissionUserManage))
+	protected.PUT("/users/:userId/role", userHandler.ChangeRole, can(models.PermissionUserManage))
+	protected.POST("/users/:userId/deactivate", userHandler.DeactivateUser, can(models.PermissionUserManage))
+	protected.POST("/users/:userId/activate", userHandler.ActivateUser, can(models.PermissionUserManage))
+	protected.POST("/users/:userId/force_password_reset", userHandler.ForcePasswordReset, can(models.PermissionUserManage))
+
+	// audit
+	protected.GET("/audit", auditHandler.GetAuditEntries, can(models.PermissionAuditRead))
+
+	// roles
+	protected.GET("/roles", rbacHandler.GetRoles, can(models.PermissionRoleManage))
+	protected.GET("/permissions", rbacHandler.GetPermissions, can(models.PermissionRoleManage))
+	protected.PUT("/roles/:role/permissions", rbacHandler.SetRolePermissions, can(models.PermissionRoleManage))
+
+	return nil
+}
+
+// loadSigningKeys читает асимметричные ключи из JWT_KEYS_DIR, а без него использует общий секрет HS256
+func loadSigningKeys(cfg *config.Config) (*utils.KeySet, error) {
+	if cfg.JWTKeysDir == "" {
+		return utils.NewHMACKeySet(cfg.JWTSecret), nil
+	}
+
+	keys, err := utils.LoadKeySet(cfg.JWTKeysDir, cfg.JWTActiveKID)
+	if err != nil {
+		return nil, fmt.Errorf("не удалось загрузить ключи подписи JWT: %v", err)
+	}
+	return keys, nil
+}
+
+// newNotifier выбирает доставку писем по NOTIFIER: через SMTP или в NOTIFIER_FILE (без него - в стандартный вывод)
+func newNotifier(cfg *config.Config) (notifier.Notifier, error) {
+	if cfg.Notifier == config.NotifierSMTP {
+		return notifier.NewSMTPNotifier(notifier.SMTPConfig{
+			Host:     cfg.SMTPHost,
+			Port:     cfg.SMTPPort,
+			Username: cfg.SMTPUsername,
+			Password: cfg.SMTPPassword,
+			From:     cfg.SMTPFrom,
+		}), nil
+	}
+
+	if cfg.NotifierFile == "" {
+		return notifier.NewLogNotifier(os.Stdout), nil
+	}
+	// файл открыт до завершения процесса
+	f, err := os.OpenFile(cfg.NotifierFile, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("не удалось открыть файл для писем: %v", err)
+	}
+	return notifier.NewLogNotifier(f), nil
+}
+
+// ipExtractor определяет IP клиента для ограничения входа. X-Forwarded-For учитывается только от прокси
+// из TRUSTED_PROXIES: иначе любой клиент, включая соседей по docker сети, подставил бы чужой адрес и обошел лимит по IP
+func ipExtractor(cfg *config.Config) echo.IPExtractor {
+	nets := cfg.TrustedProxyNets()
+	if len(nets) == 0 {
+		return echo.ExtractIPDirect()
+	}
+
+	options := []echo.TrustOption{echo.TrustLoopback(false), echo.TrustLinkLocal(false), echo.TrustPrivateNet(false)}
+	for _, ipNet := range nets {
+		options = append(options, echo.TrustIPRange(ipNet))
+	}
+	return echo.ExtractIPFromXFFHeader(options...)
+}
+
+// barcodeScope переводит BARCODE_UNIQUENESS в область уникальности штрихкода; по умолчанию - приемка
+func barcodeScope(cfg *config.Config) models.BarcodeScope {
+	if cfg.BarcodeUniqueness == config.BarcodeUniquenessGlobal {
+		return models.BarcodeScopeGlobal
+	}
+	return models.BarcodeScopeReception
+}
+
+// BootstrapAdmin назначает первого администратора из ADMIN_EMAIL и ADMIN_PASSWORD, если они заданы
+func BootstrapAdmin(ctx context.Context, db *database.DB, cfg *config.Config) error {
+	if cfg.AdminEmail == "" {
+		return nil
+	}
+	userAdminSvc := services.NewUserAdminService(repos.NewUserRepo(db), repos.NewRoleRepo(db), repos.NewRefreshTokenRepo(db), repos.NewTxManager(db), repos.NewAuditRepo(db))
+	if err := userAdminSvc.BootstrapAdmin(ctx, cfg.AdminEmail, cfg.AdminPassword); err != nil {
+		return fmt.Errorf("не удалось назначить администратора: %w", err)
+	}
+	return nil
+}
+
+// NewReceptionCloser собирает фоновую задачу автозакрытия приемок; nil, если автозакрытие выключено
+func NewReceptionCloser(db *database.DB, cfg *config.Config) *worker.ReceptionCloser {
+	idleTimeout, interval := cfg.ReceptionAutoClose()
+	if idleTimeout == 0 {
+		return nil
+	}
+
+	receptionRepo := repos.NewReceptionRepo(db)
+	receptionSvc := services.NewReceptionService(receptionRepo, repos.NewProductRepo(db), repos.NewPVZAssignmentRepo(db), repos.NewTxManager(db), repos.NewAuditRepo(db), false)
+	return worker.NewReceptionCloser(receptionSvc, idleTimeout, interval)
+}
+
+// NewGRPCServer собирает gRPC сервер. Вызовы проверяются по access токену и правам ролей, как в HTTP API
+func NewGRPCServer(db *database.DB, cfg *config.Config) (*grpc.Server, error) {
+	keys, err := loadSigningKeys(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	txManager := repos.NewTxManager(db)
+	auditRepo := repos.NewAuditRepo(db)
+	userRepo := repos.NewUserRepo(db)
+	// письма gRPC API не отправляет, сервис нужен только для проверки статуса пользователя
+	userSvc := services.NewUserService(userRepo, repos.NewRefreshTokenRepo(db), repos.NewLoginThrottleRepo(db), repos.NewPasswordResetRepo(db), txManager, auditRepo, utils.DefaultAuthUtil{Keys: keys}, nil, cfg.IsDevelopment())
+	rbacSvc := services.NewRBACService(repos.NewRoleRepo(db), txManager, auditRepo)
+	pvzSvc := services.NewPVZService(repos.NewPVZRepo(db), repos.NewReceptionRepo(db), repos.NewProductRepo(db), txManager, auditRepo)
+
+	s := grpc.NewServer(grpc.UnaryInterceptor(grpcserver.AuthInterceptor(keys, userSvc, rbacSvc)))
+	pvz_v1.RegisterPVZServiceServer(s, grpcserver.NewPVZServer(pvzSvc))
+	return s, nil
 }
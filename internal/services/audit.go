@@ -0,0 +1,60 @@
+package services
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/forzeyy/avito-internship-spring-service/internal/models"
+	"github.com/forzeyy/avito-internship-spring-service/internal/repos"
+	"github.com/forzeyy/avito-internship-spring-service/internal/reqctx"
+	"github.com/google/uuid"
+)
+
+// auditLog записывает изменения в журнал аудита. Успешное действие пишется в транзакции самого изменения,
+// неудачное - отдельно, после отката транзакции
+type auditLog struct {
+	repo repos.AuditRepo
+}
+
+func (al auditLog) success(ctx context.Context, action string, target models.AuditTarget) error {
+	return al.successWithDetails(ctx, action, target, nil)
+}
+
+// successWithDetails записывает успешное действие вместе с подробностями, которых нет в target,
+// например новым набором прав роли
+func (al auditLog) successWithDetails(ctx context.Context, action string, target models.AuditTarget, details map[string]any) error {
+	return al.repo.CreateEntry(ctx, newAuditEntry(ctx, action, target, details, nil))
+}
+
+// failure не возвращает ошибку записи, чтобы не подменить ею исходную ошибку действия
+func (al auditLog) failure(ctx context.Context, action string, target models.AuditTarget, cause error) {
+	al.failureWithDetails(ctx, action, target, nil, cause)
+}
+
+func (al auditLog) failureWithDetails(ctx context.Context, action string, target models.AuditTarget, details map[string]any, cause error) {
+	if err := al.repo.CreateEntry(ctx, newAuditEntry(ctx, action, target, details, cause)); err != nil {
+		log.Printf("не удалось записать событие аудита %s: %v", action, err)
+	}
+}
+
+func newAuditEntry(ctx context.Context, action string, target models.AuditTarget, details map[string]any, cause error) *models.AuditEntry {
+	entry := &models.AuditEntry{
+		ID:        uuid.New(),
+		Action:    action,
+		Target:    target,
+		Details:   details,
+		RequestID: reqctx.RequestID(ctx),
+		Outcome:   models.AuditOutcomeSuccess,
+		CreatedAt: time.Now(),
+	}
+	if user, ok := reqctx.UserFromContext(ctx); ok {
+		entry.ActorID = &user.ID
+		entry.ActorRole = user.Role
+	}
+	if cause != nil {
+		entry.Outcome = models.AuditOutcomeFailure
+		entry.Error = cause.Error()
+	}
+	return entry
+}
@@ -0,0 +1,79 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/forzeyy/avito-internship-spring-service/internal/apperrors"
+	"github.com/forzeyy/avito-internship-spring-service/internal/models"
+	"github.com/forzeyy/avito-internship-spring-service/internal/repos"
+	"github.com/google/uuid"
+)
+
+type AuditService interface {
+	GetEntries(ctx context.Context, params AuditListParams) ([]models.AuditEntry, error)
+}
+
+type AuditListParams struct {
+	ActorID     string
+	Action      string
+	PVZID       string
+	ReceptionID string
+	Outcome     string
+	From        *time.Time
+	To          *time.Time
+	Page        int
+	Limit       int
+}
+
+type auditService struct {
+	auditRepo repos.AuditRepo
+}
+
+func NewAuditService(auditRepo repos.AuditRepo) AuditService {
+	return &auditService{auditRepo: auditRepo}
+}
+
+func (as *auditService) GetEntries(ctx context.Context, params AuditListParams) ([]models.AuditEntry, error) {
+	filter := models.AuditFilter{
+		From: params.From,
+		To:   params.To,
+	}
+
+	if params.ActorID != "" {
+		actorID, err := uuid.Parse(params.ActorID)
+		if err != nil {
+			return nil, apperrors.Validation("неверный формат actor_id")
+		}
+		filter.ActorID = &actorID
+	}
+
+	if params.PVZID != "" {
+		pvzID, err := uuid.Parse(params.PVZID)
+		if err != nil {
+			return nil, apperrors.Validation("неверный формат pvz_id")
+		}
+		filter.PVZID = &pvzID
+	}
+
+	if params.ReceptionID != "" {
+		receptionID, err := uuid.Parse(params.ReceptionID)
+		if err != nil {
+			return nil, apperrors.Validation("неверный формат id приемки")
+		}
+		filter.ReceptionID = &receptionID
+	}
+
+	if params.Action != "" {
+		filter.Action = &params.Action
+	}
+
+	if params.Outcome != "" {
+		if params.Outcome != models.AuditOutcomeSuccess && params.Outcome != models.AuditOutcomeFailure {
+			return nil, apperrors.Validation("неверный результат действия")
+		}
+		filter.Outcome = &params.Outcome
+	}
+
+	return as.auditRepo.GetEntries(ctx, filter, params.Page, params.Limit)
+}
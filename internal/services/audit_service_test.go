@@ -0,0 +1,123 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/forzeyy/avito-internship-spring-service/internal/models"
+	"github.com/forzeyy/avito-internship-spring-service/internal/services"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// fakeAuditRepo запоминает записанные события аудита
+type fakeAuditRepo struct {
+	entries []models.AuditEntry
+	err     error
+}
+
+func (f *fakeAuditRepo) CreateEntry(ctx context.Context, entry *models.AuditEntry) error {
+	if f.err != nil {
+		return f.err
+	}
+	f.entries = append(f.entries, *entry)
+	return nil
+}
+
+func (f *fakeAuditRepo) GetEntries(ctx context.Context, filter models.AuditFilter, page, limit int) ([]models.AuditEntry, error) {
+	return f.entries, nil
+}
+
+type mockAuditRepo struct {
+	mock.Mock
+}
+
+func (m *mockAuditRepo) CreateEntry(ctx context.Context, entry *models.AuditEntry) error {
+	args := m.Called(ctx, entry)
+	return args.Error(0)
+}
+
+func (m *mockAuditRepo) GetEntries(ctx context.Context, filter models.AuditFilter, page, limit int) ([]models.AuditEntry, error) {
+	args := m.Called(ctx, filter, page, limit)
+	if entries, ok := args.Get(0).([]models.AuditEntry); ok {
+		return entries, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func TestGetAuditEntries_Filter(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := new(mockAuditRepo)
+	service := services.NewAuditService(mockRepo)
+
+	actorID := uuid.New()
+	pvzID := uuid.New()
+	action := models.AuditActionReceptionClose
+	outcome := models.AuditOutcomeFailure
+	from := time.Now().Add(-time.Hour)
+	expected := []models.AuditEntry{{ID: uuid.New(), Action: action, Outcome: outcome}}
+
+	mockRepo.On("GetEntries", ctx, models.AuditFilter{
+		ActorID: &actorID,
+		Action:  &action,
+		PVZID:   &pvzID,
+		Outcome: &outcome,
+		From:    &from,
+	}, 1, 30).Return(expected, nil)
+
+	result, err := service.GetEntries(ctx, services.AuditListParams{
+		ActorID: actorID.String(),
+		Action:  action,
+		PVZID:   pvzID.String(),
+		Outcome: outcome,
+		From:    &from,
+		Page:    1,
+		Limit:   30,
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, expected, result)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestGetAuditEntries_InvalidOutcome(t *testing.T) {
+	mockRepo := new(mockAuditRepo)
+	service := services.NewAuditService(mockRepo)
+
+	result, err := service.GetEntries(context.Background(), services.AuditListParams{Outcome: "ok", Page: 1, Limit: 30})
+
+	assert.Nil(t, result)
+	assert.EqualError(t, err, "неверный результат действия")
+	mockRepo.AssertNotCalled(t, "GetEntries")
+}
+
+func TestGetAuditEntries_InvalidActorID(t *testing.T) {
+	mockRepo := new(mockAuditRepo)
+	service := services.NewAuditService(mockRepo)
+
+	result, err := service.GetEntries(context.Background(), services.AuditListParams{ActorID: "nope", Page: 1, Limit: 30})
+
+	assert.Nil(t, result)
+	assert.EqualError(t, err, "неверный формат actor_id")
+	mockRepo.AssertNotCalled(t, "GetEntries")
+}
+
+func TestAudit_WriteErrorFailsAction(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := new(mockReceptionRepo)
+	audit := &fakeAuditRepo{err: errors.New("audit down")}
+	service := services.NewReceptionService(mockRepo, nil, assignedEverywhere(), fakeTxManager{}, audit, false)
+
+	pvzID := uuid.New()
+	mockRepo.On("GetLastOpenReception", ctx, pvzID).Return(nil, nil)
+	mockRepo.On("CreateReception", ctx, mock.Anything).Return(nil)
+
+	// без записи в журнале изменение не должно считаться выполненным
+	reception, err := service.CreateReception(ctx, pvzID.String(), testUserID.String())
+
+	assert.Nil(t, reception)
+	assert.EqualError(t, err, "audit down")
+}
@@ -0,0 +1,149 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/forzeyy/avito-internship-spring-service/internal/apperrors"
+	"github.com/forzeyy/avito-internship-spring-service/internal/models"
+)
+
+// Ограничение перебора паролей. После loginDelayAfter неудач подряд каждая следующая попытка
+// разрешается только через удваивающуюся паузу, а после порога блокировки вход закрывается на loginLockoutTTL.
+// Счетчик сбрасывается, если неудач не было дольше loginFailureWindow
+const (
+	loginDelayAfter    = 3
+	loginBaseDelay     = time.Second
+	loginMaxDelay      = time.Minute
+	emailLockoutAfter  = 10
+	ipLockoutAfter     = 50
+	loginLockoutTTL    = 15 * time.Minute
+	loginFailureWindow = loginLockoutTTL
+)
+
+type loginThrottleKey struct {
+	scope     string
+	key       string
+	lockAfter int
+}
+
+// loginThrottleKeys возвращает счетчики, которые учитывают попытку: по email и по IP, с которого она пришла.
+// Порог по IP выше, потому что за одним адресом может быть много сотрудников
+func loginThrottleKeys(email, ip string) []loginThrottleKey {
+	keys := []loginThrottleKey{{scope: models.LoginThrottleScopeEmail, key: normalizeEmail(email), lockAfter: emailLockoutAfter}}
+	if ip != "" {
+		keys = append(keys, loginThrottleKey{scope: models.LoginThrottleScopeIP, key: ip, lockAfter: ipLockoutAfter})
+	}
+	return keys
+}
+
+func normalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+// checkLoginThrottle отклоняет попытку до проверки пароля, чтобы перебор не нагружал bcrypt
+func (us *userService) checkLoginThrottle(ctx context.Context, keys []loginThrottleKey, now time.Time) error {
+	for _, key := range keys {
+		throttle, err := us.throttleRepo.GetLoginThrottle(ctx, key.scope, key.key)
+		if err != nil {
+			return err
+		}
+		if wait := loginWait(throttle, now); wait > 0 {
+			return apperrors.TooManyRequests(fmt.Sprintf("слишком много неудачных попыток входа, повторите через %d с", int(math.Ceil(wait.Seconds()))))
+		}
+	}
+	return nil
+}
+
+// loginWait возвращает, сколько осталось ждать до следующей разрешенной попытки
+func loginWait(throttle *models.LoginThrottle, now time.Time) time.Duration {
+	if throttle == nil || throttle.LastFailedAt.Before(now.Add(-loginFailureWindow)) {
+		return 0
+	}
+	if throttle.LockedUntil != nil && throttle.LockedUntil.After(now) {
+		return throttle.LockedUntil.Sub(now)
+	}
+	if throttle.FailedCount < loginDelayAfter {
+		return 0
+	}
+
+	delay := loginMaxDelay
+	if shift := throttle.FailedCount - loginDelayAfter; shift < 6 {
+		delay = min(loginBaseDelay<<shift, loginMaxDelay)
+	}
+	return max(throttle.LastFailedAt.Add(delay).Sub(now), 0)
+}
+
+// recordLoginFailure учитывает неудачную попытку во всех счетчиках и блокирует вход при достижении порога
+func (us *userService) recordLoginFailure(ctx context.Context, keys []loginThrottleKey, now time.Time) error {
+	var locked []string
+	err := us.txManager.WithTx(ctx, func(ctx context.Context) error {
+		for _, key := range keys {
+			throttle, err := us.throttleRepo.RegisterLoginFailure(ctx, key.scope, key.key, now, now.Add(-loginFailureWindow))
+			if err != nil {
+				return err
+			}
+			if throttle.FailedCount < key.lockAfter || (throttle.LockedUntil != nil && throttle.LockedUntil.After(now)) {
+				continue
+			}
+
+			if err := us.throttleRepo.LockLogin(ctx, key.scope, key.key, now.Add(loginLockoutTTL)); err != nil {
+				return err
+			}
+			locked = append(locked, fmt.Sprintf("%s %s после %d неудачных попыток", key.scope, key.key, throttle.FailedCount))
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, lockout := range locked {
+		log.Printf("вход заблокирован до %s: %s", now.Add(loginLockoutTTL).Format(time.RFC3339), lockout)
+	}
+	return nil
+}
+
+// Ограничение запросов сброса пароля: не больше resetEmailLimit писем на один адрес и resetIPLimit запросов с одного IP,
+// пока между запросами проходит меньше resetRequestWindow
+const (
+	resetEmailLimit    = 3
+	resetIPLimit       = 20
+	resetRequestWindow = time.Hour
+)
+
+// resetThrottleKeys возвращает счетчики запросов сброса пароля. Счетчик по email ведется и для незарегистрированных адресов,
+// иначе по отказу можно было бы понять, что адрес есть в системе
+func resetThrottleKeys(email, ip string) []loginThrottleKey {
+	keys := []loginThrottleKey{{scope: models.LoginThrottleScopeResetEmail, key: normalizeEmail(email), lockAfter: resetEmailLimit}}
+	if ip != "" {
+		keys = append(keys, loginThrottleKey{scope: models.LoginThrottleScopeResetIP, key: ip, lockAfter: resetIPLimit})
+	}
+	return keys
+}
+
+// checkResetThrottle отклоняет запрос сброса пароля, если лимит по email или IP уже исчерпан, иначе учитывает его
+func (us *userService) checkResetThrottle(ctx context.Context, keys []loginThrottleKey, now time.Time) error {
+	for _, key := range keys {
+		throttle, err := us.throttleRepo.GetLoginThrottle(ctx, key.scope, key.key)
+		if err != nil {
+			return err
+		}
+		if throttle != nil && !throttle.LastFailedAt.Before(now.Add(-resetRequestWindow)) && throttle.FailedCount >= key.lockAfter {
+			return apperrors.TooManyRequests("слишком много запросов сброса пароля, повторите позже")
+		}
+	}
+
+	return us.txManager.WithTx(ctx, func(ctx context.Context) error {
+		for _, key := range keys {
+			if _, err := us.throttleRepo.RegisterLoginFailure(ctx, key.scope, key.key, now, now.Add(-resetRequestWindow)); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
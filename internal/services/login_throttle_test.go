@@ -0,0 +1,139 @@
+package services_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/forzeyy/avito-internship-spring-service/internal/apperrors"
+	"github.com/forzeyy/avito-internship-spring-service/internal/models"
+	"github.com/forzeyy/avito-internship-spring-service/internal/services"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// fakeLoginThrottleRepo хранит счетчики в памяти и повторяет логику запросов к login_throttles
+type fakeLoginThrottleRepo struct {
+	throttles map[string]*models.LoginThrottle
+}
+
+func newFakeLoginThrottleRepo() *fakeLoginThrottleRepo {
+	return &fakeLoginThrottleRepo{throttles: map[string]*models.LoginThrottle{}}
+}
+
+func (f *fakeLoginThrottleRepo) GetLoginThrottle(ctx context.Context, scope, key string) (*models.LoginThrottle, error) {
+	if throttle, ok := f.throttles[scope+"/"+key]; ok {
+		copied := *throttle
+		return &copied, nil
+	}
+	return nil, nil
+}
+
+func (f *fakeLoginThrottleRepo) RegisterLoginFailure(ctx context.Context, scope, key string, at, resetBefore time.Time) (*models.LoginThrottle, error) {
+	throttle, ok := f.throttles[scope+"/"+key]
+	if !ok || throttle.LastFailedAt.Before(resetBefore) {
+		throttle = &models.LoginThrottle{Scope: scope, Key: key}
+		f.throttles[scope+"/"+key] = throttle
+	}
+	throttle.FailedCount++
+	throttle.LastFailedAt = at
+	copied := *throttle
+	return &copied, nil
+}
+
+func (f *fakeLoginThrottleRepo) LockLogin(ctx context.Context, scope, key string, until time.Time) error {
+	f.throttles[scope+"/"+key].LockedUntil = &until
+	return nil
+}
+
+func (f *fakeLoginThrottleRepo) ResetLoginThrottle(ctx context.Context, scope, key string) error {
+	delete(f.throttles, scope+"/"+key)
+	return nil
+}
+
+func (f *fakeLoginThrottleRepo) GetActiveLockouts(ctx context.Context, now time.Time) ([]models.LoginThrottle, error) {
+	var lockouts []models.LoginThrottle
+	for _, throttle := range f.throttles {
+		if throttle.LockedUntil != nil && throttle.LockedUntil.After(now) {
+			lockouts = append(lockouts, *throttle)
+		}
+	}
+	return lockouts, nil
+}
+
+func newThrottledUserService(throttles *fakeLoginThrottleRepo) (services.UserService, *mockUserRepo) {
+	mockRepo := new(mockUserRepo)
+	mockAuth := new(mockAuthUtil)
+	user := &models.User{ID: uuid.New(), Email: "victim@mail.ru", PasswordHash: "hash", Role: "employee"}
+
+	mockRepo.On("GetUserByEmail", mock.Anything, "victim@mail.ru").Return(user, nil)
+	mockAuth.On("CheckPassword", "hash", mock.Anything).Return(false)
+
+	return services.NewUserService(mockRepo, nil, throttles, nil, fakeTxManager{}, &fakeAuditRepo{}, mockAuth, nil, false), mockRepo
+}
+
+func TestLoginUser_DelayAfterRepeatedFailures(t *testing.T) {
+	throttles := newFakeLoginThrottleRepo()
+	svc, _ := newThrottledUserService(throttles)
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		_, err := svc.LoginUser(ctx, "victim@mail.ru", "guess", "10.0.0.1")
+		assert.EqualError(t, err, "неверный пароль")
+	}
+
+	// после третьей неудачи следующая попытка разрешена только через паузу, пароль даже не проверяется
+	_, err := svc.LoginUser(ctx, "Victim@Mail.ru", "guess", "10.0.0.2")
+	assert.Equal(t, apperrors.KindTooManyRequests, apperrors.KindOf(err))
+	assert.Equal(t, 3, throttles.throttles["email/victim@mail.ru"].FailedCount)
+}
+
+func TestLoginUser_LockoutAndUnlock(t *testing.T) {
+	throttles := newFakeLoginThrottleRepo()
+	svc, _ := newThrottledUserService(throttles)
+	ctx := context.Background()
+
+	// попытки разнесены во времени, поэтому паузы уже истекли и каждая из них доходит до проверки пароля
+	throttles.throttles["email/victim@mail.ru"] = &models.LoginThrottle{
+		Scope:        models.LoginThrottleScopeEmail,
+		Key:          "victim@mail.ru",
+		FailedCount:  9,
+		LastFailedAt: time.Now().Add(-2 * time.Minute),
+	}
+
+	_, err := svc.LoginUser(ctx, "victim@mail.ru", "guess", "10.0.0.1")
+	assert.EqualError(t, err, "неверный пароль")
+
+	lockouts, err := svc.GetLockouts(ctx)
+	assert.NoError(t, err)
+	if assert.Len(t, lockouts, 1) {
+		assert.Equal(t, "victim@mail.ru", lockouts[0].Key)
+		assert.WithinDuration(t, time.Now().Add(15*time.Minute), *lockouts[0].LockedUntil, time.Minute)
+	}
+
+	_, err = svc.LoginUser(ctx, "victim@mail.ru", "guess", "10.0.0.1")
+	assert.Equal(t, apperrors.KindTooManyRequests, apperrors.KindOf(err))
+
+	assert.NoError(t, svc.UnlockUser(ctx, "victim@mail.ru"))
+	lockouts, err = svc.GetLockouts(ctx)
+	assert.NoError(t, err)
+	assert.Empty(t, lockouts)
+}
+
+func TestLoginUser_ThrottledByIP(t *testing.T) {
+	throttles := newFakeLoginThrottleRepo()
+	svc, _ := newThrottledUserService(throttles)
+
+	lockedUntil := time.Now().Add(time.Minute)
+	throttles.throttles["ip/10.0.0.1"] = &models.LoginThrottle{
+		Scope:        models.LoginThrottleScopeIP,
+		Key:          "10.0.0.1",
+		FailedCount:  50,
+		LastFailedAt: time.Now(),
+		LockedUntil:  &lockedUntil,
+	}
+
+	_, err := svc.LoginUser(context.Background(), "victim@mail.ru", "guess", "10.0.0.1")
+	assert.Equal(t, apperrors.KindTooManyRequests, apperrors.KindOf(err))
+}
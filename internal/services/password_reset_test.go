@@ -0,0 +1,250 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/forzeyy/avito-internship-spring-service/internal/apperrors"
+	"github.com/forzeyy/avito-internship-spring-service/internal/models"
+	"github.com/forzeyy/avito-internship-spring-service/internal/notifier"
+	"github.com/forzeyy/avito-internship-spring-service/internal/repos"
+	"github.com/forzeyy/avito-internship-spring-service/internal/services"
+	"github.com/forzeyy/avito-internship-spring-service/internal/utils"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// fakePasswordResetRepo хранит токены сброса в памяти
+type fakePasswordResetRepo struct {
+	tokens map[string]*models.PasswordResetToken
+}
+
+func newFakePasswordResetRepo() *fakePasswordResetRepo {
+	return &fakePasswordResetRepo{tokens: map[string]*models.PasswordResetToken{}}
+}
+
+func (f *fakePasswordResetRepo) CreateResetToken(ctx context.Context, token *models.PasswordResetToken) error {
+	copied := *token
+	f.tokens[token.TokenHash] = &copied
+	return nil
+}
+
+func (f *fakePasswordResetRepo) LockResetToken(ctx context.Context, tokenHash string) (*models.PasswordResetToken, error) {
+	if token, ok := f.tokens[tokenHash]; ok {
+		copied := *token
+		return &copied, nil
+	}
+	return nil, nil
+}
+
+func (f *fakePasswordResetRepo) RevokeUserResetTokens(ctx context.Context, userID uuid.UUID) error {
+	now := time.Now()
+	for _, token := range f.tokens {
+		if token.UserID == userID && token.UsedAt == nil {
+			token.UsedAt = &now
+		}
+	}
+	return nil
+}
+
+// fakeNotifier запоминает письма; ForgotPassword отправляет их в фоне, поэтому тесты ждут попытку через waitSend
+type fakeNotifier struct {
+	mu       sync.Mutex
+	sent     []notifier.Message
+	err      error
+	attempts chan struct{}
+}
+
+func newFakeNotifier(err error) *fakeNotifier {
+	return &fakeNotifier{err: err, attempts: make(chan struct{}, 10)}
+}
+
+func (f *fakeNotifier) Send(ctx context.Context, msg notifier.Message) error {
+	defer func() { f.attempts <- struct{}{} }()
+	if f.err != nil {
+		return f.err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.sent = append(f.sent, msg)
+	return nil
+}
+
+func (f *fakeNotifier) waitSend(t *testing.T) {
+	select {
+	case <-f.attempts:
+	case <-time.After(time.Second):
+		t.Fatal("письмо не отправлено")
+	}
+}
+
+var resetTokenPattern = regexp.MustCompile(`Токен для сброса: (\S+)`)
+
+func sentResetToken(t *testing.T, msg notifier.Message) string {
+	match := resetTokenPattern.FindStringSubmatch(msg.Body)
+	if !assert.Len(t, match, 2) {
+		t.FailNow()
+	}
+	return match[1]
+}
+
+// ForgotPassword
+func TestForgotPassword_SendsToken(t *testing.T) {
+	mockRepo := new(mockUserRepo)
+	resets := newFakePasswordResetRepo()
+	mailer := newFakeNotifier(nil)
+	audit := &fakeAuditRepo{}
+	user := &models.User{ID: uuid.New(), Email: "user@mail.ru", Role: "employee"}
+
+	mockRepo.On("GetUserByEmail", mock.Anything, "user@mail.ru").Return(user, nil)
+
+	svc := services.NewUserService(mockRepo, nil, newFakeLoginThrottleRepo(), resets, fakeTxManager{}, audit, new(mockAuthUtil), mailer, false)
+
+	assert.NoError(t, svc.ForgotPassword(context.Background(), "user@mail.ru", "10.0.0.1"))
+	mailer.waitSend(t)
+	assert.NoError(t, svc.ForgotPassword(context.Background(), "user@mail.ru", "10.0.0.1"))
+	mailer.waitSend(t)
+
+	if assert.Len(t, mailer.sent, 2) {
+		assert.Equal(t, "user@mail.ru", mailer.sent[1].To)
+		// в базе только хеш, а первый токен отменен повторным запросом
+		first := resets.tokens[utils.HashPasswordResetToken(sentResetToken(t, mailer.sent[0]))]
+		second := resets.tokens[utils.HashPasswordResetToken(sentResetToken(t, mailer.sent[1]))]
+		assert.NotNil(t, first.UsedAt)
+		assert.Nil(t, second.UsedAt)
+		assert.WithinDuration(t, time.Now().Add(time.Hour), second.ExpiresAt, time.Minute)
+	}
+	if assert.Len(t, audit.entries, 2) {
+		assert.Equal(t, models.AuditActionUserResetRequest, audit.entries[0].Action)
+		assert.Equal(t, user.ID, *audit.entries[0].ActorID)
+	}
+}
+
+func TestForgotPassword_UnknownEmail(t *testing.T) {
+	mockRepo := new(mockUserRepo)
+	resets := newFakePasswordResetRepo()
+	mailer := newFakeNotifier(nil)
+	deactivatedAt := time.Now()
+
+	mockRepo.On("GetUserByEmail", mock.Anything, "ghost@mail.ru").Return(nil, repos.ErrUserNotFound)
+	mockRepo.On("GetUserByEmail", mock.Anything, "fired@mail.ru").Return(&models.User{ID: uuid.New(), Email: "fired@mail.ru", DeactivatedAt: &deactivatedAt}, nil)
+
+	svc := services.NewUserService(mockRepo, nil, newFakeLoginThrottleRepo(), resets, fakeTxManager{}, &fakeAuditRepo{}, new(mockAuthUtil), mailer, false)
+
+	// ответ такой же, как для существующего пользователя, но письмо не отправляется
+	assert.NoError(t, svc.ForgotPassword(context.Background(), "ghost@mail.ru", "10.0.0.1"))
+	assert.NoError(t, svc.ForgotPassword(context.Background(), "fired@mail.ru", "10.0.0.1"))
+	assert.Empty(t, mailer.sent)
+	assert.Empty(t, resets.tokens)
+}
+
+func TestForgotPassword_SendError(t *testing.T) {
+	mockRepo := new(mockUserRepo)
+	mailer := newFakeNotifier(errors.New("smtp недоступен"))
+	audit := &fakeAuditRepo{}
+
+	mockRepo.On("GetUserByEmail", mock.Anything, "user@mail.ru").Return(&models.User{ID: uuid.New(), Email: "user@mail.ru"}, nil)
+
+	svc := services.NewUserService(mockRepo, nil, newFakeLoginThrottleRepo(), newFakePasswordResetRepo(), fakeTxManager{}, audit, new(mockAuthUtil), mailer, false)
+
+	// ошибка доставки только логируется: иначе по ответу было бы видно, что адрес зарегистрирован
+	assert.NoError(t, svc.ForgotPassword(context.Background(), "user@mail.ru", "10.0.0.1"))
+	mailer.waitSend(t)
+	if assert.Len(t, audit.entries, 1) {
+		assert.Equal(t, models.AuditOutcomeSuccess, audit.entries[0].Outcome)
+	}
+}
+
+func TestForgotPassword_EmailLimit(t *testing.T) {
+	mockRepo := new(mockUserRepo)
+	mockRepo.On("GetUserByEmail", mock.Anything, mock.Anything).Return(nil, repos.ErrUserNotFound)
+
+	svc := services.NewUserService(mockRepo, nil, newFakeLoginThrottleRepo(), newFakePasswordResetRepo(), fakeTxManager{}, &fakeAuditRepo{}, new(mockAuthUtil), newFakeNotifier(nil), false)
+	ctx := context.Background()
+
+	// лимит одинаков для зарегистрированных и неизвестных адресов
+	for i := 0; i < 3; i++ {
+		assert.NoError(t, svc.ForgotPassword(ctx, "ghost@mail.ru", "10.0.0.1"))
+	}
+	err := svc.ForgotPassword(ctx, " Ghost@mail.ru", "10.0.0.2")
+	assert.EqualError(t, err, "слишком много запросов сброса пароля, повторите позже")
+	assert.Equal(t, apperrors.KindTooManyRequests, apperrors.KindOf(err))
+
+	assert.NoError(t, svc.ForgotPassword(ctx, "other@mail.ru", "10.0.0.1"))
+}
+
+func TestForgotPassword_IPLimit(t *testing.T) {
+	mockRepo := new(mockUserRepo)
+	mockRepo.On("GetUserByEmail", mock.Anything, mock.Anything).Return(nil, repos.ErrUserNotFound)
+
+	svc := services.NewUserService(mockRepo, nil, newFakeLoginThrottleRepo(), newFakePasswordResetRepo(), fakeTxManager{}, &fakeAuditRepo{}, new(mockAuthUtil), newFakeNotifier(nil), false)
+	ctx := context.Background()
+
+	for i := 0; i < 20; i++ {
+		assert.NoError(t, svc.ForgotPassword(ctx, fmt.Sprintf("user%d@mail.ru", i), "10.0.0.1"))
+	}
+	assert.EqualError(t, svc.ForgotPassword(ctx, "next@mail.ru", "10.0.0.1"), "слишком много запросов сброса пароля, повторите позже")
+	assert.NoError(t, svc.ForgotPassword(ctx, "next@mail.ru", "10.0.0.2"))
+}
+
+// ResetPassword
+func TestResetPassword_Success(t *testing.T) {
+	mockRepo := new(mockUserRepo)
+	mockTokens := new(mockRefreshTokenRepo)
+	resets := newFakePasswordResetRepo()
+	throttles := newFakeLoginThrottleRepo()
+	user := &models.User{ID: uuid.New(), Email: "User@Mail.ru", Role: "employee", PasswordResetRequired: true}
+
+	resets.tokens[utils.HashPasswordResetToken("token")] = &models.PasswordResetToken{ID: uuid.New(), UserID: user.ID, ExpiresAt: time.Now().Add(time.Hour)}
+	throttles.throttles["email/user@mail.ru"] = &models.LoginThrottle{Scope: models.LoginThrottleScopeEmail, Key: "user@mail.ru", FailedCount: 10}
+
+	mockRepo.On("GetUserByID", mock.Anything, user.ID).Return(user, nil)
+	mockRepo.On("UpdatePassword", mock.Anything, user.ID, mock.MatchedBy(func(hash string) bool {
+		return utils.CheckPassword(hash, "new_secret")
+	})).Return(nil)
+	mockTokens.On("RevokeUserRefreshTokens", mock.Anything, user.ID).Return(nil)
+
+	svc := services.NewUserService(mockRepo, mockTokens, throttles, resets, fakeTxManager{}, &fakeAuditRepo{}, new(mockAuthUtil), nil, false)
+
+	err := svc.ResetPassword(context.Background(), "token", "new_secret")
+	assert.NoError(t, err)
+	assert.NotNil(t, resets.tokens[utils.HashPasswordResetToken("token")].UsedAt)
+	assert.Empty(t, throttles.throttles)
+	mockRepo.AssertExpectations(t)
+	mockTokens.AssertExpectations(t)
+
+	// токен одноразовый
+	err = svc.ResetPassword(context.Background(), "token", "other")
+	assert.ErrorIs(t, err, services.ErrInvalidResetToken)
+}
+
+func TestResetPassword_InvalidToken(t *testing.T) {
+	resets := newFakePasswordResetRepo()
+	usedAt := time.Now()
+	resets.tokens[utils.HashPasswordResetToken("expired")] = &models.PasswordResetToken{UserID: uuid.New(), ExpiresAt: time.Now().Add(-time.Minute)}
+	resets.tokens[utils.HashPasswordResetToken("used")] = &models.PasswordResetToken{UserID: uuid.New(), ExpiresAt: time.Now().Add(time.Hour), UsedAt: &usedAt}
+
+	mockRepo := new(mockUserRepo)
+	svc := services.NewUserService(mockRepo, nil, newFakeLoginThrottleRepo(), resets, fakeTxManager{}, &fakeAuditRepo{}, new(mockAuthUtil), nil, false)
+
+	for _, token := range []string{"missing", "expired", "used"} {
+		t.Run(token, func(t *testing.T) {
+			err := svc.ResetPassword(context.Background(), token, "new_secret")
+			assert.ErrorIs(t, err, services.ErrInvalidResetToken)
+		})
+	}
+	mockRepo.AssertNotCalled(t, "UpdatePassword", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestResetPassword_EmptyPassword(t *testing.T) {
+	svc := services.NewUserService(new(mockUserRepo), nil, newFakeLoginThrottleRepo(), newFakePasswordResetRepo(), fakeTxManager{}, &fakeAuditRepo{}, new(mockAuthUtil), nil, false)
+
+	err := svc.ResetPassword(context.Background(), "token", "")
+	assert.EqualError(t, err, "пароль пользователя не может быть пустым")
+}
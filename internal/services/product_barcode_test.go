@@ -0,0 +1,131 @@
+package services_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/forzeyy/avito-internship-spring-service/internal/models"
+	"github.com/forzeyy/avito-internship-spring-service/internal/repos"
+	"github.com/forzeyy/avito-internship-spring-service/internal/services"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestAddProduct_WithBarcode(t *testing.T) {
+	mockProd := new(mockProductRepo)
+	mockRec := new(mockReceptionRepo)
+
+	pvzID := uuid.New()
+	reception := &models.Reception{ID: uuid.New(), PVZID: pvzID}
+	mockRec.On("LockLastOpenReception", mock.Anything, pvzID).Return(reception, nil)
+	mockProd.On("FindBarcodes", mock.Anything, []string{"4601234567890"}, &reception.ID).Return(nil, nil)
+	mockProd.On("AddProduct", mock.Anything, mock.MatchedBy(func(product *models.Product) bool {
+		return product.Barcode != nil && *product.Barcode == "4601234567890"
+	})).Return(nil)
+
+	svc := services.NewProductService(mockProd, mockRec, assignedEverywhere(), fakeTxManager{}, &fakeAuditRepo{}, models.BarcodeScopeReception, false)
+
+	// пробелы по краям добавляют некоторые сканеры
+	product, err := svc.AddProduct(context.Background(), "обувь", " 4601234567890\n", pvzID.String(), testUserID.String())
+	assert.NoError(t, err)
+	assert.Equal(t, "4601234567890", *product.Barcode)
+	mockProd.AssertNotCalled(t, "LockBarcodes", mock.Anything, mock.Anything)
+	mockProd.AssertExpectations(t)
+}
+
+func TestAddProduct_DuplicateBarcodeInReception(t *testing.T) {
+	mockProd := new(mockProductRepo)
+	mockRec := new(mockReceptionRepo)
+	audit := &fakeAuditRepo{}
+
+	pvzID := uuid.New()
+	reception := &models.Reception{ID: uuid.New(), PVZID: pvzID}
+	mockRec.On("LockLastOpenReception", mock.Anything, pvzID).Return(reception, nil)
+	mockProd.On("FindBarcodes", mock.Anything, []string{"4601234567890"}, &reception.ID).Return([]string{"4601234567890"}, nil)
+
+	svc := services.NewProductService(mockProd, mockRec, assignedEverywhere(), fakeTxManager{}, audit, models.BarcodeScopeReception, false)
+
+	product, err := svc.AddProduct(context.Background(), "обувь", "4601234567890", pvzID.String(), testUserID.String())
+	assert.Nil(t, product)
+	assert.ErrorIs(t, err, repos.ErrDuplicateBarcode)
+	mockProd.AssertNotCalled(t, "AddProduct", mock.Anything, mock.Anything)
+	if assert.Len(t, audit.entries, 1) {
+		assert.Equal(t, models.AuditOutcomeFailure, audit.entries[0].Outcome)
+	}
+}
+
+func TestAddProduct_DuplicateBarcodeGlobal(t *testing.T) {
+	mockProd := new(mockProductRepo)
+	mockRec := new(mockReceptionRepo)
+
+	pvzID := uuid.New()
+	mockRec.On("LockLastOpenReception", mock.Anything, pvzID).Return(&models.Reception{ID: uuid.New(), PVZID: pvzID}, nil)
+	mockProd.On("LockBarcodes", mock.Anything, []string{"4601234567890"}).Return(nil)
+	mockProd.On("FindBarcodes", mock.Anything, []string{"4601234567890"}, (*uuid.UUID)(nil)).Return([]string{"4601234567890"}, nil)
+
+	svc := services.NewProductService(mockProd, mockRec, assignedEverywhere(), fakeTxManager{}, &fakeAuditRepo{}, models.BarcodeScopeGlobal, false)
+
+	_, err := svc.AddProduct(context.Background(), "обувь", "4601234567890", pvzID.String(), testUserID.String())
+	assert.ErrorIs(t, err, repos.ErrDuplicateBarcode)
+	mockProd.AssertExpectations(t)
+}
+
+func TestAddProduct_InvalidBarcode(t *testing.T) {
+	svc := services.NewProductService(nil, nil, assignedEverywhere(), fakeTxManager{}, &fakeAuditRepo{}, models.BarcodeScopeReception, false)
+
+	product, err := svc.AddProduct(context.Background(), "обувь", "46012 34567890", uuid.New().String(), testUserID.String())
+	assert.Nil(t, product)
+	assert.EqualError(t, err, "неверный формат штрихкода: от 1 до 64 латинских букв, цифр и символов . _ -")
+}
+
+func TestAddProducts_RejectsDuplicateBarcodes(t *testing.T) {
+	mockProd := new(mockProductRepo)
+	mockRec := new(mockReceptionRepo)
+
+	pvzID := uuid.New()
+	reception := &models.Reception{ID: uuid.New(), PVZID: pvzID}
+	mockRec.On("LockLastOpenReception", mock.Anything, pvzID).Return(reception, nil)
+	mockProd.On("FindBarcodes", mock.Anything, []string{"A-1", "B-2"}, &reception.ID).Return([]string{"B-2"}, nil)
+	mockProd.On("AddProducts", mock.Anything, mock.MatchedBy(func(products []models.Product) bool {
+		return len(products) == 2 && *products[0].Barcode == "A-1" && products[1].Barcode == nil
+	})).Return(nil)
+
+	svc := services.NewProductService(mockProd, mockRec, assignedEverywhere(), fakeTxManager{}, &fakeAuditRepo{}, models.BarcodeScopeReception, false)
+
+	batch, err := svc.AddProducts(context.Background(), []models.NewProduct{
+		{Type: "обувь", Barcode: "A-1"},
+		{Type: "одежда", Barcode: "A-1"},
+		{Type: "обувь", Barcode: "B-2"},
+		{Type: "электроника"},
+		{Type: "одежда", Barcode: "штрихкод"},
+	}, pvzID.String(), testUserID.String())
+	assert.NoError(t, err)
+	if assert.Len(t, batch.Items, 5) {
+		assert.NotNil(t, batch.Items[0].Product)
+		assert.Equal(t, "штрихкод повторяется в пакете", batch.Items[1].Error)
+		assert.Equal(t, "товар с таким штрихкодом уже принят", batch.Items[2].Error)
+		assert.NotNil(t, batch.Items[3].Product)
+		assert.Contains(t, batch.Items[4].Error, "неверный формат штрихкода")
+	}
+	mockProd.AssertExpectations(t)
+}
+
+func TestGetProductsByBarcode(t *testing.T) {
+	mockProd := new(mockProductRepo)
+	svc := services.NewProductService(mockProd, nil, assignedEverywhere(), fakeTxManager{}, &fakeAuditRepo{}, models.BarcodeScopeReception, false)
+
+	location := models.ProductLocation{PVZID: uuid.New(), City: "Москва", ReceptionStatus: "close"}
+	mockProd.On("GetProductsByBarcode", mock.Anything, "4601234567890").Return([]models.ProductLocation{location}, nil)
+	mockProd.On("GetProductsByBarcode", mock.Anything, "4601234567891").Return(nil, nil)
+
+	locations, err := svc.GetProductsByBarcode(context.Background(), "4601234567890")
+	assert.NoError(t, err)
+	assert.Equal(t, []models.ProductLocation{location}, locations)
+
+	_, err = svc.GetProductsByBarcode(context.Background(), "4601234567891")
+	assert.ErrorIs(t, err, repos.ErrProductNotFound)
+
+	_, err = svc.GetProductsByBarcode(context.Background(), " ")
+	assert.Contains(t, err.Error(), "неверный формат штрихкода")
+}
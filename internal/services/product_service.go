@@ -2,78 +2,430 @@ package services
 
 import (
 	"context"
-	"errors"
 	"fmt"
+	"regexp"
+	"strings"
 	"time"
 
+	"github.com/forzeyy/avito-internship-spring-service/internal/apperrors"
+	"github.com/forzeyy/avito-internship-spring-service/internal/metrics"
 	"github.com/forzeyy/avito-internship-spring-service/internal/models"
 	"github.com/forzeyy/avito-internship-spring-service/internal/repos"
 	"github.com/google/uuid"
 )
 
 type ProductService interface {
-	AddProduct(ctx context.Context, productType, pvzID string) (*models.Product, error)
-	DeleteLastProduct(ctx context.Context, pvzID string) error
+	AddProduct(ctx context.Context, productType, barcode, pvzID, userID string) (*models.Product, error)
+	AddProducts(ctx context.Context, items []models.NewProduct, pvzID, userID string) (*models.ProductBatch, error)
+	DeleteLastProduct(ctx context.Context, pvzID, userID string) error
+	DeleteProduct(ctx context.Context, productID, userID string) error
+	GetProductsByBarcode(ctx context.Context, barcode string) ([]models.ProductLocation, error)
 }
 
+// MaxProductBatchSize ограничивает пакет сканера, чтобы одна транзакция не держала приемку слишком долго
+const MaxProductBatchSize = 500
+
+var (
+	ErrReceptionClosed = apperrors.Conflict("приемка уже закрыта, товар удалить нельзя")
+
+	errInvalidProductType     = apperrors.Validation("недопустимый тип товара")
+	errInvalidBarcode         = apperrors.Validation("неверный формат штрихкода: от 1 до 64 латинских букв, цифр и символов . _ -")
+	errBarcodeRepeatedInBatch = apperrors.Validation("штрихкод повторяется в пакете")
+)
+
+var barcodePattern = regexp.MustCompile(`^[0-9A-Za-z._-]{1,64}$`)
+
 type productService struct {
-	prodRepo repos.ProductRepo
-	recRepo  repos.ReceptionRepo
+	prodRepo     repos.ProductRepo
+	recRepo      repos.ReceptionRepo
+	access       pvzAccess
+	txManager    repos.TxManager
+	audit        auditLog
+	barcodeScope models.BarcodeScope
 }
 
-func NewProductService(prodRepo repos.ProductRepo, recRepo repos.ReceptionRepo) ProductService {
+func NewProductService(prodRepo repos.ProductRepo, recRepo repos.ReceptionRepo, assignmentRepo repos.PVZAssignmentRepo, txManager repos.TxManager, auditRepo repos.AuditRepo, barcodeScope models.BarcodeScope, allowDummyUsers bool) ProductService {
 	return &productService{
-		prodRepo: prodRepo,
-		recRepo:  recRepo,
+		prodRepo:     prodRepo,
+		recRepo:      recRepo,
+		access:       pvzAccess{repo: assignmentRepo, allowDummyUsers: allowDummyUsers},
+		txManager:    txManager,
+		audit:        auditLog{repo: auditRepo},
+		barcodeScope: barcodeScope,
 	}
 }
 
-func (ps *productService) AddProduct(ctx context.Context, productType, pvzID string) (*models.Product, error) {
-	if productType != "электроника" && productType != "одежда" && productType != "обувь" {
-		return nil, errors.New("недопустимый тип товара")
+// AddProduct добавляет товар в открытую приемку ПВЗ. Штрихкод необязателен; если он передан, повторно принять
+// посылку с тем же штрихкодом в пределах barcodeScope нельзя
+func (ps *productService) AddProduct(ctx context.Context, productType, barcode, pvzID, userID string) (product *models.Product, err error) {
+	var target models.AuditTarget
+	defer func() {
+		if err != nil {
+			ps.audit.failure(ctx, models.AuditActionProductAdd, target, err)
+		}
+	}()
+
+	if !isValidProductType(productType) {
+		return nil, errInvalidProductType
+	}
+	barcode, err = normalizeBarcode(barcode)
+	if err != nil {
+		return nil, err
 	}
 
 	parsedPVZID, err := uuid.Parse(pvzID)
 	if err != nil || parsedPVZID == uuid.Nil {
-		return nil, errors.New("неверный формат pvz_id")
+		return nil, apperrors.Validation("неверный формат pvz_id")
 	}
+	target.PVZID = &parsedPVZID
 
-	lastReception, err := ps.recRepo.GetLastOpenReception(ctx, parsedPVZID)
+	createdBy, err := parseUserID(userID)
 	if err != nil {
 		return nil, err
 	}
-	if lastReception == nil {
-		return nil, errors.New("последняя открытая приемка не найдена")
+	if err := ps.access.check(ctx, parsedPVZID, createdBy); err != nil {
+		return nil, err
 	}
 
-	product := &models.Product{
-		ID:          uuid.New(),
-		Type:        productType,
-		ReceptionID: lastReception.ID,
-		DateTime:    time.Now(),
-	}
-	err = ps.prodRepo.AddProduct(ctx, product)
+	// приемка блокируется до вставки товара, чтобы ее нельзя было закрыть между чтением и вставкой
+	err = ps.txManager.WithTx(ctx, func(ctx context.Context) error {
+		lastReception, err := ps.recRepo.LockLastOpenReception(ctx, parsedPVZID)
+		if err != nil {
+			return err
+		}
+		if lastReception == nil {
+			return ErrNoOpenReception
+		}
+		target.ReceptionID = &lastReception.ID
+
+		if barcode != "" {
+			existing, err := ps.existingBarcodes(ctx, []string{barcode}, lastReception.ID)
+			if err != nil {
+				return err
+			}
+			if existing[barcode] {
+				return repos.ErrDuplicateBarcode
+			}
+		}
+
+		product = &models.Product{
+			ID:          uuid.New(),
+			Type:        productType,
+			ReceptionID: lastReception.ID,
+			DateTime:    time.Now(),
+			CreatedBy:   &createdBy,
+			Barcode:     optionalBarcode(barcode),
+		}
+		if err := ps.prodRepo.AddProduct(ctx, product); err != nil {
+			return err
+		}
+		target.ProductID = &product.ID
+		return ps.audit.success(ctx, models.AuditActionProductAdd, target)
+	})
 	if err != nil {
 		return nil, err
 	}
+	metrics.ProductsAddedTotal.Inc()
 
 	return product, nil
 }
 
-func (ps *productService) DeleteLastProduct(ctx context.Context, pvzID string) error {
+// AddProducts добавляет пакет товаров со сканера в открытую приемку одной транзакцией. Позиции с недопустимым типом,
+// неверным или уже принятым штрихкодом не прерывают пакет, а возвращаются с ошибкой в своем результате
+func (ps *productService) AddProducts(ctx context.Context, items []models.NewProduct, pvzID, userID string) (batch *models.ProductBatch, err error) {
+	var target models.AuditTarget
+	defer func() {
+		if err != nil {
+			ps.audit.failure(ctx, models.AuditActionProductBatchAdd, target, err)
+		}
+	}()
+
+	if len(items) == 0 {
+		return nil, apperrors.Validation("пакет товаров пуст")
+	}
+	if len(items) > MaxProductBatchSize {
+		return nil, apperrors.Validation(fmt.Sprintf("в пакете не больше %d товаров", MaxProductBatchSize))
+	}
+
 	parsedPVZID, err := uuid.Parse(pvzID)
+	if err != nil || parsedPVZID == uuid.Nil {
+		return nil, apperrors.Validation("неверный формат pvz_id")
+	}
+	target.PVZID = &parsedPVZID
+
+	createdBy, err := parseUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := ps.access.check(ctx, parsedPVZID, createdBy); err != nil {
+		return nil, err
+	}
+
+	// позиции проверяются до транзакции; в ней остается только сверка штрихкодов с уже принятыми товарами
+	itemErrors := make([]string, len(items))
+	barcodes := make([]string, len(items))
+	var uniqueBarcodes []string
+	seen := make(map[string]bool)
+	for i, item := range items {
+		if !isValidProductType(item.Type) {
+			itemErrors[i] = errInvalidProductType.Error()
+			continue
+		}
+		barcode, err := normalizeBarcode(item.Barcode)
+		if err != nil {
+			itemErrors[i] = err.Error()
+			continue
+		}
+		if barcode == "" {
+			continue
+		}
+		if seen[barcode] {
+			itemErrors[i] = errBarcodeRepeatedInBatch.Error()
+			continue
+		}
+		seen[barcode] = true
+		barcodes[i] = barcode
+		uniqueBarcodes = append(uniqueBarcodes, barcode)
+	}
+
+	var added int
+	err = ps.txManager.WithTx(ctx, func(ctx context.Context) error {
+		lastReception, err := ps.recRepo.LockLastOpenReception(ctx, parsedPVZID)
+		if err != nil {
+			return err
+		}
+		if lastReception == nil {
+			return ErrNoOpenReception
+		}
+		target.ReceptionID = &lastReception.ID
+
+		existing, err := ps.existingBarcodes(ctx, uniqueBarcodes, lastReception.ID)
+		if err != nil {
+			return err
+		}
+
+		batch = &models.ProductBatch{
+			ReceptionID: lastReception.ID,
+			Items:       make([]models.ProductBatchItem, len(items)),
+		}
+		products := make([]models.Product, 0, len(items))
+		// время приема растет на микросекунду (точность timestamp) от позиции к позиции,
+		// чтобы удаление последнего товара шло в порядке сканирования
+		receivedAt := time.Now()
+		for i, item := range items {
+			batch.Items[i].Index = i
+			if itemErrors[i] != "" {
+				batch.Items[i].Error = itemErrors[i]
+				continue
+			}
+			if existing[barcodes[i]] {
+				batch.Items[i].Error = repos.ErrDuplicateBarcode.Error()
+				continue
+			}
+			products = append(products, models.Product{
+				ID:          uuid.New(),
+				Type:        item.Type,
+				ReceptionID: lastReception.ID,
+				DateTime:    receivedAt.Add(time.Duration(i) * time.Microsecond),
+				CreatedBy:   &createdBy,
+				Barcode:     optionalBarcode(barcodes[i]),
+			})
+			// емкость products задана заранее, поэтому указатель не устареет при append
+			batch.Items[i].Product = &products[len(products)-1]
+		}
+
+		if err := ps.prodRepo.AddProducts(ctx, products); err != nil {
+			return err
+		}
+		added = len(products)
+		return ps.audit.success(ctx, models.AuditActionProductBatchAdd, target)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	metrics.ProductsAddedTotal.Add(float64(added))
+
+	return batch, nil
+}
+
+func (ps *productService) DeleteLastProduct(ctx context.Context, pvzID, userID string) (err error) {
+	var target models.AuditTarget
+	defer func() {
+		if err != nil {
+			ps.audit.failure(ctx, models.AuditActionProductDelete, target, err)
+		}
+	}()
+
+	parsedPVZID, err := uuid.Parse(pvzID)
+	if err != nil {
+		return apperrors.Validation("неверный формат pvz_id")
+	}
+	target.PVZID = &parsedPVZID
+
+	deletedBy, err := parseUserID(userID)
+	if err != nil {
+		return err
+	}
+	if err := ps.access.check(ctx, parsedPVZID, deletedBy); err != nil {
+		return err
+	}
+
+	err = ps.txManager.WithTx(ctx, func(ctx context.Context) error {
+		// проверка есть ли открытая приемка
+		lastReception, err := ps.recRepo.LockLastOpenReception(ctx, parsedPVZID)
+		if err != nil {
+			return fmt.Errorf("не удалось получить последнюю открытую приемку: %w", err)
+		}
+		if lastReception == nil || lastReception.ID == uuid.Nil {
+			return ErrNoOpenReception
+		}
+		target.ReceptionID = &lastReception.ID
+
+		if err := ps.prodRepo.DeleteLastProduct(ctx, parsedPVZID); err != nil {
+			return err
+		}
+		return ps.audit.success(ctx, models.AuditActionProductDelete, target)
+	})
 	if err != nil {
-		return errors.New("неверный формат pvz_id")
+		return err
 	}
+	metrics.ProductsDeletedTotal.Inc()
+
+	return nil
+}
+
+// DeleteProduct удаляет конкретный товар, например ошибочно отсканированный, пока его приемка открыта
+func (ps *productService) DeleteProduct(ctx context.Context, productID, userID string) (err error) {
+	var target models.AuditTarget
+	defer func() {
+		if err != nil {
+			ps.audit.failure(ctx, models.AuditActionProductDelete, target, err)
+		}
+	}()
 
-	// проверка есть ли открытая приемка
-	lastReception, err := ps.recRepo.GetLastOpenReception(ctx, parsedPVZID)
+	parsedProductID, err := uuid.Parse(productID)
 	if err != nil {
-		return fmt.Errorf("не удалось получить последнюю открытую приемку: %w", err)
+		return apperrors.Validation("неверный формат id товара")
 	}
-	if lastReception == nil || lastReception.ID == uuid.Nil {
-		return errors.New("последняя открытая приемка не найдена")
+	target.ProductID = &parsedProductID
+
+	deletedBy, err := parseUserID(userID)
+	if err != nil {
+		return err
 	}
 
-	return ps.prodRepo.DeleteLastProduct(ctx, parsedPVZID)
+	err = ps.txManager.WithTx(ctx, func(ctx context.Context) error {
+		product, err := ps.prodRepo.GetProductByID(ctx, parsedProductID)
+		if err != nil {
+			return err
+		}
+		if product == nil {
+			return repos.ErrProductNotFound
+		}
+		target.ReceptionID = &product.ReceptionID
+
+		reception, err := ps.recRepo.GetReceptionByID(ctx, product.ReceptionID)
+		if err != nil {
+			return err
+		}
+		if reception == nil {
+			return ErrReceptionNotFound
+		}
+		target.PVZID = &reception.PVZID
+
+		if err := ps.access.check(ctx, reception.PVZID, deletedBy); err != nil {
+			return err
+		}
+
+		// открытая приемка блокируется, чтобы ее не закрыли, пока удаляется товар
+		openReception, err := ps.recRepo.LockLastOpenReception(ctx, reception.PVZID)
+		if err != nil {
+			return err
+		}
+		if openReception == nil || openReception.ID != reception.ID {
+			return ErrReceptionClosed
+		}
+
+		if err := ps.prodRepo.DeleteProduct(ctx, parsedProductID); err != nil {
+			return err
+		}
+		return ps.audit.success(ctx, models.AuditActionProductDelete, target)
+	})
+	if err != nil {
+		return err
+	}
+	metrics.ProductsDeletedTotal.Inc()
+
+	return nil
+}
+
+// GetProductsByBarcode возвращает, где и когда принималась посылка со штрихкодом, начиная с последнего приема
+func (ps *productService) GetProductsByBarcode(ctx context.Context, barcode string) ([]models.ProductLocation, error) {
+	barcode, err := normalizeBarcode(barcode)
+	if err != nil {
+		return nil, err
+	}
+	if barcode == "" {
+		return nil, errInvalidBarcode
+	}
+
+	locations, err := ps.prodRepo.GetProductsByBarcode(ctx, barcode)
+	if err != nil {
+		return nil, err
+	}
+	if len(locations) == 0 {
+		return nil, repos.ErrProductNotFound
+	}
+	return locations, nil
+}
+
+// existingBarcodes возвращает штрихкоды, которые уже приняты в приемку receptionID, а при глобальной уникальности -
+// в любую приемку. В глобальном режиме штрихкоды блокируются до конца транзакции, потому что блокировка приемки
+// не защищает от одновременного приема той же посылки в другом ПВЗ
+func (ps *productService) existingBarcodes(ctx context.Context, barcodes []string, receptionID uuid.UUID) (map[string]bool, error) {
+	if len(barcodes) == 0 {
+		return nil, nil
+	}
+
+	scope := &receptionID
+	if ps.barcodeScope == models.BarcodeScopeGlobal {
+		if err := ps.prodRepo.LockBarcodes(ctx, barcodes); err != nil {
+			return nil, err
+		}
+		scope = nil
+	}
+
+	found, err := ps.prodRepo.FindBarcodes(ctx, barcodes, scope)
+	if err != nil {
+		return nil, err
+	}
+	existing := make(map[string]bool, len(found))
+	for _, barcode := range found {
+		existing[barcode] = true
+	}
+	return existing, nil
+}
+
+// normalizeBarcode убирает пробелы по краям, которые добавляют некоторые сканеры; пустой штрихкод означает его отсутствие
+func normalizeBarcode(barcode string) (string, error) {
+	barcode = strings.TrimSpace(barcode)
+	if barcode == "" {
+		return "", nil
+	}
+	if !barcodePattern.MatchString(barcode) {
+		return "", errInvalidBarcode
+	}
+	return barcode, nil
+}
+
+func optionalBarcode(barcode string) *string {
+	if barcode == "" {
+		return nil
+	}
+	return &barcode
+}
+
+func isValidProductType(productType string) bool {
+	return productType == "электроника" || productType == "одежда" || productType == "обувь"
 }
@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/forzeyy/avito-internship-spring-service/internal/models"
+	"github.com/forzeyy/avito-internship-spring-service/internal/repos"
 	"github.com/forzeyy/avito-internship-spring-service/internal/services"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
@@ -21,11 +22,65 @@ func (m *mockProductRepo) AddProduct(ctx context.Context, product *models.Produc
 	return args.Error(0)
 }
 
+func (m *mockProductRepo) AddProducts(ctx context.Context, products []models.Product) error {
+	args := m.Called(ctx, products)
+	return args.Error(0)
+}
+
 func (m *mockProductRepo) DeleteLastProduct(ctx context.Context, pvzID uuid.UUID) error {
 	args := m.Called(ctx, pvzID)
 	return args.Error(0)
 }
 
+func (m *mockProductRepo) GetProductByID(ctx context.Context, id uuid.UUID) (*models.Product, error) {
+	args := m.Called(ctx, id)
+	if product, ok := args.Get(0).(*models.Product); ok {
+		return product, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *mockProductRepo) DeleteProduct(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *mockProductRepo) GetProductsByReceptionIDs(ctx context.Context, receptionIDs []uuid.UUID) ([]models.Product, error) {
+	args := m.Called(ctx, receptionIDs)
+	if products, ok := args.Get(0).([]models.Product); ok {
+		return products, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *mockProductRepo) LockBarcodes(ctx context.Context, barcodes []string) error {
+	args := m.Called(ctx, barcodes)
+	return args.Error(0)
+}
+
+func (m *mockProductRepo) FindBarcodes(ctx context.Context, barcodes []string, receptionID *uuid.UUID) ([]string, error) {
+	args := m.Called(ctx, barcodes, receptionID)
+	if found, ok := args.Get(0).([]string); ok {
+		return found, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *mockProductRepo) GetProductsByBarcode(ctx context.Context, barcode string) ([]models.ProductLocation, error) {
+	args := m.Called(ctx, barcode)
+	if locations, ok := args.Get(0).([]models.ProductLocation); ok {
+		return locations, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+// fakeTxManager выполняет функцию без транзакции
+type fakeTxManager struct{}
+
+func (fakeTxManager) WithTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
 // AddProduct
 func TestAddProduct_Success(t *testing.T) {
 	mockProd := new(mockProductRepo)
@@ -33,31 +88,32 @@ func TestAddProduct_Success(t *testing.T) {
 
 	pvzID := uuid.New()
 	productType := "электроника"
+	reception := &models.Reception{ID: uuid.New(), PVZID: pvzID}
 
-	mockRec.On("GetLastOpenReception", mock.Anything, pvzID).Return(&models.Reception{}, nil)
+	mockRec.On("LockLastOpenReception", mock.Anything, pvzID).Return(reception, nil)
 	mockProd.On("AddProduct", mock.Anything, mock.AnythingOfType("*models.Product")).Return(nil)
 
-	svc := services.NewProductService(mockProd, mockRec)
+	svc := services.NewProductService(mockProd, mockRec, assignedEverywhere(), fakeTxManager{}, &fakeAuditRepo{}, models.BarcodeScopeReception, false)
 
-	product, err := svc.AddProduct(context.Background(), productType, pvzID.String())
+	product, err := svc.AddProduct(context.Background(), productType, "", pvzID.String(), testUserID.String())
 	assert.NoError(t, err)
 	assert.NotNil(t, product)
 	assert.Equal(t, productType, product.Type)
-	assert.Equal(t, pvzID, product.ReceptionID)
+	assert.Equal(t, reception.ID, product.ReceptionID)
 }
 
 func TestAddProduct_InvalidType(t *testing.T) {
-	svc := services.NewProductService(nil, nil)
+	svc := services.NewProductService(nil, nil, assignedEverywhere(), fakeTxManager{}, &fakeAuditRepo{}, models.BarcodeScopeReception, false)
 
-	product, err := svc.AddProduct(context.Background(), "еда", uuid.New().String())
+	product, err := svc.AddProduct(context.Background(), "еда", "", uuid.New().String(), testUserID.String())
 	assert.Nil(t, product)
 	assert.EqualError(t, err, "недопустимый тип товара")
 }
 
 func TestAddProduct_InvalidUUID(t *testing.T) {
-	svc := services.NewProductService(nil, nil)
+	svc := services.NewProductService(nil, nil, assignedEverywhere(), fakeTxManager{}, &fakeAuditRepo{}, models.BarcodeScopeReception, false)
 
-	product, err := svc.AddProduct(context.Background(), "одежда", "invalid-uuid")
+	product, err := svc.AddProduct(context.Background(), "одежда", "", "invalid-uuid", testUserID.String())
 	assert.Nil(t, product)
 	assert.EqualError(t, err, "неверный формат pvz_id")
 }
@@ -67,12 +123,12 @@ func TestAddProduct_NoOpenReception(t *testing.T) {
 	mockRec := new(mockReceptionRepo)
 
 	pvzID := uuid.New()
-	mockRec.On("GetLastOpenReception", mock.Anything, pvzID).Return(nil, nil)
+	mockRec.On("LockLastOpenReception", mock.Anything, pvzID).Return(nil, nil)
 
-	svc := services.NewProductService(mockProd, mockRec)
+	svc := services.NewProductService(mockProd, mockRec, assignedEverywhere(), fakeTxManager{}, &fakeAuditRepo{}, models.BarcodeScopeReception, false)
 
-	product, err := svc.AddProduct(context.Background(), "обувь", pvzID.String())
-	mockRec.AssertCalled(t, "GetLastOpenReception", mock.Anything, pvzID)
+	product, err := svc.AddProduct(context.Background(), "обувь", "", pvzID.String(), testUserID.String())
+	mockRec.AssertCalled(t, "LockLastOpenReception", mock.Anything, pvzID)
 	assert.Nil(t, product)
 	assert.EqualError(t, err, "последняя открытая приемка не найдена")
 }
@@ -82,35 +138,94 @@ func TestAddProduct_DBError(t *testing.T) {
 	mockRec := new(mockReceptionRepo)
 
 	pvzID := uuid.New()
-	mockRec.On("GetLastOpenReception", mock.Anything, pvzID).Return(&models.Reception{}, nil)
+	mockRec.On("LockLastOpenReception", mock.Anything, pvzID).Return(&models.Reception{ID: uuid.New(), PVZID: pvzID}, nil)
 	mockProd.On("AddProduct", mock.Anything, mock.Anything).Return(errors.New("db error"))
 
-	svc := services.NewProductService(mockProd, mockRec)
+	svc := services.NewProductService(mockProd, mockRec, assignedEverywhere(), fakeTxManager{}, &fakeAuditRepo{}, models.BarcodeScopeReception, false)
 
-	product, err := svc.AddProduct(context.Background(), "электроника", pvzID.String())
+	product, err := svc.AddProduct(context.Background(), "электроника", "", pvzID.String(), testUserID.String())
 	assert.Nil(t, product)
 	assert.EqualError(t, err, "db error")
 }
 
+// AddProducts
+func TestAddProducts_PartialBatch(t *testing.T) {
+	mockProd := new(mockProductRepo)
+	mockRec := new(mockReceptionRepo)
+	audit := &fakeAuditRepo{}
+
+	pvzID := uuid.New()
+	reception := &models.Reception{ID: uuid.New(), PVZID: pvzID}
+	mockRec.On("LockLastOpenReception", mock.Anything, pvzID).Return(reception, nil)
+	mockProd.On("AddProducts", mock.Anything, mock.MatchedBy(func(products []models.Product) bool {
+		return len(products) == 3 &&
+			products[0].DateTime.Before(products[1].DateTime) &&
+			products[1].DateTime.Before(products[2].DateTime)
+	})).Return(nil)
+
+	svc := services.NewProductService(mockProd, mockRec, assignedEverywhere(), fakeTxManager{}, audit, models.BarcodeScopeReception, false)
+
+	batch, err := svc.AddProducts(context.Background(), []models.NewProduct{{Type: "электроника"}, {Type: "еда"}, {Type: "одежда"}, {Type: "обувь"}}, pvzID.String(), testUserID.String())
+	assert.NoError(t, err)
+	assert.Equal(t, reception.ID, batch.ReceptionID)
+	if assert.Len(t, batch.Items, 4) {
+		assert.Equal(t, "электроника", batch.Items[0].Product.Type)
+		assert.Nil(t, batch.Items[1].Product)
+		assert.Equal(t, "недопустимый тип товара", batch.Items[1].Error)
+		assert.Equal(t, 3, batch.Items[3].Index)
+		assert.Equal(t, reception.ID, batch.Items[3].Product.ReceptionID)
+	}
+	if assert.Len(t, audit.entries, 1) {
+		assert.Equal(t, models.AuditActionProductBatchAdd, audit.entries[0].Action)
+	}
+	mockProd.AssertExpectations(t)
+}
+
+func TestAddProducts_InvalidBatchSize(t *testing.T) {
+	svc := services.NewProductService(nil, nil, assignedEverywhere(), fakeTxManager{}, &fakeAuditRepo{}, models.BarcodeScopeReception, false)
+
+	_, err := svc.AddProducts(context.Background(), nil, uuid.New().String(), testUserID.String())
+	assert.EqualError(t, err, "пакет товаров пуст")
+
+	tooMany := make([]models.NewProduct, services.MaxProductBatchSize+1)
+	_, err = svc.AddProducts(context.Background(), tooMany, uuid.New().String(), testUserID.String())
+	assert.EqualError(t, err, "в пакете не больше 500 товаров")
+}
+
+func TestAddProducts_NoOpenReception(t *testing.T) {
+	mockProd := new(mockProductRepo)
+	mockRec := new(mockReceptionRepo)
+
+	pvzID := uuid.New()
+	mockRec.On("LockLastOpenReception", mock.Anything, pvzID).Return(nil, nil)
+
+	svc := services.NewProductService(mockProd, mockRec, assignedEverywhere(), fakeTxManager{}, &fakeAuditRepo{}, models.BarcodeScopeReception, false)
+
+	batch, err := svc.AddProducts(context.Background(), []models.NewProduct{{Type: "обувь"}}, pvzID.String(), testUserID.String())
+	assert.Nil(t, batch)
+	assert.ErrorIs(t, err, services.ErrNoOpenReception)
+	mockProd.AssertNotCalled(t, "AddProducts", mock.Anything, mock.Anything)
+}
+
 // DeleteLastProduct
 func TestDeleteLastProduct_Success(t *testing.T) {
 	mockProd := new(mockProductRepo)
 	mockRec := new(mockReceptionRepo)
 
 	pvzID := uuid.New()
-	mockRec.On("GetLastOpenReception", mock.Anything, pvzID).Return(&models.Reception{}, nil)
+	mockRec.On("LockLastOpenReception", mock.Anything, pvzID).Return(&models.Reception{ID: uuid.New(), PVZID: pvzID}, nil)
 	mockProd.On("DeleteLastProduct", mock.Anything, pvzID).Return(nil)
 
-	svc := services.NewProductService(mockProd, mockRec)
+	svc := services.NewProductService(mockProd, mockRec, assignedEverywhere(), fakeTxManager{}, &fakeAuditRepo{}, models.BarcodeScopeReception, false)
 
-	err := svc.DeleteLastProduct(context.Background(), pvzID.String())
+	err := svc.DeleteLastProduct(context.Background(), pvzID.String(), testUserID.String())
 	assert.NoError(t, err)
 }
 
 func TestDeleteLastProduct_InvalidUUID(t *testing.T) {
-	svc := services.NewProductService(nil, nil)
+	svc := services.NewProductService(nil, nil, assignedEverywhere(), fakeTxManager{}, &fakeAuditRepo{}, models.BarcodeScopeReception, false)
 
-	err := svc.DeleteLastProduct(context.Background(), "invalid-uuid")
+	err := svc.DeleteLastProduct(context.Background(), "invalid-uuid", testUserID.String())
 	assert.EqualError(t, err, "неверный формат pvz_id")
 }
 
@@ -119,12 +234,13 @@ func TestDeleteLastProduct_NoOpenReception(t *testing.T) {
 	mockRec := new(mockReceptionRepo)
 
 	pvzID := uuid.New()
-	mockRec.On("GetLastOpenReception", mock.Anything, pvzID).Return(nil, nil)
+	mockRec.On("LockLastOpenReception", mock.Anything, pvzID).Return(nil, nil)
 
-	svc := services.NewProductService(mockProd, mockRec)
+	svc := services.NewProductService(mockProd, mockRec, assignedEverywhere(), fakeTxManager{}, &fakeAuditRepo{}, models.BarcodeScopeReception, false)
 
-	err := svc.DeleteLastProduct(context.Background(), pvzID.String())
-	assert.EqualError(t, err, "приемка закрыта")
+	err := svc.DeleteLastProduct(context.Background(), pvzID.String(), testUserID.String())
+	assert.ErrorIs(t, err, services.ErrNoOpenReception)
+	mockProd.AssertNotCalled(t, "DeleteLastProduct", mock.Anything, mock.Anything)
 }
 
 func TestDeleteLastProduct_DBError(t *testing.T) {
@@ -132,11 +248,75 @@ func TestDeleteLastProduct_DBError(t *testing.T) {
 	mockRec := new(mockReceptionRepo)
 
 	pvzID := uuid.New()
-	mockRec.On("GetLastOpenReception", mock.Anything, pvzID).Return(&models.Reception{}, nil)
+	mockRec.On("LockLastOpenReception", mock.Anything, pvzID).Return(&models.Reception{ID: uuid.New(), PVZID: pvzID}, nil)
 	mockProd.On("DeleteLastProduct", mock.Anything, pvzID).Return(errors.New("delete error"))
 
-	svc := services.NewProductService(mockProd, mockRec)
+	svc := services.NewProductService(mockProd, mockRec, assignedEverywhere(), fakeTxManager{}, &fakeAuditRepo{}, models.BarcodeScopeReception, false)
 
-	err := svc.DeleteLastProduct(context.Background(), pvzID.String())
+	err := svc.DeleteLastProduct(context.Background(), pvzID.String(), testUserID.String())
 	assert.EqualError(t, err, "delete error")
 }
+
+// DeleteProduct
+func TestDeleteProduct_Success(t *testing.T) {
+	mockProd := new(mockProductRepo)
+	mockRec := new(mockReceptionRepo)
+	audit := &fakeAuditRepo{}
+
+	reception := &models.Reception{ID: uuid.New(), PVZID: uuid.New(), Status: "in_progress"}
+	product := &models.Product{ID: uuid.New(), Type: "обувь", ReceptionID: reception.ID}
+
+	mockProd.On("GetProductByID", mock.Anything, product.ID).Return(product, nil)
+	mockRec.On("GetReceptionByID", mock.Anything, reception.ID).Return(reception, nil)
+	mockRec.On("LockLastOpenReception", mock.Anything, reception.PVZID).Return(reception, nil)
+	mockProd.On("DeleteProduct", mock.Anything, product.ID).Return(nil)
+
+	svc := services.NewProductService(mockProd, mockRec, assignedEverywhere(), fakeTxManager{}, audit, models.BarcodeScopeReception, false)
+
+	err := svc.DeleteProduct(context.Background(), product.ID.String(), testUserID.String())
+	assert.NoError(t, err)
+	if assert.Len(t, audit.entries, 1) {
+		assert.Equal(t, product.ID, *audit.entries[0].Target.ProductID)
+		assert.Equal(t, reception.PVZID, *audit.entries[0].Target.PVZID)
+	}
+	mockProd.AssertExpectations(t)
+}
+
+func TestDeleteProduct_ReceptionClosed(t *testing.T) {
+	mockProd := new(mockProductRepo)
+	mockRec := new(mockReceptionRepo)
+
+	closed := &models.Reception{ID: uuid.New(), PVZID: uuid.New(), Status: "close"}
+	newer := &models.Reception{ID: uuid.New(), PVZID: closed.PVZID, Status: "in_progress"}
+	product := &models.Product{ID: uuid.New(), ReceptionID: closed.ID}
+
+	mockProd.On("GetProductByID", mock.Anything, product.ID).Return(product, nil)
+	mockRec.On("GetReceptionByID", mock.Anything, closed.ID).Return(closed, nil)
+	// в ПВЗ уже открыта следующая приемка, но товар относится к закрытой
+	mockRec.On("LockLastOpenReception", mock.Anything, closed.PVZID).Return(newer, nil)
+
+	svc := services.NewProductService(mockProd, mockRec, assignedEverywhere(), fakeTxManager{}, &fakeAuditRepo{}, models.BarcodeScopeReception, false)
+
+	err := svc.DeleteProduct(context.Background(), product.ID.String(), testUserID.String())
+	assert.ErrorIs(t, err, services.ErrReceptionClosed)
+	mockProd.AssertNotCalled(t, "DeleteProduct", mock.Anything, mock.Anything)
+}
+
+func TestDeleteProduct_NotFound(t *testing.T) {
+	mockProd := new(mockProductRepo)
+
+	productID := uuid.New()
+	mockProd.On("GetProductByID", mock.Anything, productID).Return(nil, nil)
+
+	svc := services.NewProductService(mockProd, new(mockReceptionRepo), assignedEverywhere(), fakeTxManager{}, &fakeAuditRepo{}, models.BarcodeScopeReception, false)
+
+	err := svc.DeleteProduct(context.Background(), productID.String(), testUserID.String())
+	assert.ErrorIs(t, err, repos.ErrProductNotFound)
+}
+
+func TestDeleteProduct_InvalidID(t *testing.T) {
+	svc := services.NewProductService(nil, nil, assignedEverywhere(), fakeTxManager{}, &fakeAuditRepo{}, models.BarcodeScopeReception, false)
+
+	err := svc.DeleteProduct(context.Background(), "product-1", testUserID.String())
+	assert.EqualError(t, err, "неверный формат id товара")
+}
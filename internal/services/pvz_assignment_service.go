@@ -0,0 +1,186 @@
+package services
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/forzeyy/avito-internship-spring-service/internal/apperrors"
+	"github.com/forzeyy/avito-internship-spring-service/internal/models"
+	"github.com/forzeyy/avito-internship-spring-service/internal/repos"
+	"github.com/forzeyy/avito-internship-spring-service/internal/reqctx"
+	"github.com/google/uuid"
+)
+
+type PVZAssignmentService interface {
+	GetAssignments(ctx context.Context, pvzID string) ([]models.PVZAssignment, error)
+	AssignEmployee(ctx context.Context, pvzID, userID string) (*models.PVZAssignment, error)
+	UnassignEmployee(ctx context.Context, pvzID, userID string) error
+}
+
+var (
+	ErrPVZNotAssigned = apperrors.Forbidden("сотрудник не закреплен за этим ПВЗ")
+	ErrNotPVZEmployee = apperrors.Validation("за ПВЗ можно закрепить только активного сотрудника")
+)
+
+// PVZScopedRoles - роли сотрудников ПВЗ: с приемками и товарами они работают только на закрепленных за ними ПВЗ.
+// Модераторы, региональные менеджеры и администраторы к пунктам не привязаны
+var PVZScopedRoles = []string{models.RoleEmployee, models.RoleSeniorEmployee}
+
+type pvzAssignmentService struct {
+	assignmentRepo repos.PVZAssignmentRepo
+	pvzRepo        repos.PVZRepo
+	userRepo       repos.UserRepo
+	txManager      repos.TxManager
+	audit          auditLog
+}
+
+func NewPVZAssignmentService(assignmentRepo repos.PVZAssignmentRepo, pvzRepo repos.PVZRepo, userRepo repos.UserRepo, txManager repos.TxManager, auditRepo repos.AuditRepo) PVZAssignmentService {
+	return &pvzAssignmentService{
+		assignmentRepo: assignmentRepo,
+		pvzRepo:        pvzRepo,
+		userRepo:       userRepo,
+		txManager:      txManager,
+		audit:          auditLog{repo: auditRepo},
+	}
+}
+
+func (as *pvzAssignmentService) GetAssignments(ctx context.Context, pvzID string) ([]models.PVZAssignment, error) {
+	parsedPVZID, err := uuid.Parse(pvzID)
+	if err != nil {
+		return nil, apperrors.Validation("неверный формат pvz_id")
+	}
+
+	pvz, err := as.pvzRepo.GetPVZByID(ctx, parsedPVZID)
+	if err != nil {
+		return nil, err
+	}
+	if pvz == nil {
+		return nil, ErrPVZNotFound
+	}
+
+	return as.assignmentRepo.GetAssignments(ctx, parsedPVZID)
+}
+
+func (as *pvzAssignmentService) AssignEmployee(ctx context.Context, pvzID, userID string) (assignment *models.PVZAssignment, err error) {
+	var target models.AuditTarget
+	defer func() {
+		if err != nil {
+			as.audit.failure(ctx, models.AuditActionPVZAssign, target, err)
+		}
+	}()
+
+	parsedPVZID, parsedUserID, err := parseAssignmentIDs(pvzID, userID)
+	if err != nil {
+		return nil, err
+	}
+	target.PVZID, target.UserID = &parsedPVZID, &parsedUserID
+
+	pvz, err := as.pvzRepo.GetPVZByID(ctx, parsedPVZID)
+	if err != nil {
+		return nil, err
+	}
+	if pvz == nil {
+		return nil, ErrPVZNotFound
+	}
+
+	user, err := as.userRepo.GetUserByID(ctx, parsedUserID)
+	if err != nil {
+		return nil, err
+	}
+	if !slices.Contains(PVZScopedRoles, user.Role) || user.DeactivatedAt != nil {
+		return nil, ErrNotPVZEmployee
+	}
+
+	assignment = &models.PVZAssignment{
+		PVZID:      parsedPVZID,
+		UserID:     parsedUserID,
+		Email:      user.Email,
+		AssignedAt: time.Now(),
+	}
+	if actor, ok := reqctx.UserFromContext(ctx); ok {
+		assignment.AssignedBy = &actor.ID
+	}
+
+	err = as.txManager.WithTx(ctx, func(ctx context.Context) error {
+		if err := as.assignmentRepo.AssignEmployee(ctx, assignment); err != nil {
+			return err
+		}
+		return as.audit.success(ctx, models.AuditActionPVZAssign, target)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return assignment, nil
+}
+
+func (as *pvzAssignmentService) UnassignEmployee(ctx context.Context, pvzID, userID string) (err error) {
+	var target models.AuditTarget
+	defer func() {
+		if err != nil {
+			as.audit.failure(ctx, models.AuditActionPVZUnassign, target, err)
+		}
+	}()
+
+	parsedPVZID, parsedUserID, err := parseAssignmentIDs(pvzID, userID)
+	if err != nil {
+		return err
+	}
+	target.PVZID, target.UserID = &parsedPVZID, &parsedUserID
+
+	return as.txManager.WithTx(ctx, func(ctx context.Context) error {
+		if err := as.assignmentRepo.UnassignEmployee(ctx, parsedPVZID, parsedUserID); err != nil {
+			return err
+		}
+		return as.audit.success(ctx, models.AuditActionPVZUnassign, target)
+	})
+}
+
+func parseAssignmentIDs(pvzID, userID string) (uuid.UUID, uuid.UUID, error) {
+	parsedPVZID, err := uuid.Parse(pvzID)
+	if err != nil {
+		return uuid.Nil, uuid.Nil, apperrors.Validation("неверный формат pvz_id")
+	}
+	parsedUserID, err := uuid.Parse(userID)
+	if err != nil {
+		return uuid.Nil, uuid.Nil, apperrors.Validation("неверный формат id пользователя")
+	}
+	return parsedPVZID, parsedUserID, nil
+}
+
+// pvzAccess проверяет, что сотрудник ПВЗ закреплен за пунктом, с приемками и товарами которого работает
+type pvzAccess struct {
+	repo repos.PVZAssignmentRepo
+	// allowDummyUsers пропускает тестовых пользователей из /dummyLogin; включается только в development
+	allowDummyUsers bool
+}
+
+// check пропускает действие, если пользователь закреплен за ПВЗ или его роль не привязана к пунктам.
+// Роль берется из контекста запроса; если ее там нет, пользователь проверяется как сотрудник ПВЗ
+func (pa pvzAccess) check(ctx context.Context, pvzID, userID uuid.UUID) error {
+	if user, ok := reqctx.UserFromContext(ctx); ok && !slices.Contains(PVZScopedRoles, user.Role) {
+		return nil
+	}
+	// тестовые пользователи из /dummyLogin не хранятся в базе, поэтому закрепить их нельзя
+	if pa.allowDummyUsers && isDummyUser(userID) {
+		return nil
+	}
+
+	assigned, err := pa.repo.IsAssigned(ctx, pvzID, userID)
+	if err != nil {
+		return err
+	}
+	if !assigned {
+		return ErrPVZNotAssigned
+	}
+	return nil
+}
+
+func isDummyUser(userID uuid.UUID) bool {
+	for _, role := range DummyRoles {
+		if userID == DummyUser(role).ID {
+			return true
+		}
+	}
+	return false
+}
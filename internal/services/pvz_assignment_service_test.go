@@ -0,0 +1,221 @@
+package services_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/forzeyy/avito-internship-spring-service/internal/models"
+	"github.com/forzeyy/avito-internship-spring-service/internal/repos"
+	"github.com/forzeyy/avito-internship-spring-service/internal/reqctx"
+	"github.com/forzeyy/avito-internship-spring-service/internal/services"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// fakeAssignmentRepo хранит закрепления в памяти; при everyone любой пользователь закреплен за любым ПВЗ
+type fakeAssignmentRepo struct {
+	everyone    bool
+	assignments []models.PVZAssignment
+}
+
+func assignedEverywhere() *fakeAssignmentRepo {
+	return &fakeAssignmentRepo{everyone: true}
+}
+
+func (f *fakeAssignmentRepo) AssignEmployee(ctx context.Context, assignment *models.PVZAssignment) error {
+	f.assignments = append(f.assignments, *assignment)
+	return nil
+}
+
+func (f *fakeAssignmentRepo) UnassignEmployee(ctx context.Context, pvzID, userID uuid.UUID) error {
+	for i, assignment := range f.assignments {
+		if assignment.PVZID == pvzID && assignment.UserID == userID {
+			f.assignments = append(f.assignments[:i], f.assignments[i+1:]...)
+			return nil
+		}
+	}
+	return repos.ErrAssignmentNotFound
+}
+
+func (f *fakeAssignmentRepo) GetAssignments(ctx context.Context, pvzID uuid.UUID) ([]models.PVZAssignment, error) {
+	var result []models.PVZAssignment
+	for _, assignment := range f.assignments {
+		if assignment.PVZID == pvzID {
+			result = append(result, assignment)
+		}
+	}
+	return result, nil
+}
+
+func (f *fakeAssignmentRepo) IsAssigned(ctx context.Context, pvzID, userID uuid.UUID) (bool, error) {
+	if f.everyone {
+		return true, nil
+	}
+	for _, assignment := range f.assignments {
+		if assignment.PVZID == pvzID && assignment.UserID == userID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// AssignEmployee
+func TestAssignEmployee_Success(t *testing.T) {
+	mockPVZ := new(mockPVZRepo)
+	mockUsers := new(mockUserRepo)
+	assignments := &fakeAssignmentRepo{}
+	audit := &fakeAuditRepo{}
+	svc := services.NewPVZAssignmentService(assignments, mockPVZ, mockUsers, fakeTxManager{}, audit)
+
+	pvzID, moderatorID := uuid.New(), uuid.New()
+	user := &models.User{ID: uuid.New(), Email: "worker@mail.ru", Role: "employee"}
+	mockPVZ.On("GetPVZByID", mock.Anything, pvzID).Return(&models.PVZ{ID: pvzID}, nil)
+	mockUsers.On("GetUserByID", mock.Anything, user.ID).Return(user, nil)
+
+	ctx := reqctx.WithUser(context.Background(), reqctx.User{ID: moderatorID, Role: "moderator"})
+	assignment, err := svc.AssignEmployee(ctx, pvzID.String(), user.ID.String())
+
+	assert.NoError(t, err)
+	assert.Equal(t, "worker@mail.ru", assignment.Email)
+	assert.Equal(t, &moderatorID, assignment.AssignedBy)
+	assert.Len(t, assignments.assignments, 1)
+	if assert.Len(t, audit.entries, 1) {
+		assert.Equal(t, models.AuditActionPVZAssign, audit.entries[0].Action)
+		assert.Equal(t, &pvzID, audit.entries[0].Target.PVZID)
+		assert.Equal(t, &user.ID, audit.entries[0].Target.UserID)
+	}
+}
+
+func TestAssignEmployee_PVZNotFound(t *testing.T) {
+	mockPVZ := new(mockPVZRepo)
+	assignments := &fakeAssignmentRepo{}
+	svc := services.NewPVZAssignmentService(assignments, mockPVZ, new(mockUserRepo), fakeTxManager{}, &fakeAuditRepo{})
+
+	pvzID := uuid.New()
+	mockPVZ.On("GetPVZByID", mock.Anything, pvzID).Return(nil, nil)
+
+	assignment, err := svc.AssignEmployee(context.Background(), pvzID.String(), uuid.NewString())
+	assert.Nil(t, assignment)
+	assert.ErrorIs(t, err, services.ErrPVZNotFound)
+	assert.Empty(t, assignments.assignments)
+}
+
+func TestAssignEmployee_NotAnActiveEmployee(t *testing.T) {
+	deactivatedAt := time.Now()
+	tests := []struct {
+		name string
+		user *models.User
+	}{
+		{name: "администратор", user: &models.User{ID: uuid.New(), Role: models.RoleAdmin}},
+		{name: "модератор", user: &models.User{ID: uuid.New(), Role: "moderator"}},
+		{name: "деактивированный сотрудник", user: &models.User{ID: uuid.New(), Role: models.RoleEmployee, DeactivatedAt: &deactivatedAt}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockPVZ := new(mockPVZRepo)
+			mockUsers := new(mockUserRepo)
+			assignments := &fakeAssignmentRepo{}
+			svc := services.NewPVZAssignmentService(assignments, mockPVZ, mockUsers, fakeTxManager{}, &fakeAuditRepo{})
+
+			pvzID := uuid.New()
+			mockPVZ.On("GetPVZByID", mock.Anything, pvzID).Return(&models.PVZ{ID: pvzID}, nil)
+			mockUsers.On("GetUserByID", mock.Anything, tt.user.ID).Return(tt.user, nil)
+
+			assignment, err := svc.AssignEmployee(context.Background(), pvzID.String(), tt.user.ID.String())
+			assert.Nil(t, assignment)
+			assert.ErrorIs(t, err, services.ErrNotPVZEmployee)
+			assert.Empty(t, assignments.assignments)
+		})
+	}
+}
+
+// UnassignEmployee
+func TestUnassignEmployee_NotAssigned(t *testing.T) {
+	svc := services.NewPVZAssignmentService(&fakeAssignmentRepo{}, new(mockPVZRepo), new(mockUserRepo), fakeTxManager{}, &fakeAuditRepo{})
+
+	err := svc.UnassignEmployee(context.Background(), uuid.NewString(), uuid.NewString())
+	assert.ErrorIs(t, err, repos.ErrAssignmentNotFound)
+}
+
+// проверка закрепления в приемках и товарах
+func TestCreateReception_NotAssigned(t *testing.T) {
+	mockRepo := new(mockReceptionRepo)
+	pvzID := uuid.New()
+	assignments := &fakeAssignmentRepo{assignments: []models.PVZAssignment{{PVZID: uuid.New(), UserID: testUserID}}}
+	service := services.NewReceptionService(mockRepo, nil, assignments, fakeTxManager{}, &fakeAuditRepo{}, false)
+
+	reception, err := service.CreateReception(context.Background(), pvzID.String(), testUserID.String())
+
+	assert.Nil(t, reception)
+	assert.ErrorIs(t, err, services.ErrPVZNotAssigned)
+	mockRepo.AssertNotCalled(t, "CreateReception", mock.Anything, mock.Anything)
+}
+
+func TestDeleteLastProduct_NotAssigned(t *testing.T) {
+	mockRec := new(mockReceptionRepo)
+	svc := services.NewProductService(new(mockProductRepo), mockRec, &fakeAssignmentRepo{}, fakeTxManager{}, &fakeAuditRepo{}, models.BarcodeScopeReception, false)
+
+	err := svc.DeleteLastProduct(context.Background(), uuid.NewString(), testUserID.String())
+
+	assert.ErrorIs(t, err, services.ErrPVZNotAssigned)
+	mockRec.AssertNotCalled(t, "LockLastOpenReception", mock.Anything, mock.Anything)
+}
+
+func TestAddProduct_DummyEmployeeSkipsAssignment(t *testing.T) {
+	mockProd := new(mockProductRepo)
+	mockRec := new(mockReceptionRepo)
+	pvzID := uuid.New()
+
+	reception := &models.Reception{ID: uuid.New(), PVZID: pvzID, Status: "in_progress"}
+	mockRec.On("LockLastOpenReception", mock.Anything, pvzID).Return(reception, nil)
+	mockProd.On("AddProduct", mock.Anything, mock.AnythingOfType("*models.Product")).Return(nil)
+
+	svc := services.NewProductService(mockProd, mockRec, &fakeAssignmentRepo{}, fakeTxManager{}, &fakeAuditRepo{}, models.BarcodeScopeReception, true)
+
+	product, err := svc.AddProduct(context.Background(), "обувь", "", pvzID.String(), services.DummyUser("employee").ID.String())
+
+	assert.NoError(t, err)
+	assert.Equal(t, reception.ID, product.ReceptionID)
+}
+
+func TestAddProduct_DummyEmployeeOutsideDevelopment(t *testing.T) {
+	mockRec := new(mockReceptionRepo)
+	svc := services.NewProductService(new(mockProductRepo), mockRec, &fakeAssignmentRepo{}, fakeTxManager{}, &fakeAuditRepo{}, models.BarcodeScopeReception, false)
+
+	_, err := svc.AddProduct(context.Background(), "обувь", "", uuid.NewString(), services.DummyUser("employee").ID.String())
+
+	assert.ErrorIs(t, err, services.ErrPVZNotAssigned)
+	mockRec.AssertNotCalled(t, "LockLastOpenReception", mock.Anything, mock.Anything)
+}
+
+func TestCreateReception_UnscopedRolesSkipAssignment(t *testing.T) {
+	for _, role := range []string{"moderator", models.RoleAdmin} {
+		t.Run(role, func(t *testing.T) {
+			mockRepo := new(mockReceptionRepo)
+			pvzID := uuid.New()
+			mockRepo.On("GetLastOpenReception", mock.Anything, pvzID).Return(nil, nil)
+			mockRepo.On("CreateReception", mock.Anything, mock.Anything).Return(nil)
+
+			service := services.NewReceptionService(mockRepo, nil, &fakeAssignmentRepo{}, fakeTxManager{}, &fakeAuditRepo{}, false)
+
+			ctx := reqctx.WithUser(context.Background(), reqctx.User{ID: testUserID, Role: role})
+			reception, err := service.CreateReception(ctx, pvzID.String(), testUserID.String())
+
+			assert.NoError(t, err)
+			assert.Equal(t, pvzID, reception.PVZID)
+		})
+	}
+}
+
+func TestCreateReception_SeniorEmployeeNotAssigned(t *testing.T) {
+	mockRepo := new(mockReceptionRepo)
+	service := services.NewReceptionService(mockRepo, nil, &fakeAssignmentRepo{}, fakeTxManager{}, &fakeAuditRepo{}, false)
+
+	ctx := reqctx.WithUser(context.Background(), reqctx.User{ID: testUserID, Role: models.RoleSeniorEmployee})
+	_, err := service.CreateReception(ctx, uuid.NewString(), testUserID.String())
+
+	assert.ErrorIs(t, err, services.ErrPVZNotAssigned)
+}
@@ -2,9 +2,12 @@ package services
 
 import (
 	"context"
-	"errors"
+	"encoding/base64"
+	"strings"
 	"time"
 
+	"github.com/forzeyy/avito-internship-spring-service/internal/apperrors"
+	"github.com/forzeyy/avito-internship-spring-service/internal/metrics"
 	"github.com/forzeyy/avito-internship-spring-service/internal/models"
 	"github.com/forzeyy/avito-internship-spring-service/internal/repos"
 	"github.com/google/uuid"
@@ -12,36 +15,223 @@ import (
 
 type PVZService interface {
 	CreatePVZ(ctx context.Context, city string) (*models.PVZ, error)
-	GetPVZs(ctx context.Context, startDate, endDate *time.Time, page, limit int) ([]models.PVZ, error)
+	GetPVZs(ctx context.Context, startDate, endDate *time.Time, page, limit int) ([]models.PVZWithReceptions, error)
+	GetPVZsByCursor(ctx context.Context, startDate, endDate *time.Time, cursor string, limit int) ([]models.PVZWithReceptions, string, error)
+	ListPVZs(ctx context.Context, cursor string, limit int) ([]models.PVZ, string, error)
+	GetPVZDetails(ctx context.Context, pvzID string, page, limit int) (*models.PVZDetails, error)
 }
 
+var ErrPVZNotFound = apperrors.NotFound("ПВЗ не найден")
+
 type pvzService struct {
-	pvzRepo repos.PVZRepo
+	pvzRepo   repos.PVZRepo
+	recRepo   repos.ReceptionRepo
+	prodRepo  repos.ProductRepo
+	txManager repos.TxManager
+	audit     auditLog
 }
 
-func NewPVZService(pvzRepo repos.PVZRepo) PVZService {
-	return &pvzService{pvzRepo: pvzRepo}
+func NewPVZService(pvzRepo repos.PVZRepo, recRepo repos.ReceptionRepo, prodRepo repos.ProductRepo, txManager repos.TxManager, auditRepo repos.AuditRepo) PVZService {
+	return &pvzService{
+		pvzRepo:   pvzRepo,
+		recRepo:   recRepo,
+		prodRepo:  prodRepo,
+		txManager: txManager,
+		audit:     auditLog{repo: auditRepo},
+	}
 }
 
-func (ps *pvzService) CreatePVZ(ctx context.Context, city string) (*models.PVZ, error) {
+func (ps *pvzService) CreatePVZ(ctx context.Context, city string) (pvz *models.PVZ, err error) {
+	var target models.AuditTarget
+	defer func() {
+		if err != nil {
+			ps.audit.failure(ctx, models.AuditActionPVZCreate, target, err)
+		}
+	}()
+
 	if city != "Москва" && city != "Санкт-Петербург" && city != "Казань" {
-		return nil, errors.New("неверный город")
+		return nil, apperrors.Validation("неверный город")
 	}
 
-	pvz := &models.PVZ{
+	pvz = &models.PVZ{
 		ID:      uuid.New(),
 		City:    city,
 		RegDate: time.Now(),
 	}
+	target.PVZID = &pvz.ID
 
-	err := ps.pvzRepo.CreatePVZ(ctx, pvz)
+	err = ps.txManager.WithTx(ctx, func(ctx context.Context) error {
+		if err := ps.pvzRepo.CreatePVZ(ctx, pvz); err != nil {
+			return err
+		}
+		return ps.audit.success(ctx, models.AuditActionPVZCreate, target)
+	})
 	if err != nil {
 		return nil, err
 	}
+	metrics.PVZCreatedTotal.Inc()
 
 	return pvz, nil
 }
 
-func (ps *pvzService) GetPVZs(ctx context.Context, startDate, endDate *time.Time, page, limit int) ([]models.PVZ, error) {
-	return ps.pvzRepo.GetPVZs(ctx, startDate, endDate, page, limit)
+func (ps *pvzService) GetPVZs(ctx context.Context, startDate, endDate *time.Time, page, limit int) ([]models.PVZWithReceptions, error) {
+	pvzs, err := ps.pvzRepo.GetPVZs(ctx, startDate, endDate, page, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return ps.withReceptions(ctx, pvzs, startDate, endDate)
+}
+
+// GetPVZsByCursor возвращает страницу ПВЗ после курсора и курсор следующей страницы.
+// Пустой курсор означает первую страницу, пустой следующий курсор - последнюю.
+func (ps *pvzService) GetPVZsByCursor(ctx context.Context, startDate, endDate *time.Time, cursor string, limit int) ([]models.PVZWithReceptions, string, error) {
+	pvzs, nextCursor, err := ps.pvzPage(ctx, startDate, endDate, cursor, limit)
+	if err != nil {
+		return nil, "", err
+	}
+
+	result, err := ps.withReceptions(ctx, pvzs, startDate, endDate)
+	if err != nil {
+		return nil, "", err
+	}
+	return result, nextCursor, nil
+}
+
+// ListPVZs возвращает страницу ПВЗ без приемок в том же порядке и с теми же курсорами, что и GetPVZsByCursor
+func (ps *pvzService) ListPVZs(ctx context.Context, cursor string, limit int) ([]models.PVZ, string, error) {
+	return ps.pvzPage(ctx, nil, nil, cursor, limit)
+}
+
+func (ps *pvzService) pvzPage(ctx context.Context, startDate, endDate *time.Time, cursor string, limit int) ([]models.PVZ, string, error) {
+	var after *models.PVZCursor
+	if cursor != "" {
+		decoded, err := decodePVZCursor(cursor)
+		if err != nil {
+			return nil, "", err
+		}
+		after = decoded
+	}
+
+	pvzs, err := ps.pvzRepo.GetPVZsAfter(ctx, startDate, endDate, after, limit)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var nextCursor string
+	if len(pvzs) == limit {
+		last := pvzs[len(pvzs)-1]
+		nextCursor = encodePVZCursor(&models.PVZCursor{RegDate: last.RegDate, ID: last.ID})
+	}
+	return pvzs, nextCursor, nil
+}
+
+func (ps *pvzService) withReceptions(ctx context.Context, pvzs []models.PVZ, startDate, endDate *time.Time) ([]models.PVZWithReceptions, error) {
+	result := make([]models.PVZWithReceptions, 0, len(pvzs))
+	if len(pvzs) == 0 {
+		return result, nil
+	}
+
+	// приемки и товары выбираются одним запросом на всю страницу, а не на каждый ПВЗ
+	pvzIDs := make([]uuid.UUID, 0, len(pvzs))
+	for _, pvz := range pvzs {
+		pvzIDs = append(pvzIDs, pvz.ID)
+	}
+
+	receptions, err := ps.recRepo.GetReceptionsByPVZIDs(ctx, pvzIDs, startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+
+	productsByReception := make(map[uuid.UUID][]models.Product)
+	if len(receptions) > 0 {
+		receptionIDs := make([]uuid.UUID, 0, len(receptions))
+		for _, reception := range receptions {
+			receptionIDs = append(receptionIDs, reception.ID)
+		}
+
+		products, err := ps.prodRepo.GetProductsByReceptionIDs(ctx, receptionIDs)
+		if err != nil {
+			return nil, err
+		}
+		for _, product := range products {
+			productsByReception[product.ReceptionID] = append(productsByReception[product.ReceptionID], product)
+		}
+	}
+
+	receptionsByPVZ := make(map[uuid.UUID][]models.ReceptionWithProducts)
+	for _, reception := range receptions {
+		receptionsByPVZ[reception.PVZID] = append(receptionsByPVZ[reception.PVZID], models.ReceptionWithProducts{
+			Reception: reception,
+			Products:  productsByReception[reception.ID],
+		})
+	}
+
+	for _, pvz := range pvzs {
+		result = append(result, models.PVZWithReceptions{
+			PVZ:        pvz,
+			Receptions: receptionsByPVZ[pvz.ID],
+		})
+	}
+	return result, nil
+}
+
+func (ps *pvzService) GetPVZDetails(ctx context.Context, pvzID string, page, limit int) (*models.PVZDetails, error) {
+	parsedPVZID, err := uuid.Parse(pvzID)
+	if err != nil {
+		return nil, apperrors.Validation("неверный формат pvz_id")
+	}
+
+	pvz, err := ps.pvzRepo.GetPVZByID(ctx, parsedPVZID)
+	if err != nil {
+		return nil, err
+	}
+	if pvz == nil {
+		return nil, ErrPVZNotFound
+	}
+
+	openReception, err := ps.recRepo.GetLastOpenReception(ctx, parsedPVZID)
+	if err != nil {
+		return nil, err
+	}
+
+	history, err := ps.recRepo.GetClosedReceptions(ctx, parsedPVZID, page, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.PVZDetails{
+		PVZ:           *pvz,
+		OpenReception: openReception,
+		History:       history,
+	}, nil
+}
+
+func encodePVZCursor(cursor *models.PVZCursor) string {
+	raw := cursor.RegDate.Format(time.RFC3339Nano) + "|" + cursor.ID.String()
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func decodePVZCursor(cursor string) (*models.PVZCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, apperrors.Validation("неверный курсор")
+	}
+
+	regDate, id, ok := strings.Cut(string(raw), "|")
+	if !ok {
+		return nil, apperrors.Validation("неверный курсор")
+	}
+
+	parsedRegDate, err := time.Parse(time.RFC3339Nano, regDate)
+	if err != nil {
+		return nil, apperrors.Validation("неверный курсор")
+	}
+
+	parsedID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, apperrors.Validation("неверный курсор")
+	}
+
+	return &models.PVZCursor{RegDate: parsedRegDate, ID: parsedID}, nil
 }
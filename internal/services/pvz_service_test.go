@@ -22,16 +22,32 @@ func (m *mockPVZRepo) CreatePVZ(ctx context.Context, pvz *models.PVZ) error {
 	return args.Error(0)
 }
 
+func (m *mockPVZRepo) GetPVZByID(ctx context.Context, id uuid.UUID) (*models.PVZ, error) {
+	args := m.Called(ctx, id)
+	if pvz, ok := args.Get(0).(*models.PVZ); ok {
+		return pvz, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
 func (m *mockPVZRepo) GetPVZs(ctx context.Context, startDate, endDate *time.Time, page, limit int) ([]models.PVZ, error) {
 	args := m.Called(ctx, startDate, endDate, page, limit)
 	return args.Get(0).([]models.PVZ), args.Error(1)
 }
 
+func (m *mockPVZRepo) GetPVZsAfter(ctx context.Context, startDate, endDate *time.Time, after *models.PVZCursor, limit int) ([]models.PVZ, error) {
+	args := m.Called(ctx, startDate, endDate, after, limit)
+	if pvzs, ok := args.Get(0).([]models.PVZ); ok {
+		return pvzs, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
 // CreatePVZ
 func TestCreatePVZ_Success(t *testing.T) {
 	ctx := context.Background()
 	mockRepo := new(mockPVZRepo)
-	service := services.NewPVZService(mockRepo)
+	service := services.NewPVZService(mockRepo, nil, nil, fakeTxManager{}, &fakeAuditRepo{})
 
 	city := "Москва"
 
@@ -48,7 +64,7 @@ func TestCreatePVZ_Success(t *testing.T) {
 func TestCreatePVZ_InvalidCity(t *testing.T) {
 	ctx := context.Background()
 	mockRepo := new(mockPVZRepo)
-	service := services.NewPVZService(mockRepo)
+	service := services.NewPVZService(mockRepo, nil, nil, fakeTxManager{}, &fakeAuditRepo{})
 
 	pvz, err := service.CreatePVZ(ctx, "Париж")
 
@@ -60,7 +76,7 @@ func TestCreatePVZ_InvalidCity(t *testing.T) {
 func TestCreatePVZ_RepoError(t *testing.T) {
 	ctx := context.Background()
 	mockRepo := new(mockPVZRepo)
-	service := services.NewPVZService(mockRepo)
+	service := services.NewPVZService(mockRepo, nil, nil, fakeTxManager{}, &fakeAuditRepo{})
 
 	mockRepo.On("CreatePVZ", ctx, mock.AnythingOfType("*models.PVZ")).Return(errors.New("db error"))
 
@@ -75,19 +91,207 @@ func TestCreatePVZ_RepoError(t *testing.T) {
 func TestGetPVZs_Success(t *testing.T) {
 	ctx := context.Background()
 	mockRepo := new(mockPVZRepo)
-	service := services.NewPVZService(mockRepo)
+	mockRec := new(mockReceptionRepo)
+	mockProd := new(mockProductRepo)
+	service := services.NewPVZService(mockRepo, mockRec, mockProd, fakeTxManager{}, &fakeAuditRepo{})
 
 	now := time.Now()
-	expected := []models.PVZ{
+	pvzs := []models.PVZ{
 		{ID: uuid.New(), City: "Москва", RegDate: now},
 		{ID: uuid.New(), City: "Казань", RegDate: now},
 	}
+	reception := models.Reception{ID: uuid.New(), PVZID: pvzs[0].ID, Status: "in_progress", DateTime: now}
+	product := models.Product{ID: uuid.New(), Type: "обувь", ReceptionID: reception.ID, DateTime: now}
 
-	mockRepo.On("GetPVZs", ctx, &now, &now, 1, 10).Return(expected, nil)
+	mockRepo.On("GetPVZs", ctx, &now, &now, 1, 10).Return(pvzs, nil)
+	mockRec.On("GetReceptionsByPVZIDs", ctx, []uuid.UUID{pvzs[0].ID, pvzs[1].ID}, &now, &now).
+		Return([]models.Reception{reception}, nil)
+	mockProd.On("GetProductsByReceptionIDs", ctx, []uuid.UUID{reception.ID}).
+		Return([]models.Product{product}, nil)
 
 	result, err := service.GetPVZs(ctx, &now, &now, 1, 10)
 
 	assert.NoError(t, err)
-	assert.Equal(t, expected, result)
+	assert.Len(t, result, 2)
+	assert.Equal(t, pvzs[0], result[0].PVZ)
+	assert.Len(t, result[0].Receptions, 1)
+	assert.Equal(t, reception, result[0].Receptions[0].Reception)
+	assert.Equal(t, []models.Product{product}, result[0].Receptions[0].Products)
+	assert.Empty(t, result[1].Receptions)
+	mockRepo.AssertExpectations(t)
+	mockRec.AssertExpectations(t)
+	mockProd.AssertExpectations(t)
+}
+
+func TestGetPVZs_Empty(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := new(mockPVZRepo)
+	mockRec := new(mockReceptionRepo)
+	mockProd := new(mockProductRepo)
+	service := services.NewPVZService(mockRepo, mockRec, mockProd, fakeTxManager{}, &fakeAuditRepo{})
+
+	mockRepo.On("GetPVZs", ctx, (*time.Time)(nil), (*time.Time)(nil), 1, 10).Return([]models.PVZ{}, nil)
+
+	result, err := service.GetPVZs(ctx, nil, nil, 1, 10)
+
+	assert.NoError(t, err)
+	assert.Empty(t, result)
+	mockRec.AssertNotCalled(t, "GetReceptionsByPVZIDs")
+	mockProd.AssertNotCalled(t, "GetProductsByReceptionIDs")
+}
+
+func TestGetPVZs_ReceptionsError(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := new(mockPVZRepo)
+	mockRec := new(mockReceptionRepo)
+	mockProd := new(mockProductRepo)
+	service := services.NewPVZService(mockRepo, mockRec, mockProd, fakeTxManager{}, &fakeAuditRepo{})
+
+	pvzs := []models.PVZ{{ID: uuid.New(), City: "Москва", RegDate: time.Now()}}
+
+	mockRepo.On("GetPVZs", ctx, (*time.Time)(nil), (*time.Time)(nil), 1, 10).Return(pvzs, nil)
+	mockRec.On("GetReceptionsByPVZIDs", ctx, mock.Anything, (*time.Time)(nil), (*time.Time)(nil)).
+		Return(nil, errors.New("db error"))
+
+	result, err := service.GetPVZs(ctx, nil, nil, 1, 10)
+
+	assert.Nil(t, result)
+	assert.EqualError(t, err, "db error")
+	mockProd.AssertNotCalled(t, "GetProductsByReceptionIDs")
+}
+
+// ListPVZs
+func TestListPVZs_Pages(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := new(mockPVZRepo)
+	service := services.NewPVZService(mockRepo, nil, nil, fakeTxManager{}, &fakeAuditRepo{})
+
+	now := time.Now().UTC()
+	first := models.PVZ{ID: uuid.New(), City: "Москва", RegDate: now}
+	second := models.PVZ{ID: uuid.New(), City: "Казань", RegDate: now.Add(-time.Hour)}
+
+	mockRepo.On("GetPVZsAfter", ctx, (*time.Time)(nil), (*time.Time)(nil), (*models.PVZCursor)(nil), 1).
+		Return([]models.PVZ{first}, nil)
+	mockRepo.On("GetPVZsAfter", ctx, (*time.Time)(nil), (*time.Time)(nil), &models.PVZCursor{RegDate: first.RegDate, ID: first.ID}, 1).
+		Return([]models.PVZ{second}, nil)
+
+	page, cursor, err := service.ListPVZs(ctx, "", 1)
+	assert.NoError(t, err)
+	assert.Equal(t, []models.PVZ{first}, page)
+	assert.NotEmpty(t, cursor)
+
+	page, _, err = service.ListPVZs(ctx, cursor, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, []models.PVZ{second}, page)
 	mockRepo.AssertExpectations(t)
 }
+
+// GetPVZsByCursor
+func TestGetPVZsByCursor_Pages(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := new(mockPVZRepo)
+	mockRec := new(mockReceptionRepo)
+	service := services.NewPVZService(mockRepo, mockRec, nil, fakeTxManager{}, &fakeAuditRepo{})
+
+	now := time.Now().UTC()
+	first := models.PVZ{ID: uuid.New(), City: "Москва", RegDate: now}
+	second := models.PVZ{ID: uuid.New(), City: "Казань", RegDate: now.Add(-time.Hour)}
+
+	mockRepo.On("GetPVZsAfter", ctx, (*time.Time)(nil), (*time.Time)(nil), (*models.PVZCursor)(nil), 1).
+		Return([]models.PVZ{first}, nil)
+	mockRepo.On("GetPVZsAfter", ctx, (*time.Time)(nil), (*time.Time)(nil), &models.PVZCursor{RegDate: first.RegDate, ID: first.ID}, 1).
+		Return([]models.PVZ{second}, nil)
+	mockRec.On("GetReceptionsByPVZIDs", ctx, mock.Anything, (*time.Time)(nil), (*time.Time)(nil)).
+		Return([]models.Reception{}, nil)
+
+	page, nextCursor, err := service.GetPVZsByCursor(ctx, nil, nil, "", 1)
+	assert.NoError(t, err)
+	assert.Len(t, page, 1)
+	assert.Equal(t, first, page[0].PVZ)
+	assert.NotEmpty(t, nextCursor)
+
+	page, nextCursor, err = service.GetPVZsByCursor(ctx, nil, nil, nextCursor, 1)
+	assert.NoError(t, err)
+	assert.Len(t, page, 1)
+	assert.Equal(t, second, page[0].PVZ)
+	assert.NotEmpty(t, nextCursor)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestGetPVZsByCursor_LastPage(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := new(mockPVZRepo)
+	service := services.NewPVZService(mockRepo, nil, nil, fakeTxManager{}, &fakeAuditRepo{})
+
+	mockRepo.On("GetPVZsAfter", ctx, (*time.Time)(nil), (*time.Time)(nil), (*models.PVZCursor)(nil), 10).
+		Return([]models.PVZ{}, nil)
+
+	page, nextCursor, err := service.GetPVZsByCursor(ctx, nil, nil, "", 10)
+	assert.NoError(t, err)
+	assert.Empty(t, page)
+	assert.Empty(t, nextCursor)
+}
+
+func TestGetPVZsByCursor_InvalidCursor(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := new(mockPVZRepo)
+	service := services.NewPVZService(mockRepo, nil, nil, fakeTxManager{}, &fakeAuditRepo{})
+
+	page, nextCursor, err := service.GetPVZsByCursor(ctx, nil, nil, "не-курсор", 10)
+	assert.Nil(t, page)
+	assert.Empty(t, nextCursor)
+	assert.EqualError(t, err, "неверный курсор")
+	mockRepo.AssertNotCalled(t, "GetPVZsAfter")
+}
+
+// GetPVZDetails
+func TestGetPVZDetails_Success(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := new(mockPVZRepo)
+	mockRec := new(mockReceptionRepo)
+	service := services.NewPVZService(mockRepo, mockRec, nil, fakeTxManager{}, &fakeAuditRepo{})
+
+	pvz := &models.PVZ{ID: uuid.New(), City: "Москва", RegDate: time.Now()}
+	open := &models.Reception{ID: uuid.New(), PVZID: pvz.ID, Status: "in_progress"}
+	history := []models.ReceptionWithProductCount{
+		{Reception: models.Reception{ID: uuid.New(), PVZID: pvz.ID, Status: "close"}, ProductCount: 3},
+	}
+
+	mockRepo.On("GetPVZByID", ctx, pvz.ID).Return(pvz, nil)
+	mockRec.On("GetLastOpenReception", ctx, pvz.ID).Return(open, nil)
+	mockRec.On("GetClosedReceptions", ctx, pvz.ID, 1, 10).Return(history, nil)
+
+	details, err := service.GetPVZDetails(ctx, pvz.ID.String(), 1, 10)
+
+	assert.NoError(t, err)
+	assert.Equal(t, *pvz, details.PVZ)
+	assert.Equal(t, open, details.OpenReception)
+	assert.Equal(t, history, details.History)
+	mockRepo.AssertExpectations(t)
+	mockRec.AssertExpectations(t)
+}
+
+func TestGetPVZDetails_NotFound(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := new(mockPVZRepo)
+	mockRec := new(mockReceptionRepo)
+	service := services.NewPVZService(mockRepo, mockRec, nil, fakeTxManager{}, &fakeAuditRepo{})
+
+	pvzID := uuid.New()
+	mockRepo.On("GetPVZByID", ctx, pvzID).Return(nil, nil)
+
+	details, err := service.GetPVZDetails(ctx, pvzID.String(), 1, 10)
+
+	assert.Nil(t, details)
+	assert.ErrorIs(t, err, services.ErrPVZNotFound)
+	mockRec.AssertNotCalled(t, "GetClosedReceptions")
+}
+
+func TestGetPVZDetails_InvalidUUID(t *testing.T) {
+	service := services.NewPVZService(nil, nil, nil, fakeTxManager{}, &fakeAuditRepo{})
+
+	details, err := service.GetPVZDetails(context.Background(), "invalid-uuid", 1, 10)
+
+	assert.Nil(t, details)
+	assert.EqualError(t, err, "неверный формат pvz_id")
+}
@@ -0,0 +1,141 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/forzeyy/avito-internship-spring-service/internal/apperrors"
+	"github.com/forzeyy/avito-internship-spring-service/internal/models"
+	"github.com/forzeyy/avito-internship-spring-service/internal/repos"
+)
+
+type RBACService interface {
+	HasPermission(ctx context.Context, role, permission string) (bool, error)
+	GetRoles(ctx context.Context) ([]models.Role, error)
+	GetPermissions(ctx context.Context) ([]models.Permission, error)
+	SetRolePermissions(ctx context.Context, role string, permissions []string) (*models.Role, error)
+}
+
+var ErrRoleNotFound = apperrors.NotFound("роль не найдена")
+
+// permissionCacheTTL ограничивает, как долго другие экземпляры сервиса видят старые права после изменения роли
+const permissionCacheTTL = 30 * time.Second
+
+type rbacService struct {
+	roleRepo  repos.RoleRepo
+	txManager repos.TxManager
+	audit     auditLog
+
+	// права проверяются на каждом запросе, поэтому таблица ролей держится в памяти
+	mu       sync.RWMutex
+	byRole   map[string]map[string]struct{}
+	loadedAt time.Time
+}
+
+func NewRBACService(roleRepo repos.RoleRepo, txManager repos.TxManager, auditRepo repos.AuditRepo) RBACService {
+	return &rbacService{
+		roleRepo:  roleRepo,
+		txManager: txManager,
+		audit:     auditLog{repo: auditRepo},
+	}
+}
+
+func (rs *rbacService) HasPermission(ctx context.Context, role, permission string) (bool, error) {
+	rs.mu.RLock()
+	byRole, fresh := rs.byRole, time.Since(rs.loadedAt) < permissionCacheTTL
+	rs.mu.RUnlock()
+
+	if byRole == nil || !fresh {
+		var err error
+		if byRole, err = rs.reloadPermissions(ctx); err != nil {
+			return false, err
+		}
+	}
+
+	_, ok := byRole[role][permission]
+	return ok, nil
+}
+
+func (rs *rbacService) reloadPermissions(ctx context.Context) (map[string]map[string]struct{}, error) {
+	roles, err := rs.roleRepo.GetRoles(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	byRole := make(map[string]map[string]struct{}, len(roles))
+	for _, role := range roles {
+		permissions := make(map[string]struct{}, len(role.Permissions))
+		for _, permission := range role.Permissions {
+			permissions[permission] = struct{}{}
+		}
+		byRole[role.Name] = permissions
+	}
+
+	rs.mu.Lock()
+	rs.byRole, rs.loadedAt = byRole, time.Now()
+	rs.mu.Unlock()
+
+	return byRole, nil
+}
+
+func (rs *rbacService) GetRoles(ctx context.Context) ([]models.Role, error) {
+	return rs.roleRepo.GetRoles(ctx)
+}
+
+func (rs *rbacService) GetPermissions(ctx context.Context) ([]models.Permission, error) {
+	return rs.roleRepo.GetPermissions(ctx)
+}
+
+func (rs *rbacService) SetRolePermissions(ctx context.Context, role string, permissions []string) (result *models.Role, err error) {
+	defer func() {
+		if err != nil {
+			rs.audit.failureWithDetails(ctx, models.AuditActionRoleUpdate, models.AuditTarget{Role: role},
+				map[string]any{"permissions": permissions}, err)
+		}
+	}()
+
+	known, err := rs.roleRepo.GetPermissions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, permission := range permissions {
+		if !slices.ContainsFunc(known, func(p models.Permission) bool { return p.Name == permission }) {
+			return nil, apperrors.Validation(fmt.Sprintf("неизвестное право %s", permission))
+		}
+	}
+	if role == models.RoleAdmin && !slices.Contains(permissions, models.PermissionRoleManage) {
+		return nil, apperrors.Conflict(fmt.Sprintf("нельзя отнять право %s у роли %s", models.PermissionRoleManage, models.RoleAdmin))
+	}
+
+	permissions = slices.Compact(slices.Sorted(slices.Values(permissions)))
+	err = rs.txManager.WithTx(ctx, func(ctx context.Context) error {
+		found, err := rs.roleRepo.GetRole(ctx, role)
+		if err != nil {
+			return err
+		}
+		if found == nil {
+			return ErrRoleNotFound
+		}
+		result = found
+
+		if err := rs.roleRepo.SetRolePermissions(ctx, role, permissions); err != nil {
+			return err
+		}
+		return rs.audit.successWithDetails(ctx, models.AuditActionRoleUpdate, models.AuditTarget{Role: role},
+			map[string]any{"permissions": permissions})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// на этом экземпляре новые права действуют сразу, на остальных - после истечения кэша
+	rs.mu.Lock()
+	rs.byRole = nil
+	rs.mu.Unlock()
+
+	result.Permissions = permissions
+	return result, nil
+}
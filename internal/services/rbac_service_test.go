@@ -0,0 +1,157 @@
+package services_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/forzeyy/avito-internship-spring-service/internal/models"
+	"github.com/forzeyy/avito-internship-spring-service/internal/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type mockRoleRepo struct {
+	mock.Mock
+}
+
+func (m *mockRoleRepo) GetRoles(ctx context.Context) ([]models.Role, error) {
+	args := m.Called(ctx)
+	if roles, ok := args.Get(0).([]models.Role); ok {
+		return roles, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *mockRoleRepo) GetRole(ctx context.Context, name string) (*models.Role, error) {
+	args := m.Called(ctx, name)
+	if role, ok := args.Get(0).(*models.Role); ok {
+		return role, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *mockRoleRepo) GetPermissions(ctx context.Context) ([]models.Permission, error) {
+	args := m.Called(ctx)
+	if permissions, ok := args.Get(0).([]models.Permission); ok {
+		return permissions, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *mockRoleRepo) SetRolePermissions(ctx context.Context, role string, permissions []string) error {
+	args := m.Called(ctx, role, permissions)
+	return args.Error(0)
+}
+
+var testPermissions = []models.Permission{
+	{Name: models.PermissionPVZRead},
+	{Name: models.PermissionPVZCreate},
+	{Name: models.PermissionRoleManage},
+}
+
+// HasPermission
+func TestHasPermission_CachesRoles(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := new(mockRoleRepo)
+	service := services.NewRBACService(mockRepo, fakeTxManager{}, &fakeAuditRepo{})
+
+	mockRepo.On("GetRoles", ctx).Return([]models.Role{
+		{Name: "auditor", Permissions: []string{models.PermissionPVZRead}},
+	}, nil).Once()
+
+	allowed, err := service.HasPermission(ctx, "auditor", models.PermissionPVZRead)
+	assert.NoError(t, err)
+	assert.True(t, allowed)
+
+	allowed, err = service.HasPermission(ctx, "auditor", models.PermissionPVZCreate)
+	assert.NoError(t, err)
+	assert.False(t, allowed)
+
+	allowed, err = service.HasPermission(ctx, "unknown", models.PermissionPVZRead)
+	assert.NoError(t, err)
+	assert.False(t, allowed)
+
+	mockRepo.AssertNumberOfCalls(t, "GetRoles", 1)
+}
+
+// SetRolePermissions
+func TestSetRolePermissions_Success(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := new(mockRoleRepo)
+	audit := &fakeAuditRepo{}
+	service := services.NewRBACService(mockRepo, fakeTxManager{}, audit)
+
+	mockRepo.On("GetRoles", ctx).Return([]models.Role{{Name: "auditor", Permissions: []string{models.PermissionPVZRead}}}, nil).Once()
+	allowed, _ := service.HasPermission(ctx, "auditor", models.PermissionPVZCreate)
+	assert.False(t, allowed)
+
+	mockRepo.On("GetPermissions", ctx).Return(testPermissions, nil)
+	mockRepo.On("GetRole", ctx, "auditor").Return(&models.Role{Name: "auditor", Description: "Аудитор"}, nil)
+	mockRepo.On("SetRolePermissions", ctx, "auditor", []string{models.PermissionPVZCreate, models.PermissionPVZRead}).Return(nil)
+
+	role, err := service.SetRolePermissions(ctx, "auditor", []string{models.PermissionPVZRead, models.PermissionPVZCreate, models.PermissionPVZRead})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{models.PermissionPVZCreate, models.PermissionPVZRead}, role.Permissions)
+	if assert.Len(t, audit.entries, 1) {
+		assert.Equal(t, models.AuditActionRoleUpdate, audit.entries[0].Action)
+		assert.Equal(t, models.AuditTarget{Role: "auditor"}, audit.entries[0].Target)
+		assert.Equal(t, map[string]any{"permissions": []string{models.PermissionPVZCreate, models.PermissionPVZRead}}, audit.entries[0].Details)
+	}
+
+	// кэш сбрасывается, и новые права читаются из базы
+	mockRepo.On("GetRoles", ctx).Return([]models.Role{{Name: "auditor", Permissions: role.Permissions}}, nil).Once()
+	allowed, err = service.HasPermission(ctx, "auditor", models.PermissionPVZCreate)
+	assert.NoError(t, err)
+	assert.True(t, allowed)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestSetRolePermissions_UnknownPermission(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := new(mockRoleRepo)
+	audit := &fakeAuditRepo{}
+	service := services.NewRBACService(mockRepo, fakeTxManager{}, audit)
+
+	mockRepo.On("GetPermissions", ctx).Return(testPermissions, nil)
+
+	role, err := service.SetRolePermissions(ctx, "auditor", []string{"pvz:destroy"})
+
+	assert.Nil(t, role)
+	assert.EqualError(t, err, "неизвестное право pvz:destroy")
+	mockRepo.AssertNotCalled(t, "SetRolePermissions")
+	if assert.Len(t, audit.entries, 1) {
+		assert.Equal(t, models.AuditOutcomeFailure, audit.entries[0].Outcome)
+		assert.Equal(t, models.AuditTarget{Role: "auditor"}, audit.entries[0].Target)
+		assert.Equal(t, map[string]any{"permissions": []string{"pvz:destroy"}}, audit.entries[0].Details)
+	}
+}
+
+func TestSetRolePermissions_AdminKeepsRoleManage(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := new(mockRoleRepo)
+	service := services.NewRBACService(mockRepo, fakeTxManager{}, &fakeAuditRepo{})
+
+	mockRepo.On("GetPermissions", ctx).Return(testPermissions, nil)
+
+	role, err := service.SetRolePermissions(ctx, models.RoleAdmin, []string{models.PermissionPVZRead})
+
+	assert.Nil(t, role)
+	assert.EqualError(t, err, "нельзя отнять право role:manage у роли admin")
+	mockRepo.AssertNotCalled(t, "SetRolePermissions")
+}
+
+func TestSetRolePermissions_RoleNotFound(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := new(mockRoleRepo)
+	service := services.NewRBACService(mockRepo, fakeTxManager{}, &fakeAuditRepo{})
+
+	mockRepo.On("GetPermissions", ctx).Return(testPermissions, nil)
+	mockRepo.On("GetRole", ctx, "ghost").Return(nil, nil)
+
+	role, err := service.SetRolePermissions(ctx, "ghost", []string{models.PermissionPVZRead})
+
+	assert.Nil(t, role)
+	assert.ErrorIs(t, err, services.ErrRoleNotFound)
+	mockRepo.AssertNotCalled(t, "SetRolePermissions")
+}
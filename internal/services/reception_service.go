@@ -5,50 +5,250 @@ import (
 	"errors"
 	"time"
 
+	"github.com/forzeyy/avito-internship-spring-service/internal/apperrors"
+	"github.com/forzeyy/avito-internship-spring-service/internal/metrics"
 	"github.com/forzeyy/avito-internship-spring-service/internal/models"
 	"github.com/forzeyy/avito-internship-spring-service/internal/repos"
 	"github.com/google/uuid"
 )
 
 type ReceptionService interface {
-	CreateReception(ctx context.Context, pvzID string) (*models.Reception, error)
-	CloseLastReception(ctx context.Context, pvzID string) (*models.Reception, error)
+	CreateReception(ctx context.Context, pvzID, userID string) (*models.Reception, error)
+	CloseLastReception(ctx context.Context, pvzID, userID string) (*models.Reception, error)
+	GetReceptions(ctx context.Context, params ReceptionListParams) ([]models.Reception, error)
+	GetReception(ctx context.Context, receptionID string) (*models.ReceptionWithProducts, error)
+	CloseStaleReceptions(ctx context.Context, inactiveFor time.Duration) ([]models.Reception, error)
 }
 
+type ReceptionListParams struct {
+	PVZID       string
+	Status      string
+	CreatedFrom *time.Time
+	CreatedTo   *time.Time
+	ClosedFrom  *time.Time
+	ClosedTo    *time.Time
+	Page        int
+	Limit       int
+}
+
+var (
+	ErrReceptionNotFound      = apperrors.NotFound("приемка не найдена")
+	ErrReceptionAlreadyOpened = apperrors.Conflict("в ПВЗ уже есть незакрытая приемка")
+	ErrNoOpenReception        = apperrors.Conflict("последняя открытая приемка не найдена")
+)
+
+// staleReceptionBatchSize - сколько неактивных приемок закрывается в одной транзакции
+const staleReceptionBatchSize = 100
+
 type receptionService struct {
 	receptionRepo repos.ReceptionRepo
+	productRepo   repos.ProductRepo
+	access        pvzAccess
+	txManager     repos.TxManager
+	audit         auditLog
 }
 
-func NewReceptionService(receptionRepo repos.ReceptionRepo) ReceptionService {
-	return &receptionService{receptionRepo: receptionRepo}
+func NewReceptionService(receptionRepo repos.ReceptionRepo, productRepo repos.ProductRepo, assignmentRepo repos.PVZAssignmentRepo, txManager repos.TxManager, auditRepo repos.AuditRepo, allowDummyUsers bool) ReceptionService {
+	return &receptionService{
+		receptionRepo: receptionRepo,
+		productRepo:   productRepo,
+		access:        pvzAccess{repo: assignmentRepo, allowDummyUsers: allowDummyUsers},
+		txManager:     txManager,
+		audit:         auditLog{repo: auditRepo},
+	}
 }
 
-func (rs *receptionService) CreateReception(ctx context.Context, pvzID string) (*models.Reception, error) {
-	_, err := uuid.Parse(pvzID)
+func (rs *receptionService) CreateReception(ctx context.Context, pvzID, userID string) (reception *models.Reception, err error) {
+	var target models.AuditTarget
+	defer func() {
+		if err != nil {
+			rs.audit.failure(ctx, models.AuditActionReceptionOpen, target, err)
+		}
+	}()
+
+	parsedPVZID, err := uuid.Parse(pvzID)
+	if err != nil {
+		return nil, apperrors.Validation("неверный формат pvz_id")
+	}
+	target.PVZID = &parsedPVZID
+
+	createdBy, err := parseUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := rs.access.check(ctx, parsedPVZID, createdBy); err != nil {
+		return nil, err
+	}
+
+	openReception, err := rs.receptionRepo.GetLastOpenReception(ctx, parsedPVZID)
+	if err != nil {
+		return nil, err
+	}
+	if openReception != nil {
+		return nil, ErrReceptionAlreadyOpened
+	}
+
+	reception = &models.Reception{
+		ID:        uuid.New(),
+		PVZID:     parsedPVZID,
+		Status:    "in_progress",
+		DateTime:  time.Now(),
+		CreatedBy: &createdBy,
+	}
+	target.ReceptionID = &reception.ID
+
+	err = rs.txManager.WithTx(ctx, func(ctx context.Context) error {
+		// проверка выше не защищает от параллельных запросов, их отсекает уникальный индекс
+		if err := rs.receptionRepo.CreateReception(ctx, reception); err != nil {
+			if errors.Is(err, repos.ErrOpenReceptionExists) {
+				return ErrReceptionAlreadyOpened
+			}
+			return err
+		}
+		return rs.audit.success(ctx, models.AuditActionReceptionOpen, target)
+	})
+	if err != nil {
+		return nil, err
+	}
+	metrics.ReceptionsCreatedTotal.Inc()
+
+	return reception, nil
+}
+
+func (rs *receptionService) CloseLastReception(ctx context.Context, pvzID, userID string) (reception *models.Reception, err error) {
+	var target models.AuditTarget
+	defer func() {
+		if err != nil {
+			rs.audit.failure(ctx, models.AuditActionReceptionClose, target, err)
+		}
+	}()
+
+	parsedPVZID, err := uuid.Parse(pvzID)
 	if err != nil {
-		return nil, errors.New("неверный формат pvz_id")
+		return nil, apperrors.Validation("неверный формат pvz_id")
 	}
+	target.PVZID = &parsedPVZID
 
-	reception := &models.Reception{
-		ID:       uuid.New(),
-		PVZID:    uuid.MustParse(pvzID),
-		Status:   "in_progress",
-		DateTime: time.Now(),
+	closedBy, err := parseUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := rs.access.check(ctx, parsedPVZID, closedBy); err != nil {
+		return nil, err
 	}
 
-	err = rs.receptionRepo.CreateReception(ctx, reception)
+	err = rs.txManager.WithTx(ctx, func(ctx context.Context) error {
+		var err error
+		reception, err = rs.receptionRepo.CloseLastReception(ctx, parsedPVZID, closedBy, time.Now())
+		if err != nil {
+			return err
+		}
+		if reception == nil {
+			return ErrNoOpenReception
+		}
+		target.ReceptionID = &reception.ID
+		return rs.audit.success(ctx, models.AuditActionReceptionClose, target)
+	})
 	if err != nil {
 		return nil, err
 	}
+	metrics.ReceptionsClosedTotal.Inc()
 
 	return reception, nil
 }
 
-func (rs *receptionService) CloseLastReception(ctx context.Context, pvzID string) (*models.Reception, error) {
-	_, err := uuid.Parse(pvzID)
+func (rs *receptionService) GetReceptions(ctx context.Context, params ReceptionListParams) ([]models.Reception, error) {
+	var filter models.ReceptionFilter
+
+	if params.PVZID != "" {
+		parsedPVZID, err := uuid.Parse(params.PVZID)
+		if err != nil {
+			return nil, apperrors.Validation("неверный формат pvz_id")
+		}
+		filter.PVZID = &parsedPVZID
+	}
+
+	if params.Status != "" {
+		if params.Status != "in_progress" && params.Status != "close" {
+			return nil, apperrors.Validation("неверный статус приемки")
+		}
+		filter.Status = &params.Status
+	}
+
+	filter.CreatedFrom = params.CreatedFrom
+	filter.CreatedTo = params.CreatedTo
+	filter.ClosedFrom = params.ClosedFrom
+	filter.ClosedTo = params.ClosedTo
+
+	return rs.receptionRepo.GetReceptions(ctx, filter, params.Page, params.Limit)
+}
+
+func (rs *receptionService) GetReception(ctx context.Context, receptionID string) (*models.ReceptionWithProducts, error) {
+	parsedID, err := uuid.Parse(receptionID)
 	if err != nil {
-		return nil, errors.New("неверный формат pvz_id")
+		return nil, apperrors.Validation("неверный формат id приемки")
 	}
 
-	return rs.receptionRepo.CloseLastReception(ctx, uuid.MustParse(pvzID))
+	reception, err := rs.receptionRepo.GetReceptionByID(ctx, parsedID)
+	if err != nil {
+		return nil, err
+	}
+	if reception == nil {
+		return nil, ErrReceptionNotFound
+	}
+
+	products, err := rs.productRepo.GetProductsByReceptionIDs(ctx, []uuid.UUID{parsedID})
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.ReceptionWithProducts{
+		Reception: *reception,
+		Products:  products,
+	}, nil
+}
+
+// CloseStaleReceptions закрывает приемки, в которые дольше inactiveFor не добавляли товары.
+// Закрытие идет пачками, каждая в своей транзакции вместе с событиями аудита
+func (rs *receptionService) CloseStaleReceptions(ctx context.Context, inactiveFor time.Duration) ([]models.Reception, error) {
+	inactiveSince := time.Now().Add(-inactiveFor)
+
+	var closed []models.Reception
+	for {
+		var batch []models.Reception
+		err := rs.txManager.WithTx(ctx, func(ctx context.Context) error {
+			var err error
+			batch, err = rs.receptionRepo.CloseStaleReceptions(ctx, inactiveSince, time.Now(), staleReceptionBatchSize)
+			if err != nil {
+				return err
+			}
+			for _, reception := range batch {
+				target := models.AuditTarget{PVZID: &reception.PVZID, ReceptionID: &reception.ID}
+				if err := rs.audit.success(ctx, models.AuditActionReceptionAutoClose, target); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return closed, err
+		}
+
+		closed = append(closed, batch...)
+		metrics.ReceptionsClosedTotal.Add(float64(len(batch)))
+		metrics.ReceptionsAutoClosedTotal.Add(float64(len(batch)))
+
+		if len(batch) < staleReceptionBatchSize {
+			return closed, nil
+		}
+	}
+}
+
+// parseUserID разбирает id пользователя, который middleware.WithRole берет из claim sub
+func parseUserID(userID string) (uuid.UUID, error) {
+	parsedUserID, err := uuid.Parse(userID)
+	if err != nil {
+		return uuid.Nil, apperrors.Unauthorized("неверный идентификатор пользователя")
+	}
+	return parsedUserID, nil
 }
@@ -4,8 +4,11 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/forzeyy/avito-internship-spring-service/internal/models"
+	"github.com/forzeyy/avito-internship-spring-service/internal/repos"
+	"github.com/forzeyy/avito-internship-spring-service/internal/reqctx"
 	"github.com/forzeyy/avito-internship-spring-service/internal/services"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
@@ -29,7 +32,7 @@ func (m *mockReceptionRepo) GetLastOpenReception(ctx context.Context, pvzID uuid
 	return nil, args.Error(1)
 }
 
-func (m *mockReceptionRepo) CloseLastReception(ctx context.Context, pvzID uuid.UUID) (*models.Reception, error) {
+func (m *mockReceptionRepo) LockLastOpenReception(ctx context.Context, pvzID uuid.UUID) (*models.Reception, error) {
 	args := m.Called(ctx, pvzID)
 	if rec, ok := args.Get(0).(*models.Reception); ok {
 		return rec, args.Error(1)
@@ -37,30 +40,95 @@ func (m *mockReceptionRepo) CloseLastReception(ctx context.Context, pvzID uuid.U
 	return nil, args.Error(1)
 }
 
+func (m *mockReceptionRepo) CloseLastReception(ctx context.Context, pvzID, closedBy uuid.UUID, closedAt time.Time) (*models.Reception, error) {
+	args := m.Called(ctx, pvzID, closedBy, closedAt)
+	if rec, ok := args.Get(0).(*models.Reception); ok {
+		return rec, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *mockReceptionRepo) GetReceptionsByPVZIDs(ctx context.Context, pvzIDs []uuid.UUID, startDate, endDate *time.Time) ([]models.Reception, error) {
+	args := m.Called(ctx, pvzIDs, startDate, endDate)
+	if receptions, ok := args.Get(0).([]models.Reception); ok {
+		return receptions, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *mockReceptionRepo) GetClosedReceptions(ctx context.Context, pvzID uuid.UUID, page, limit int) ([]models.ReceptionWithProductCount, error) {
+	args := m.Called(ctx, pvzID, page, limit)
+	if receptions, ok := args.Get(0).([]models.ReceptionWithProductCount); ok {
+		return receptions, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *mockReceptionRepo) GetReceptions(ctx context.Context, filter models.ReceptionFilter, page, limit int) ([]models.Reception, error) {
+	args := m.Called(ctx, filter, page, limit)
+	if receptions, ok := args.Get(0).([]models.Reception); ok {
+		return receptions, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *mockReceptionRepo) GetReceptionByID(ctx context.Context, id uuid.UUID) (*models.Reception, error) {
+	args := m.Called(ctx, id)
+	if rec, ok := args.Get(0).(*models.Reception); ok {
+		return rec, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *mockReceptionRepo) CloseStaleReceptions(ctx context.Context, inactiveSince, closedAt time.Time, limit int) ([]models.Reception, error) {
+	args := m.Called(ctx, inactiveSince, closedAt, limit)
+	if receptions, ok := args.Get(0).([]models.Reception); ok {
+		return receptions, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+// testUserID - id пользователя из токена, от имени которого выполняются действия
+var testUserID = uuid.New()
+
 // CreateReception
 func TestCreateReception_Success(t *testing.T) {
 	ctx := context.Background()
 	mockRepo := new(mockReceptionRepo)
-	service := services.NewReceptionService(mockRepo)
+	service := services.NewReceptionService(mockRepo, nil, assignedEverywhere(), fakeTxManager{}, &fakeAuditRepo{}, false)
 
 	pvzID := uuid.New().String()
 
+	mockRepo.On("GetLastOpenReception", ctx, uuid.MustParse(pvzID)).Return(nil, nil)
 	mockRepo.On("CreateReception", ctx, mock.AnythingOfType("*models.Reception")).Return(nil)
 
-	reception, err := service.CreateReception(ctx, pvzID)
+	reception, err := service.CreateReception(ctx, pvzID, testUserID.String())
 
 	assert.NoError(t, err)
 	assert.NotNil(t, reception)
 	assert.Equal(t, "in_progress", reception.Status)
+	assert.Equal(t, &testUserID, reception.CreatedBy)
 	mockRepo.AssertExpectations(t)
 }
 
+func TestCreateReception_InvalidUserID(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := new(mockReceptionRepo)
+	service := services.NewReceptionService(mockRepo, nil, assignedEverywhere(), fakeTxManager{}, &fakeAuditRepo{}, false)
+
+	reception, err := service.CreateReception(ctx, uuid.New().String(), "")
+
+	assert.Nil(t, reception)
+	assert.EqualError(t, err, "неверный идентификатор пользователя")
+	mockRepo.AssertNotCalled(t, "CreateReception")
+}
+
 func TestCreateReception_InvalidUUID(t *testing.T) {
 	ctx := context.Background()
 	mockRepo := new(mockReceptionRepo)
-	service := services.NewReceptionService(mockRepo)
+	service := services.NewReceptionService(mockRepo, nil, assignedEverywhere(), fakeTxManager{}, &fakeAuditRepo{}, false)
 
-	reception, err := service.CreateReception(ctx, "invalid-uuid")
+	reception, err := service.CreateReception(ctx, "invalid-uuid", testUserID.String())
 
 	assert.Nil(t, reception)
 	assert.EqualError(t, err, "неверный формат pvz_id")
@@ -70,24 +138,57 @@ func TestCreateReception_InvalidUUID(t *testing.T) {
 func TestCreateReception_RepoError(t *testing.T) {
 	ctx := context.Background()
 	mockRepo := new(mockReceptionRepo)
-	service := services.NewReceptionService(mockRepo)
+	service := services.NewReceptionService(mockRepo, nil, assignedEverywhere(), fakeTxManager{}, &fakeAuditRepo{}, false)
 
 	pvzID := uuid.New().String()
 
+	mockRepo.On("GetLastOpenReception", ctx, uuid.MustParse(pvzID)).Return(nil, nil)
 	mockRepo.On("CreateReception", ctx, mock.Anything).Return(errors.New("db error"))
 
-	reception, err := service.CreateReception(ctx, pvzID)
+	reception, err := service.CreateReception(ctx, pvzID, testUserID.String())
 
 	assert.Nil(t, reception)
 	assert.EqualError(t, err, "db error")
 	mockRepo.AssertExpectations(t)
 }
 
+func TestCreateReception_AlreadyOpened(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := new(mockReceptionRepo)
+	service := services.NewReceptionService(mockRepo, nil, assignedEverywhere(), fakeTxManager{}, &fakeAuditRepo{}, false)
+
+	pvzID := uuid.New()
+
+	mockRepo.On("GetLastOpenReception", ctx, pvzID).Return(&models.Reception{ID: uuid.New(), PVZID: pvzID, Status: "in_progress"}, nil)
+
+	reception, err := service.CreateReception(ctx, pvzID.String(), testUserID.String())
+
+	assert.Nil(t, reception)
+	assert.ErrorIs(t, err, services.ErrReceptionAlreadyOpened)
+	mockRepo.AssertNotCalled(t, "CreateReception")
+}
+
+func TestCreateReception_ConcurrentlyOpened(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := new(mockReceptionRepo)
+	service := services.NewReceptionService(mockRepo, nil, assignedEverywhere(), fakeTxManager{}, &fakeAuditRepo{}, false)
+
+	pvzID := uuid.New()
+
+	mockRepo.On("GetLastOpenReception", ctx, pvzID).Return(nil, nil)
+	mockRepo.On("CreateReception", ctx, mock.Anything).Return(repos.ErrOpenReceptionExists)
+
+	reception, err := service.CreateReception(ctx, pvzID.String(), testUserID.String())
+
+	assert.Nil(t, reception)
+	assert.ErrorIs(t, err, services.ErrReceptionAlreadyOpened)
+}
+
 // CloseLastReception
 func TestCloseLastReception_Success(t *testing.T) {
 	ctx := context.Background()
 	mockRepo := new(mockReceptionRepo)
-	service := services.NewReceptionService(mockRepo)
+	service := services.NewReceptionService(mockRepo, nil, assignedEverywhere(), fakeTxManager{}, &fakeAuditRepo{}, false)
 
 	pvzID := uuid.New()
 	expectedReception := &models.Reception{
@@ -96,21 +197,39 @@ func TestCloseLastReception_Success(t *testing.T) {
 		Status: "closed",
 	}
 
-	mockRepo.On("CloseLastReception", ctx, pvzID).Return(expectedReception, nil)
+	mockRepo.On("CloseLastReception", ctx, pvzID, testUserID, mock.Anything).Return(expectedReception, nil)
 
-	reception, err := service.CloseLastReception(ctx, pvzID.String())
+	reception, err := service.CloseLastReception(ctx, pvzID.String(), testUserID.String())
 
 	assert.NoError(t, err)
 	assert.Equal(t, expectedReception, reception)
 	mockRepo.AssertExpectations(t)
 }
 
+func TestCloseLastReception_ClosedAtFromServiceClock(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := new(mockReceptionRepo)
+	service := services.NewReceptionService(mockRepo, nil, assignedEverywhere(), fakeTxManager{}, &fakeAuditRepo{}, false)
+
+	pvzID := uuid.New()
+	before := time.Now()
+	mockRepo.On("CloseLastReception", ctx, pvzID, testUserID, mock.MatchedBy(func(closedAt time.Time) bool {
+		// время закрытия берется с тех же часов, что и время создания приемки, а не из базы
+		return !closedAt.Before(before) && !closedAt.After(time.Now())
+	})).Return(&models.Reception{ID: uuid.New(), PVZID: pvzID, Status: "close"}, nil)
+
+	_, err := service.CloseLastReception(ctx, pvzID.String(), testUserID.String())
+
+	assert.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+}
+
 func TestCloseLastReception_InvalidUUID(t *testing.T) {
 	ctx := context.Background()
 	mockRepo := new(mockReceptionRepo)
-	service := services.NewReceptionService(mockRepo)
+	service := services.NewReceptionService(mockRepo, nil, assignedEverywhere(), fakeTxManager{}, &fakeAuditRepo{}, false)
 
-	reception, err := service.CloseLastReception(ctx, "not-a-uuid")
+	reception, err := service.CloseLastReception(ctx, "not-a-uuid", testUserID.String())
 
 	assert.Nil(t, reception)
 	assert.EqualError(t, err, "неверный формат pvz_id")
@@ -120,14 +239,203 @@ func TestCloseLastReception_InvalidUUID(t *testing.T) {
 func TestCloseLastReception_RepoError(t *testing.T) {
 	ctx := context.Background()
 	mockRepo := new(mockReceptionRepo)
-	service := services.NewReceptionService(mockRepo)
+	service := services.NewReceptionService(mockRepo, nil, assignedEverywhere(), fakeTxManager{}, &fakeAuditRepo{}, false)
 
 	pvzID := uuid.New()
-	mockRepo.On("CloseLastReception", ctx, pvzID).Return(nil, errors.New("close error"))
+	mockRepo.On("CloseLastReception", ctx, pvzID, testUserID, mock.Anything).Return(nil, errors.New("close error"))
 
-	reception, err := service.CloseLastReception(ctx, pvzID.String())
+	reception, err := service.CloseLastReception(ctx, pvzID.String(), testUserID.String())
 
 	assert.Nil(t, reception)
 	assert.EqualError(t, err, "close error")
 	mockRepo.AssertExpectations(t)
 }
+
+func TestCloseLastReception_NoOpenReception(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := new(mockReceptionRepo)
+	service := services.NewReceptionService(mockRepo, nil, assignedEverywhere(), fakeTxManager{}, &fakeAuditRepo{}, false)
+
+	pvzID := uuid.New()
+	mockRepo.On("CloseLastReception", ctx, pvzID, testUserID, mock.Anything).Return(nil, nil)
+
+	reception, err := service.CloseLastReception(ctx, pvzID.String(), testUserID.String())
+
+	assert.Nil(t, reception)
+	assert.ErrorIs(t, err, services.ErrNoOpenReception)
+	mockRepo.AssertExpectations(t)
+}
+
+// GetReceptions
+func TestGetReceptions_Filter(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := new(mockReceptionRepo)
+	service := services.NewReceptionService(mockRepo, nil, assignedEverywhere(), fakeTxManager{}, &fakeAuditRepo{}, false)
+
+	pvzID := uuid.New()
+	status := "close"
+	from := time.Now().Add(-24 * time.Hour)
+	expected := []models.Reception{{ID: uuid.New(), PVZID: pvzID, Status: status}}
+
+	mockRepo.On("GetReceptions", ctx, models.ReceptionFilter{
+		PVZID:      &pvzID,
+		Status:     &status,
+		ClosedFrom: &from,
+	}, 2, 5).Return(expected, nil)
+
+	result, err := service.GetReceptions(ctx, services.ReceptionListParams{
+		PVZID:      pvzID.String(),
+		Status:     status,
+		ClosedFrom: &from,
+		Page:       2,
+		Limit:      5,
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, expected, result)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestGetReceptions_InvalidStatus(t *testing.T) {
+	mockRepo := new(mockReceptionRepo)
+	service := services.NewReceptionService(mockRepo, nil, assignedEverywhere(), fakeTxManager{}, &fakeAuditRepo{}, false)
+
+	result, err := service.GetReceptions(context.Background(), services.ReceptionListParams{Status: "open", Page: 1, Limit: 10})
+
+	assert.Nil(t, result)
+	assert.EqualError(t, err, "неверный статус приемки")
+	mockRepo.AssertNotCalled(t, "GetReceptions")
+}
+
+// GetReception
+func TestGetReception_Success(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := new(mockReceptionRepo)
+	mockProd := new(mockProductRepo)
+	service := services.NewReceptionService(mockRepo, mockProd, assignedEverywhere(), fakeTxManager{}, &fakeAuditRepo{}, false)
+
+	reception := &models.Reception{ID: uuid.New(), PVZID: uuid.New(), Status: "close"}
+	products := []models.Product{{ID: uuid.New(), Type: "обувь", ReceptionID: reception.ID}}
+
+	mockRepo.On("GetReceptionByID", ctx, reception.ID).Return(reception, nil)
+	mockProd.On("GetProductsByReceptionIDs", ctx, []uuid.UUID{reception.ID}).Return(products, nil)
+
+	result, err := service.GetReception(ctx, reception.ID.String())
+
+	assert.NoError(t, err)
+	assert.Equal(t, *reception, result.Reception)
+	assert.Equal(t, products, result.Products)
+	mockRepo.AssertExpectations(t)
+	mockProd.AssertExpectations(t)
+}
+
+func TestGetReception_NotFound(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := new(mockReceptionRepo)
+	mockProd := new(mockProductRepo)
+	service := services.NewReceptionService(mockRepo, mockProd, assignedEverywhere(), fakeTxManager{}, &fakeAuditRepo{}, false)
+
+	id := uuid.New()
+	mockRepo.On("GetReceptionByID", ctx, id).Return(nil, nil)
+
+	result, err := service.GetReception(ctx, id.String())
+
+	assert.Nil(t, result)
+	assert.ErrorIs(t, err, services.ErrReceptionNotFound)
+	mockProd.AssertNotCalled(t, "GetProductsByReceptionIDs")
+}
+
+// audit
+func TestCreateReception_RecordsAudit(t *testing.T) {
+	ctx := reqctx.WithRequestID(context.Background(), "req-1")
+	ctx = reqctx.WithUser(ctx, reqctx.User{ID: testUserID, Role: "employee"})
+	mockRepo := new(mockReceptionRepo)
+	audit := &fakeAuditRepo{}
+	service := services.NewReceptionService(mockRepo, nil, assignedEverywhere(), fakeTxManager{}, audit, false)
+
+	pvzID := uuid.New()
+	mockRepo.On("GetLastOpenReception", ctx, pvzID).Return(nil, nil)
+	mockRepo.On("CreateReception", ctx, mock.Anything).Return(nil)
+
+	reception, err := service.CreateReception(ctx, pvzID.String(), testUserID.String())
+
+	assert.NoError(t, err)
+	if assert.Len(t, audit.entries, 1) {
+		entry := audit.entries[0]
+		assert.Equal(t, models.AuditActionReceptionOpen, entry.Action)
+		assert.Equal(t, models.AuditOutcomeSuccess, entry.Outcome)
+		assert.Equal(t, &testUserID, entry.ActorID)
+		assert.Equal(t, "employee", entry.ActorRole)
+		assert.Equal(t, "req-1", entry.RequestID)
+		assert.Equal(t, &pvzID, entry.Target.PVZID)
+		assert.Equal(t, &reception.ID, entry.Target.ReceptionID)
+	}
+}
+
+func TestCloseLastReception_RecordsFailure(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := new(mockReceptionRepo)
+	audit := &fakeAuditRepo{}
+	service := services.NewReceptionService(mockRepo, nil, assignedEverywhere(), fakeTxManager{}, audit, false)
+
+	pvzID := uuid.New()
+	mockRepo.On("CloseLastReception", ctx, pvzID, testUserID, mock.Anything).Return(nil, nil)
+
+	_, err := service.CloseLastReception(ctx, pvzID.String(), testUserID.String())
+
+	assert.ErrorIs(t, err, services.ErrNoOpenReception)
+	if assert.Len(t, audit.entries, 1) {
+		entry := audit.entries[0]
+		assert.Equal(t, models.AuditActionReceptionClose, entry.Action)
+		assert.Equal(t, models.AuditOutcomeFailure, entry.Outcome)
+		assert.Equal(t, services.ErrNoOpenReception.Error(), entry.Error)
+		assert.Equal(t, &pvzID, entry.Target.PVZID)
+		assert.Nil(t, entry.Target.ReceptionID)
+	}
+}
+
+// CloseStaleReceptions
+func TestCloseStaleReceptions_ClosesInBatches(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := new(mockReceptionRepo)
+	audit := &fakeAuditRepo{}
+	service := services.NewReceptionService(mockRepo, nil, assignedEverywhere(), fakeTxManager{}, audit, false)
+
+	full := make([]models.Reception, 100)
+	for i := range full {
+		full[i] = models.Reception{ID: uuid.New(), PVZID: uuid.New(), Status: "close", AutoClosed: true}
+	}
+	last := []models.Reception{{ID: uuid.New(), PVZID: uuid.New(), Status: "close", AutoClosed: true}}
+
+	inactiveSince := mock.MatchedBy(func(since time.Time) bool {
+		return time.Since(since) >= 12*time.Hour && time.Since(since) < 12*time.Hour+time.Minute
+	})
+	// полная пачка означает, что могли остаться еще приемки, поэтому запрашивается следующая
+	mockRepo.On("CloseStaleReceptions", ctx, inactiveSince, mock.Anything, 100).Return(full, nil).Once()
+	mockRepo.On("CloseStaleReceptions", ctx, inactiveSince, mock.Anything, 100).Return(last, nil).Once()
+
+	closed, err := service.CloseStaleReceptions(ctx, 12*time.Hour)
+
+	assert.NoError(t, err)
+	assert.Len(t, closed, 101)
+	if assert.Len(t, audit.entries, 101) {
+		entry := audit.entries[100]
+		assert.Equal(t, models.AuditActionReceptionAutoClose, entry.Action)
+		assert.Nil(t, entry.ActorID)
+		assert.Equal(t, &last[0].ID, entry.Target.ReceptionID)
+	}
+	mockRepo.AssertExpectations(t)
+}
+
+func TestCloseStaleReceptions_Error(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := new(mockReceptionRepo)
+	service := services.NewReceptionService(mockRepo, nil, assignedEverywhere(), fakeTxManager{}, &fakeAuditRepo{}, false)
+
+	mockRepo.On("CloseStaleReceptions", ctx, mock.Anything, mock.Anything, 100).Return(nil, errors.New("db error"))
+
+	closed, err := service.CloseStaleReceptions(ctx, time.Hour)
+
+	assert.EqualError(t, err, "db error")
+	assert.Empty(t, closed)
+}
@@ -0,0 +1,221 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/forzeyy/avito-internship-spring-service/internal/apperrors"
+	"github.com/forzeyy/avito-internship-spring-service/internal/models"
+	"github.com/forzeyy/avito-internship-spring-service/internal/repos"
+	"github.com/forzeyy/avito-internship-spring-service/internal/reqctx"
+	"github.com/forzeyy/avito-internship-spring-service/internal/utils"
+	"github.com/google/uuid"
+)
+
+type UserAdminService interface {
+	GetUsers(ctx context.Context, params UserListParams) ([]models.User, error)
+	GetUser(ctx context.Context, userID string) (*models.User, error)
+	ChangeRole(ctx context.Context, userID, role string) (*models.User, error)
+	DeactivateUser(ctx context.Context, userID string) (*models.User, error)
+	ActivateUser(ctx context.Context, userID string) (*models.User, error)
+	ForcePasswordReset(ctx context.Context, userID string) (*models.User, error)
+	BootstrapAdmin(ctx context.Context, email, password string) error
+}
+
+type UserListParams struct {
+	Email  string
+	Role   string
+	Status string
+	Page   int
+	Limit  int
+}
+
+const (
+	UserStatusActive      = "active"
+	UserStatusDeactivated = "deactivated"
+)
+
+type userAdminService struct {
+	userRepo  repos.UserRepo
+	roleRepo  repos.RoleRepo
+	tokenRepo repos.RefreshTokenRepo
+	txManager repos.TxManager
+	audit     auditLog
+}
+
+func NewUserAdminService(userRepo repos.UserRepo, roleRepo repos.RoleRepo, tokenRepo repos.RefreshTokenRepo, txManager repos.TxManager, auditRepo repos.AuditRepo) UserAdminService {
+	return &userAdminService{
+		userRepo:  userRepo,
+		roleRepo:  roleRepo,
+		tokenRepo: tokenRepo,
+		txManager: txManager,
+		audit:     auditLog{repo: auditRepo},
+	}
+}
+
+func (uas *userAdminService) GetUsers(ctx context.Context, params UserListParams) ([]models.User, error) {
+	var filter models.UserFilter
+
+	if params.Email != "" {
+		filter.Email = &params.Email
+	}
+	if params.Role != "" {
+		filter.Role = &params.Role
+	}
+
+	switch params.Status {
+	case "":
+	case UserStatusActive, UserStatusDeactivated:
+		active := params.Status == UserStatusActive
+		filter.Active = &active
+	default:
+		return nil, apperrors.Validation("неверный статус пользователя")
+	}
+
+	return uas.userRepo.GetUsers(ctx, filter, params.Page, params.Limit)
+}
+
+func (uas *userAdminService) GetUser(ctx context.Context, userID string) (*models.User, error) {
+	id, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, apperrors.Validation("неверный формат id пользователя")
+	}
+	return uas.userRepo.GetUserByID(ctx, id)
+}
+
+func (uas *userAdminService) ChangeRole(ctx context.Context, userID, role string) (*models.User, error) {
+	found, err := uas.roleRepo.GetRole(ctx, role)
+	if err != nil {
+		return nil, err
+	}
+	if found == nil {
+		return nil, ErrRoleNotFound
+	}
+
+	return uas.updateUser(ctx, models.AuditActionUserRoleChange, userID, func(ctx context.Context, user *models.User) error {
+		// новая роль попадает в токены при следующем обновлении, а старые access токены отклоняет CheckAccess
+		user.Role = role
+		return uas.userRepo.UpdateUserRole(ctx, user.ID, role)
+	})
+}
+
+func (uas *userAdminService) DeactivateUser(ctx context.Context, userID string) (*models.User, error) {
+	return uas.updateUser(ctx, models.AuditActionUserDeactivate, userID, func(ctx context.Context, user *models.User) error {
+		if user.DeactivatedAt != nil {
+			return nil
+		}
+
+		now := time.Now()
+		user.DeactivatedAt = &now
+		if err := uas.userRepo.SetUserDeactivated(ctx, user.ID, user.DeactivatedAt); err != nil {
+			return err
+		}
+		return uas.tokenRepo.RevokeUserRefreshTokens(ctx, user.ID)
+	})
+}
+
+func (uas *userAdminService) ActivateUser(ctx context.Context, userID string) (*models.User, error) {
+	return uas.updateUser(ctx, models.AuditActionUserActivate, userID, func(ctx context.Context, user *models.User) error {
+		user.DeactivatedAt = nil
+		return uas.userRepo.SetUserDeactivated(ctx, user.ID, nil)
+	})
+}
+
+func (uas *userAdminService) ForcePasswordReset(ctx context.Context, userID string) (*models.User, error) {
+	return uas.updateUser(ctx, models.AuditActionUserForceReset, userID, func(ctx context.Context, user *models.User) error {
+		user.PasswordResetRequired = true
+		if err := uas.userRepo.SetPasswordResetRequired(ctx, user.ID, true); err != nil {
+			return err
+		}
+		return uas.tokenRepo.RevokeUserRefreshTokens(ctx, user.ID)
+	})
+}
+
+// BootstrapAdmin назначает первого администратора при запуске, пока в системе нет ни одного: иначе управлять
+// пользователями и ролями некому. Пользователь с email создается с паролем password, а если он уже зарегистрирован,
+// то получает роль admin с прежним паролем
+func (uas *userAdminService) BootstrapAdmin(ctx context.Context, email, password string) (err error) {
+	admin := models.RoleAdmin
+	admins, err := uas.userRepo.GetUsers(ctx, models.UserFilter{Role: &admin}, 1, 1)
+	if err != nil {
+		return err
+	}
+	if len(admins) > 0 {
+		return nil
+	}
+
+	user, err := uas.userRepo.GetUserByEmail(ctx, email)
+	if err == nil {
+		_, err = uas.updateUser(ctx, models.AuditActionUserRoleChange, user.ID.String(), func(ctx context.Context, user *models.User) error {
+			user.Role = models.RoleAdmin
+			return uas.userRepo.UpdateUserRole(ctx, user.ID, models.RoleAdmin)
+		})
+		return err
+	}
+	if !errors.Is(err, repos.ErrUserNotFound) {
+		return err
+	}
+
+	hashedPassword, err := utils.HashPassword(password)
+	if err != nil {
+		return fmt.Errorf("не удалось захешировать пароль: %v", err)
+	}
+	user = &models.User{
+		ID:           uuid.New(),
+		Email:        email,
+		PasswordHash: hashedPassword,
+		Role:         models.RoleAdmin,
+		CreatedAt:    time.Now(),
+	}
+	target := models.AuditTarget{UserID: &user.ID}
+	defer func() {
+		if err != nil {
+			uas.audit.failure(ctx, models.AuditActionUserRegister, target, err)
+		}
+	}()
+
+	return uas.txManager.WithTx(ctx, func(ctx context.Context) error {
+		if err := uas.userRepo.CreateUser(ctx, user); err != nil {
+			return err
+		}
+		return uas.audit.success(ctx, models.AuditActionUserRegister, target)
+	})
+}
+
+// updateUser загружает пользователя, применяет к нему update и пишет событие аудита в одной транзакции.
+// Свою учетную запись администратор менять не может, иначе он рискует потерять доступ к управлению
+func (uas *userAdminService) updateUser(ctx context.Context, action, userID string, update func(ctx context.Context, user *models.User) error) (user *models.User, err error) {
+	var target models.AuditTarget
+	defer func() {
+		if err != nil {
+			uas.audit.failure(ctx, action, target, err)
+		}
+	}()
+
+	id, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, apperrors.Validation("неверный формат id пользователя")
+	}
+	target.UserID = &id
+
+	if actor, ok := reqctx.UserFromContext(ctx); ok && actor.ID == id {
+		return nil, apperrors.Conflict("нельзя изменить собственную учетную запись")
+	}
+
+	err = uas.txManager.WithTx(ctx, func(ctx context.Context) error {
+		user, err = uas.userRepo.GetUserByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		if err := update(ctx, user); err != nil {
+			return err
+		}
+		return uas.audit.success(ctx, action, target)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return user, nil
+}
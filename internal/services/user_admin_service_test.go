@@ -0,0 +1,195 @@
+package services_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/forzeyy/avito-internship-spring-service/internal/models"
+	"github.com/forzeyy/avito-internship-spring-service/internal/repos"
+	"github.com/forzeyy/avito-internship-spring-service/internal/reqctx"
+	"github.com/forzeyy/avito-internship-spring-service/internal/services"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func adminContext() context.Context {
+	return reqctx.WithUser(context.Background(), reqctx.User{ID: uuid.New(), Role: models.RoleAdmin})
+}
+
+// GetUsers
+func TestGetUsers_Filter(t *testing.T) {
+	mockRepo := new(mockUserRepo)
+	svc := services.NewUserAdminService(mockRepo, new(mockRoleRepo), new(mockRefreshTokenRepo), fakeTxManager{}, &fakeAuditRepo{})
+
+	email, role, active := "ivan", "moderator", false
+	users := []models.User{{ID: uuid.New(), Email: "ivan@mail.ru", Role: role}}
+	mockRepo.On("GetUsers", mock.Anything, models.UserFilter{Email: &email, Role: &role, Active: &active}, 2, 10).Return(users, nil)
+
+	result, err := svc.GetUsers(context.Background(), services.UserListParams{
+		Email:  email,
+		Role:   role,
+		Status: services.UserStatusDeactivated,
+		Page:   2,
+		Limit:  10,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, users, result)
+}
+
+func TestGetUsers_InvalidStatus(t *testing.T) {
+	mockRepo := new(mockUserRepo)
+	svc := services.NewUserAdminService(mockRepo, new(mockRoleRepo), new(mockRefreshTokenRepo), fakeTxManager{}, &fakeAuditRepo{})
+
+	result, err := svc.GetUsers(context.Background(), services.UserListParams{Status: "banned", Page: 1, Limit: 10})
+	assert.Nil(t, result)
+	assert.EqualError(t, err, "неверный статус пользователя")
+	mockRepo.AssertNotCalled(t, "GetUsers", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+// ChangeRole
+func TestChangeRole_Success(t *testing.T) {
+	mockRepo := new(mockUserRepo)
+	mockRoles := new(mockRoleRepo)
+	audit := &fakeAuditRepo{}
+	svc := services.NewUserAdminService(mockRepo, mockRoles, new(mockRefreshTokenRepo), fakeTxManager{}, audit)
+
+	user := &models.User{ID: uuid.New(), Email: "worker@mail.ru", Role: "employee"}
+	mockRoles.On("GetRole", mock.Anything, "moderator").Return(&models.Role{Name: "moderator"}, nil)
+	mockRepo.On("GetUserByID", mock.Anything, user.ID).Return(user, nil)
+	mockRepo.On("UpdateUserRole", mock.Anything, user.ID, "moderator").Return(nil)
+
+	result, err := svc.ChangeRole(adminContext(), user.ID.String(), "moderator")
+	assert.NoError(t, err)
+	assert.Equal(t, "moderator", result.Role)
+
+	if assert.Len(t, audit.entries, 1) {
+		assert.Equal(t, models.AuditActionUserRoleChange, audit.entries[0].Action)
+		assert.Equal(t, &user.ID, audit.entries[0].Target.UserID)
+	}
+	mockRepo.AssertExpectations(t)
+}
+
+func TestChangeRole_UnknownRole(t *testing.T) {
+	mockRepo := new(mockUserRepo)
+	mockRoles := new(mockRoleRepo)
+	svc := services.NewUserAdminService(mockRepo, mockRoles, new(mockRefreshTokenRepo), fakeTxManager{}, &fakeAuditRepo{})
+
+	mockRoles.On("GetRole", mock.Anything, "superuser").Return(nil, nil)
+
+	result, err := svc.ChangeRole(adminContext(), uuid.NewString(), "superuser")
+	assert.Nil(t, result)
+	assert.ErrorIs(t, err, services.ErrRoleNotFound)
+	mockRepo.AssertNotCalled(t, "UpdateUserRole", mock.Anything, mock.Anything, mock.Anything)
+}
+
+// DeactivateUser
+func TestDeactivateUser_RevokesSessions(t *testing.T) {
+	mockRepo := new(mockUserRepo)
+	mockTokens := new(mockRefreshTokenRepo)
+	svc := services.NewUserAdminService(mockRepo, new(mockRoleRepo), mockTokens, fakeTxManager{}, &fakeAuditRepo{})
+
+	user := &models.User{ID: uuid.New(), Role: "employee"}
+	mockRepo.On("GetUserByID", mock.Anything, user.ID).Return(user, nil)
+	mockRepo.On("SetUserDeactivated", mock.Anything, user.ID, mock.AnythingOfType("*time.Time")).Return(nil)
+	mockTokens.On("RevokeUserRefreshTokens", mock.Anything, user.ID).Return(nil)
+
+	result, err := svc.DeactivateUser(adminContext(), user.ID.String())
+	assert.NoError(t, err)
+	assert.NotNil(t, result.DeactivatedAt)
+	mockRepo.AssertExpectations(t)
+	mockTokens.AssertExpectations(t)
+}
+
+func TestDeactivateUser_Self(t *testing.T) {
+	mockRepo := new(mockUserRepo)
+	audit := &fakeAuditRepo{}
+	svc := services.NewUserAdminService(mockRepo, new(mockRoleRepo), new(mockRefreshTokenRepo), fakeTxManager{}, audit)
+
+	ctx := adminContext()
+	actor, _ := reqctx.UserFromContext(ctx)
+
+	result, err := svc.DeactivateUser(ctx, actor.ID.String())
+	assert.Nil(t, result)
+	assert.EqualError(t, err, "нельзя изменить собственную учетную запись")
+	mockRepo.AssertNotCalled(t, "SetUserDeactivated", mock.Anything, mock.Anything, mock.Anything)
+
+	if assert.Len(t, audit.entries, 1) {
+		assert.Equal(t, models.AuditOutcomeFailure, audit.entries[0].Outcome)
+	}
+}
+
+// ForcePasswordReset
+func TestForcePasswordReset_Success(t *testing.T) {
+	mockRepo := new(mockUserRepo)
+	mockTokens := new(mockRefreshTokenRepo)
+	svc := services.NewUserAdminService(mockRepo, new(mockRoleRepo), mockTokens, fakeTxManager{}, &fakeAuditRepo{})
+
+	user := &models.User{ID: uuid.New(), Role: "employee"}
+	mockRepo.On("GetUserByID", mock.Anything, user.ID).Return(user, nil)
+	mockRepo.On("SetPasswordResetRequired", mock.Anything, user.ID, true).Return(nil)
+	mockTokens.On("RevokeUserRefreshTokens", mock.Anything, user.ID).Return(nil)
+
+	result, err := svc.ForcePasswordReset(adminContext(), user.ID.String())
+	assert.NoError(t, err)
+	assert.True(t, result.PasswordResetRequired)
+	mockTokens.AssertExpectations(t)
+}
+
+func TestForcePasswordReset_InvalidID(t *testing.T) {
+	svc := services.NewUserAdminService(new(mockUserRepo), new(mockRoleRepo), new(mockRefreshTokenRepo), fakeTxManager{}, &fakeAuditRepo{})
+
+	result, err := svc.ForcePasswordReset(adminContext(), "not-a-uuid")
+	assert.Nil(t, result)
+	assert.EqualError(t, err, "неверный формат id пользователя")
+}
+
+// BootstrapAdmin
+func TestBootstrapAdmin_CreatesAdmin(t *testing.T) {
+	mockRepo := new(mockUserRepo)
+	audit := &fakeAuditRepo{}
+	svc := services.NewUserAdminService(mockRepo, new(mockRoleRepo), new(mockRefreshTokenRepo), fakeTxManager{}, audit)
+
+	admin := models.RoleAdmin
+	mockRepo.On("GetUsers", mock.Anything, models.UserFilter{Role: &admin}, 1, 1).Return([]models.User{}, nil)
+	mockRepo.On("GetUserByEmail", mock.Anything, "admin@mail.ru").Return(nil, repos.ErrUserNotFound)
+	mockRepo.On("CreateUser", mock.Anything, mock.MatchedBy(func(user *models.User) bool {
+		return user.Email == "admin@mail.ru" && user.Role == models.RoleAdmin && user.PasswordHash != "secret"
+	})).Return(nil)
+
+	err := svc.BootstrapAdmin(context.Background(), "admin@mail.ru", "secret")
+	assert.NoError(t, err)
+	if assert.Len(t, audit.entries, 1) {
+		assert.Equal(t, models.AuditActionUserRegister, audit.entries[0].Action)
+	}
+	mockRepo.AssertExpectations(t)
+}
+
+func TestBootstrapAdmin_PromotesExistingUser(t *testing.T) {
+	mockRepo := new(mockUserRepo)
+	svc := services.NewUserAdminService(mockRepo, new(mockRoleRepo), new(mockRefreshTokenRepo), fakeTxManager{}, &fakeAuditRepo{})
+
+	user := &models.User{ID: uuid.New(), Email: "boss@mail.ru", Role: "moderator"}
+	admin := models.RoleAdmin
+	mockRepo.On("GetUsers", mock.Anything, models.UserFilter{Role: &admin}, 1, 1).Return([]models.User{}, nil)
+	mockRepo.On("GetUserByEmail", mock.Anything, user.Email).Return(user, nil)
+	mockRepo.On("GetUserByID", mock.Anything, user.ID).Return(user, nil)
+	mockRepo.On("UpdateUserRole", mock.Anything, user.ID, models.RoleAdmin).Return(nil)
+
+	err := svc.BootstrapAdmin(context.Background(), user.Email, "ignored")
+	assert.NoError(t, err)
+	mockRepo.AssertNotCalled(t, "CreateUser", mock.Anything, mock.Anything)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestBootstrapAdmin_AdminExists(t *testing.T) {
+	mockRepo := new(mockUserRepo)
+	svc := services.NewUserAdminService(mockRepo, new(mockRoleRepo), new(mockRefreshTokenRepo), fakeTxManager{}, &fakeAuditRepo{})
+
+	admin := models.RoleAdmin
+	mockRepo.On("GetUsers", mock.Anything, models.UserFilter{Role: &admin}, 1, 1).Return([]models.User{{ID: uuid.New(), Role: admin}}, nil)
+
+	err := svc.BootstrapAdmin(context.Background(), "admin@mail.ru", "secret")
+	assert.NoError(t, err)
+	mockRepo.AssertNotCalled(t, "GetUserByEmail", mock.Anything, mock.Anything)
+}
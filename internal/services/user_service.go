@@ -4,10 +4,15 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"log"
+	"slices"
 	"time"
 
+	"github.com/forzeyy/avito-internship-spring-service/internal/apperrors"
 	"github.com/forzeyy/avito-internship-spring-service/internal/models"
+	"github.com/forzeyy/avito-internship-spring-service/internal/notifier"
 	"github.com/forzeyy/avito-internship-spring-service/internal/repos"
+	"github.com/forzeyy/avito-internship-spring-service/internal/reqctx"
 	"github.com/forzeyy/avito-internship-spring-service/internal/utils"
 	"github.com/google/uuid"
 )
@@ -15,29 +20,72 @@ import (
 type UserService interface {
 	DummyLogin(ctx context.Context, role string) (string, error)
 	RegisterUser(ctx context.Context, email, password, role string) error
-	LoginUser(ctx context.Context, email, password string) (string, error)
+	LoginUser(ctx context.Context, email, password, ip string) (*models.TokenPair, error)
+	RefreshTokens(ctx context.Context, refreshToken string) (*models.TokenPair, error)
+	Logout(ctx context.Context, refreshToken string) error
+	GetLockouts(ctx context.Context) ([]models.LoginThrottle, error)
+	UnlockUser(ctx context.Context, email string) error
+	CheckAccess(ctx context.Context, userID, role string) error
+	ForgotPassword(ctx context.Context, email, ip string) error
+	ResetPassword(ctx context.Context, token, password string) error
 }
 
+const (
+	refreshTokenTTL       = 30 * 24 * time.Hour
+	passwordResetTokenTTL = time.Hour
+)
+
+var (
+	ErrInvalidRefreshToken = apperrors.Unauthorized("недействительный refresh токен")
+	ErrRefreshTokenReused  = apperrors.Unauthorized("refresh токен уже использован, сессия отозвана")
+
+	ErrUserDeactivated       = apperrors.Forbidden("учетная запись деактивирована")
+	ErrPasswordResetRequired = apperrors.Forbidden("требуется сброс пароля")
+	ErrAccessRevoked         = apperrors.Unauthorized("доступ отозван, войдите заново")
+
+	ErrInvalidResetToken = apperrors.Validation("ссылка для сброса пароля недействительна или устарела")
+)
+
 type userService struct {
-	userRepo repos.UserRepo
-	jwtKey   []byte
-	authUtil utils.AuthUtil
+	userRepo     repos.UserRepo
+	tokenRepo    repos.RefreshTokenRepo
+	throttleRepo repos.LoginThrottleRepo
+	resetRepo    repos.PasswordResetRepo
+	txManager    repos.TxManager
+	audit        auditLog
+	authUtil     utils.AuthUtil
+	notifier     notifier.Notifier
+	// allowDummyUsers пропускает тестовых пользователей из /dummyLogin; включается только в development
+	allowDummyUsers bool
 }
 
-func NewUserService(userRepo repos.UserRepo, jwtKey string, authUtil utils.AuthUtil) UserService {
+func NewUserService(userRepo repos.UserRepo, tokenRepo repos.RefreshTokenRepo, throttleRepo repos.LoginThrottleRepo, resetRepo repos.PasswordResetRepo, txManager repos.TxManager, auditRepo repos.AuditRepo, authUtil utils.AuthUtil, notifier notifier.Notifier, allowDummyUsers bool) UserService {
 	return &userService{
-		userRepo: userRepo,
-		jwtKey:   []byte(jwtKey),
-		authUtil: authUtil,
+		userRepo:     userRepo,
+		tokenRepo:    tokenRepo,
+		throttleRepo: throttleRepo,
+		resetRepo:    resetRepo,
+		txManager:    txManager,
+		audit:        auditLog{repo: auditRepo},
+		authUtil:     authUtil,
+		notifier:     notifier,
+
+		allowDummyUsers: allowDummyUsers,
 	}
 }
 
+// DummyRoles - роли, для которых /dummyLogin выдает тестовый токен; совпадают с ролями из миграции V11
+var DummyRoles = []string{"employee", "senior_employee", "moderator", "regional_manager", "auditor", models.RoleAdmin}
+
 func (us *userService) DummyLogin(ctx context.Context, role string) (string, error) {
-	if role != "employee" && role != "moderator" {
-		return "", errors.New("неверная роль пользователя")
+	if !slices.Contains(DummyRoles, role) {
+		return "", apperrors.Validation("неверная роль пользователя")
 	}
 
-	token, err := us.authUtil.GenerateAccessToken(role, string(us.jwtKey))
+	// тестовый пользователь не хранится в базе, но получает постоянный id для каждой роли,
+	// чтобы его действия можно было отличить в created_by/closed_by
+	user := DummyUser(role)
+	token, err := us.authUtil.GenerateAccessToken(user.ID.String(), user.Email, user.Role)
 	if err != nil {
 		return "", fmt.Errorf("не удалось сгенерировать токен: %v", err)
 	}
@@ -45,14 +93,33 @@ func (us *userService) DummyLogin(ctx context.Context, role string) (string, err
 	return token, nil
 }
 
-func (us *userService) RegisterUser(ctx context.Context, email, password, role string) error {
-	if role != "employee" && role != "moderator" {
-		return errors.New("неверная роль пользователя")
+func DummyUser(role string) *models.User {
+	return &models.User{
+		ID:    uuid.NewSHA1(uuid.NameSpaceURL, []byte("dummy/"+role)),
+		Email: role + "@dummy.local",
+		Role:  role,
+	}
+}
+
+func (us *userService) RegisterUser(ctx context.Context, email, password, role string) (err error) {
+	var target models.AuditTarget
+	defer func() {
+		if err != nil {
+			us.audit.failure(ctx, models.AuditActionUserRegister, target, err)
+		}
+	}()
+
+	// остальные роли назначает администратор, иначе любой мог бы зарегистрироваться модератором
+	if role != models.RoleEmployee {
+		return apperrors.Validation("неверная роль пользователя")
 	}
 
-	_, err := us.userRepo.GetUserByEmail(ctx, email)
+	_, err = us.userRepo.GetUserByEmail(ctx, email)
 	if err == nil {
-		return errors.New("пользователь с таким email уже существует")
+		return apperrors.Conflict("пользователь с таким email уже существует")
+	}
+	if !errors.Is(err, repos.ErrUserNotFound) {
+		return err
 	}
 
 	hashedPassword, err := utils.HashPassword(password)
@@ -67,24 +134,360 @@ func (us *userService) RegisterUser(ctx context.Context, email, password, role s
 		Role:         role,
 		CreatedAt:    time.Now(),
 	}
+	target.UserID = &user.ID
 
-	return us.userRepo.CreateUser(ctx, user)
+	return us.txManager.WithTx(ctx, func(ctx context.Context) error {
+		if err := us.userRepo.CreateUser(ctx, user); err != nil {
+			return err
+		}
+		return us.audit.success(ctx, models.AuditActionUserRegister, target)
+	})
 }
 
-func (us *userService) LoginUser(ctx context.Context, email, password string) (string, error) {
+func (us *userService) LoginUser(ctx context.Context, email, password, ip string) (pair *models.TokenPair, err error) {
+	var target models.AuditTarget
+	defer func() {
+		if err != nil {
+			us.audit.failure(ctx, models.AuditActionUserLogin, target, err)
+		}
+	}()
+
+	now := time.Now()
+	throttleKeys := loginThrottleKeys(email, ip)
+	if err := us.checkLoginThrottle(ctx, throttleKeys, now); err != nil {
+		return nil, err
+	}
+
 	user, err := us.userRepo.GetUserByEmail(ctx, email)
+	if errors.Is(err, repos.ErrUserNotFound) {
+		if err := us.recordLoginFailure(ctx, throttleKeys, now); err != nil {
+			return nil, err
+		}
+		return nil, apperrors.NotFound("пользователь с таким email не найден")
+	}
 	if err != nil {
-		return "", errors.New("пользователь с таким email не найден")
+		return nil, err
 	}
+	target.UserID = &user.ID
 
 	if !us.authUtil.CheckPassword(user.PasswordHash, password) {
-		return "", errors.New("неверный пароль")
+		if err := us.recordLoginFailure(ctx, throttleKeys, now); err != nil {
+			return nil, err
+		}
+		return nil, apperrors.Validation("неверный пароль")
+	}
+	// статус проверяется после пароля, чтобы по ответу нельзя было узнать о состоянии чужой учетной записи
+	if err := checkUserStatus(user); err != nil {
+		return nil, err
 	}
 
-	token, err := us.authUtil.GenerateAccessToken(user.Role, string(us.jwtKey))
+	err = us.txManager.WithTx(ctx, func(ctx context.Context) error {
+		// каждый вход начинает новую сессию со своим семейством refresh токенов
+		var err error
+		pair, err = us.issueTokens(ctx, user, uuid.New())
+		if err != nil {
+			return err
+		}
+		// счетчик по IP не сбрасывается: иначе один известный пароль открыл бы перебор остальных аккаунтов
+		if err := us.throttleRepo.ResetLoginThrottle(ctx, models.LoginThrottleScopeEmail, normalizeEmail(email)); err != nil {
+			return err
+		}
+		// до входа пользователь анонимен, поэтому действующим лицом записывается он сам
+		ctx = reqctx.WithUser(ctx, reqctx.User{ID: user.ID, Role: user.Role})
+		return us.audit.success(ctx, models.AuditActionUserLogin, target)
+	})
 	if err != nil {
-		return "", fmt.Errorf("не удалось сгенерировать токен: %v", err)
+		return nil, err
 	}
 
-	return token, nil
+	return pair, nil
+}
+
+func (us *userService) GetLockouts(ctx context.Context) ([]models.LoginThrottle, error) {
+	return us.throttleRepo.GetActiveLockouts(ctx, time.Now())
+}
+
+func (us *userService) UnlockUser(ctx context.Context, email string) (err error) {
+	var target models.AuditTarget
+	defer func() {
+		if err != nil {
+			us.audit.failure(ctx, models.AuditActionUserUnlock, target, err)
+		}
+	}()
+
+	// счетчик ведется и для несуществующих email, поэтому сбрасывается даже без пользователя
+	user, err := us.userRepo.GetUserByEmail(ctx, email)
+	if err != nil && !errors.Is(err, repos.ErrUserNotFound) {
+		return err
+	}
+	if user != nil {
+		target.UserID = &user.ID
+	}
+
+	return us.txManager.WithTx(ctx, func(ctx context.Context) error {
+		if err := us.throttleRepo.ResetLoginThrottle(ctx, models.LoginThrottleScopeEmail, normalizeEmail(email)); err != nil {
+			return err
+		}
+		return us.audit.success(ctx, models.AuditActionUserUnlock, target)
+	})
+}
+
+// CheckAccess вызывается на каждом запросе с access токеном: токен действует до истечения срока,
+// поэтому деактивация, сброс пароля и смена роли проверяются по базе
+func (us *userService) CheckAccess(ctx context.Context, userID, role string) error {
+	id, err := uuid.Parse(userID)
+	if err != nil {
+		return ErrAccessRevoked
+	}
+	// тестовые пользователи из /dummyLogin не хранятся в базе
+	if us.allowDummyUsers && id == DummyUser(role).ID {
+		return nil
+	}
+
+	user, err := us.userRepo.GetUserByID(ctx, id)
+	if errors.Is(err, repos.ErrUserNotFound) {
+		return ErrAccessRevoked
+	}
+	if err != nil {
+		return err
+	}
+	if checkUserStatus(user) != nil || user.Role != role {
+		return ErrAccessRevoked
+	}
+	return nil
+}
+
+func checkUserStatus(user *models.User) error {
+	if user.DeactivatedAt != nil {
+		return ErrUserDeactivated
+	}
+	if user.PasswordResetRequired {
+		return ErrPasswordResetRequired
+	}
+	return nil
+}
+
+func (us *userService) RefreshTokens(ctx context.Context, refreshToken string) (*models.TokenPair, error) {
+	var (
+		pair   *models.TokenPair
+		reused bool
+	)
+	err := us.txManager.WithTx(ctx, func(ctx context.Context) error {
+		token, err := us.tokenRepo.LockRefreshToken(ctx, utils.HashRefreshToken(refreshToken))
+		if err != nil {
+			return err
+		}
+		if token == nil {
+			return ErrInvalidRefreshToken
+		}
+
+		// предъявлен уже замененный токен: его могли украсть, поэтому отзывается вся сессия.
+		// Ошибка возвращается после фиксации транзакции, иначе отзыв откатится
+		if token.RevokedAt != nil {
+			reused = true
+			return us.tokenRepo.RevokeRefreshTokenFamily(ctx, token.FamilyID)
+		}
+		if time.Now().After(token.ExpiresAt) {
+			return ErrInvalidRefreshToken
+		}
+
+		user, err := us.userRepo.GetUserByID(ctx, token.UserID)
+		if errors.Is(err, repos.ErrUserNotFound) {
+			return ErrInvalidRefreshToken
+		}
+		if err != nil {
+			return err
+		}
+		if checkUserStatus(user) != nil {
+			return ErrInvalidRefreshToken
+		}
+
+		if err := us.tokenRepo.RevokeRefreshToken(ctx, token.ID); err != nil {
+			return err
+		}
+		pair, err = us.issueTokens(ctx, user, token.FamilyID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if reused {
+		return nil, ErrRefreshTokenReused
+	}
+
+	return pair, nil
+}
+
+func (us *userService) Logout(ctx context.Context, refreshToken string) error {
+	return us.txManager.WithTx(ctx, func(ctx context.Context) error {
+		token, err := us.tokenRepo.LockRefreshToken(ctx, utils.HashRefreshToken(refreshToken))
+		if err != nil {
+			return err
+		}
+		if token == nil {
+			return ErrInvalidRefreshToken
+		}
+
+		return us.tokenRepo.RevokeRefreshTokenFamily(ctx, token.FamilyID)
+	})
+}
+
+// ForgotPassword отправляет на email одноразовый токен сброса пароля. Для неизвестного или деактивированного email
+// письмо не отправляется, но ответ тот же, чтобы по нему нельзя было проверить, зарегистрирован ли адрес.
+// Поэтому и письмо уходит в фоне: ни время ответа, ни ошибка доставки не зависят от того, есть ли пользователь
+func (us *userService) ForgotPassword(ctx context.Context, email, ip string) (err error) {
+	var target models.AuditTarget
+	defer func() {
+		if err != nil {
+			us.audit.failure(ctx, models.AuditActionUserResetRequest, target, err)
+		}
+	}()
+
+	now := time.Now()
+	if err := us.checkResetThrottle(ctx, resetThrottleKeys(email, ip), now); err != nil {
+		return err
+	}
+
+	user, err := us.userRepo.GetUserByEmail(ctx, email)
+	if errors.Is(err, repos.ErrUserNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if user.DeactivatedAt != nil {
+		return nil
+	}
+	target.UserID = &user.ID
+
+	token, tokenHash, err := utils.GeneratePasswordResetToken()
+	if err != nil {
+		return fmt.Errorf("не удалось сгенерировать токен сброса пароля: %v", err)
+	}
+
+	err = us.txManager.WithTx(ctx, func(ctx context.Context) error {
+		// действует только последний запрошенный токен
+		if err := us.resetRepo.RevokeUserResetTokens(ctx, user.ID); err != nil {
+			return err
+		}
+		err := us.resetRepo.CreateResetToken(ctx, &models.PasswordResetToken{
+			ID:        uuid.New(),
+			UserID:    user.ID,
+			TokenHash: tokenHash,
+			ExpiresAt: now.Add(passwordResetTokenTTL),
+			CreatedAt: now,
+		})
+		if err != nil {
+			return err
+		}
+		ctx = reqctx.WithUser(ctx, reqctx.User{ID: user.ID, Role: user.Role})
+		return us.audit.success(ctx, models.AuditActionUserResetRequest, target)
+	})
+	if err != nil {
+		return err
+	}
+
+	// письмо отправляется после фиксации транзакции, чтобы пользователь не получил токен, которого нет в базе
+	msg := notifier.Message{
+		To:      user.Email,
+		Subject: "Сброс пароля",
+		Body: fmt.Sprintf("Для вашей учетной записи запрошен сброс пароля.\n\n"+
+			"Токен для сброса: %s\n\n"+
+			"Токен одноразовый и действует %d мин. Если вы не запрашивали сброс, просто проигнорируйте это письмо.",
+			token, int(passwordResetTokenTTL.Minutes())),
+	}
+	go func() {
+		if err := us.notifier.Send(context.WithoutCancel(ctx), msg); err != nil {
+			log.Printf("не удалось отправить письмо для сброса пароля пользователю %s: %v", user.ID, err)
+		}
+	}()
+	return nil
+}
+
+// ResetPassword меняет пароль по токену из письма. Все сессии пользователя при этом завершаются,
+// а блокировка входа по его email снимается
+func (us *userService) ResetPassword(ctx context.Context, token, password string) (err error) {
+	var target models.AuditTarget
+	defer func() {
+		if err != nil {
+			us.audit.failure(ctx, models.AuditActionUserPasswordReset, target, err)
+		}
+	}()
+
+	if password == "" {
+		return apperrors.Validation("пароль пользователя не может быть пустым")
+	}
+
+	hashedPassword, err := utils.HashPassword(password)
+	if err != nil {
+		return fmt.Errorf("не удалось захешировать пароль: %v", err)
+	}
+
+	return us.txManager.WithTx(ctx, func(ctx context.Context) error {
+		resetToken, err := us.resetRepo.LockResetToken(ctx, utils.HashPasswordResetToken(token))
+		if err != nil {
+			return err
+		}
+		if resetToken == nil || resetToken.UsedAt != nil || time.Now().After(resetToken.ExpiresAt) {
+			return ErrInvalidResetToken
+		}
+		target.UserID = &resetToken.UserID
+
+		user, err := us.userRepo.GetUserByID(ctx, resetToken.UserID)
+		if errors.Is(err, repos.ErrUserNotFound) {
+			return ErrInvalidResetToken
+		}
+		if err != nil {
+			return err
+		}
+		// деактивированный пользователь не должен вернуть себе доступ через старое письмо
+		if user.DeactivatedAt != nil {
+			return ErrInvalidResetToken
+		}
+
+		if err := us.userRepo.UpdatePassword(ctx, user.ID, hashedPassword); err != nil {
+			return err
+		}
+		if err := us.resetRepo.RevokeUserResetTokens(ctx, user.ID); err != nil {
+			return err
+		}
+		if err := us.tokenRepo.RevokeUserRefreshTokens(ctx, user.ID); err != nil {
+			return err
+		}
+		if err := us.throttleRepo.ResetLoginThrottle(ctx, models.LoginThrottleScopeEmail, normalizeEmail(user.Email)); err != nil {
+			return err
+		}
+		ctx = reqctx.WithUser(ctx, reqctx.User{ID: user.ID, Role: user.Role})
+		return us.audit.success(ctx, models.AuditActionUserPasswordReset, target)
+	})
+}
+
+func (us *userService) issueTokens(ctx context.Context, user *models.User, familyID uuid.UUID) (*models.TokenPair, error) {
+	accessToken, err := us.authUtil.GenerateAccessToken(user.ID.String(), user.Email, user.Role)
+	if err != nil {
+		return nil, fmt.Errorf("не удалось сгенерировать токен: %v", err)
+	}
+
+	refreshToken, tokenHash, err := utils.GenerateRefreshToken()
+	if err != nil {
+		return nil, fmt.Errorf("не удалось сгенерировать refresh токен: %v", err)
+	}
+
+	now := time.Now()
+	err = us.tokenRepo.CreateRefreshToken(ctx, &models.RefreshToken{
+		ID:        uuid.New(),
+		UserID:    user.ID,
+		FamilyID:  familyID,
+		TokenHash: tokenHash,
+		ExpiresAt: now.Add(refreshTokenTTL),
+		CreatedAt: now,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.TokenPair{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+	}, nil
 }
@@ -2,12 +2,13 @@ package services_test
 
 import (
 	"context"
-	"errors"
 	"testing"
 	"time"
 
 	"github.com/forzeyy/avito-internship-spring-service/internal/models"
+	"github.com/forzeyy/avito-internship-spring-service/internal/repos"
 	"github.com/forzeyy/avito-internship-spring-service/internal/services"
+	"github.com/forzeyy/avito-internship-spring-service/internal/utils"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -28,12 +29,74 @@ func (m *mockUserRepo) GetUserByEmail(ctx context.Context, email string) (*model
 	return user, args.Error(1)
 }
 
+func (m *mockUserRepo) GetUserByID(ctx context.Context, id uuid.UUID) (*models.User, error) {
+	args := m.Called(ctx, id)
+	user, _ := args.Get(0).(*models.User)
+	return user, args.Error(1)
+}
+
+func (m *mockUserRepo) GetUsers(ctx context.Context, filter models.UserFilter, page, limit int) ([]models.User, error) {
+	args := m.Called(ctx, filter, page, limit)
+	users, _ := args.Get(0).([]models.User)
+	return users, args.Error(1)
+}
+
+func (m *mockUserRepo) UpdateUserRole(ctx context.Context, id uuid.UUID, role string) error {
+	args := m.Called(ctx, id, role)
+	return args.Error(0)
+}
+
+func (m *mockUserRepo) SetUserDeactivated(ctx context.Context, id uuid.UUID, deactivatedAt *time.Time) error {
+	args := m.Called(ctx, id, deactivatedAt)
+	return args.Error(0)
+}
+
+func (m *mockUserRepo) SetPasswordResetRequired(ctx context.Context, id uuid.UUID, required bool) error {
+	args := m.Called(ctx, id, required)
+	return args.Error(0)
+}
+
+func (m *mockUserRepo) UpdatePassword(ctx context.Context, id uuid.UUID, passwordHash string) error {
+	args := m.Called(ctx, id, passwordHash)
+	return args.Error(0)
+}
+
+type mockRefreshTokenRepo struct {
+	mock.Mock
+}
+
+func (m *mockRefreshTokenRepo) CreateRefreshToken(ctx context.Context, token *models.RefreshToken) error {
+	args := m.Called(ctx, token)
+	return args.Error(0)
+}
+
+func (m *mockRefreshTokenRepo) LockRefreshToken(ctx context.Context, tokenHash string) (*models.RefreshToken, error) {
+	args := m.Called(ctx, tokenHash)
+	token, _ := args.Get(0).(*models.RefreshToken)
+	return token, args.Error(1)
+}
+
+func (m *mockRefreshTokenRepo) RevokeRefreshToken(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *mockRefreshTokenRepo) RevokeRefreshTokenFamily(ctx context.Context, familyID uuid.UUID) error {
+	args := m.Called(ctx, familyID)
+	return args.Error(0)
+}
+
+func (m *mockRefreshTokenRepo) RevokeUserRefreshTokens(ctx context.Context, userID uuid.UUID) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
+
 type mockAuthUtil struct {
 	mock.Mock
 }
 
-func (m *mockAuthUtil) GenerateAccessToken(role, key string) (string, error) {
-	args := m.Called(role, key)
+func (m *mockAuthUtil) GenerateAccessToken(userID, email, role string) (string, error) {
+	args := m.Called(userID, email, role)
 	return args.String(0), args.Error(1)
 }
 
@@ -51,10 +114,10 @@ func TestRegisterUser_Success(t *testing.T) {
 	password := "WeakPassword123"
 	role := "employee"
 
-	mockRepo.On("GetUserByEmail", mock.Anything, email).Return(nil, errors.New("not found"))
+	mockRepo.On("GetUserByEmail", mock.Anything, email).Return(nil, repos.ErrUserNotFound)
 	mockRepo.On("CreateUser", mock.Anything, mock.AnythingOfType("*models.User")).Return(nil)
 
-	svc := services.NewUserService(mockRepo, "secret", mockAuth)
+	svc := services.NewUserService(mockRepo, nil, newFakeLoginThrottleRepo(), nil, fakeTxManager{}, &fakeAuditRepo{}, mockAuth, nil, false)
 
 	err := svc.RegisterUser(context.Background(), email, password, role)
 	assert.NoError(t, err)
@@ -70,7 +133,7 @@ func TestRegisterUser_UserAlreadyExists(t *testing.T) {
 
 	mockRepo.On("GetUserByEmail", mock.Anything, email).Return(&models.User{}, nil)
 
-	svc := services.NewUserService(mockRepo, "secret", mockAuth)
+	svc := services.NewUserService(mockRepo, nil, newFakeLoginThrottleRepo(), nil, fakeTxManager{}, &fakeAuditRepo{}, mockAuth, nil, false)
 
 	err := svc.RegisterUser(context.Background(), email, "passwd", "employee")
 	assert.EqualError(t, err, "пользователь с таким email уже существует")
@@ -80,12 +143,22 @@ func TestRegisterUser_InvalidRole(t *testing.T) {
 	mockRepo := new(mockUserRepo)
 	mockAuth := new(mockAuthUtil)
 
-	svc := services.NewUserService(mockRepo, "secret", mockAuth)
+	svc := services.NewUserService(mockRepo, nil, newFakeLoginThrottleRepo(), nil, fakeTxManager{}, &fakeAuditRepo{}, mockAuth, nil, false)
 
 	err := svc.RegisterUser(context.Background(), "admin@mail.ru", "123", "admin")
 	assert.EqualError(t, err, "неверная роль пользователя")
 }
 
+func TestRegisterUser_ModeratorRejected(t *testing.T) {
+	mockRepo := new(mockUserRepo)
+
+	svc := services.NewUserService(mockRepo, nil, newFakeLoginThrottleRepo(), nil, fakeTxManager{}, &fakeAuditRepo{}, new(mockAuthUtil), nil, false)
+
+	err := svc.RegisterUser(context.Background(), "boss@mail.ru", "123", "moderator")
+	assert.EqualError(t, err, "неверная роль пользователя")
+	mockRepo.AssertNotCalled(t, "CreateUser", mock.Anything, mock.Anything)
+}
+
 // LoginUser
 func TestLoginUser_Success(t *testing.T) {
 	mockRepo := new(mockUserRepo)
@@ -106,16 +179,31 @@ func TestLoginUser_Success(t *testing.T) {
 
 	mockRepo.On("GetUserByEmail", mock.Anything, email).Return(user, nil)
 	mockAuth.On("CheckPassword", hashed, password).Return(true)
-	mockAuth.On("GenerateAccessToken", role, "secret").Return("token123", nil)
+	mockAuth.On("GenerateAccessToken", user.ID.String(), email, role).Return("token123", nil)
+
+	mockTokens := new(mockRefreshTokenRepo)
+	mockTokens.On("CreateRefreshToken", mock.Anything, mock.MatchedBy(func(token *models.RefreshToken) bool {
+		return token.UserID == user.ID && token.FamilyID != uuid.Nil && token.ExpiresAt.After(time.Now())
+	})).Return(nil)
 
-	svc := services.NewUserService(mockRepo, "secret", mockAuth)
+	audit := &fakeAuditRepo{}
+	svc := services.NewUserService(mockRepo, mockTokens, newFakeLoginThrottleRepo(), nil, fakeTxManager{}, audit, mockAuth, nil, false)
 
-	token, err := svc.LoginUser(context.Background(), email, password)
+	tokens, err := svc.LoginUser(context.Background(), email, password, "10.0.0.1")
 	assert.NoError(t, err)
-	assert.Equal(t, "token123", token)
+	assert.Equal(t, "token123", tokens.AccessToken)
+	assert.NotEmpty(t, tokens.RefreshToken)
+
+	// вход записывается от имени самого пользователя
+	if assert.Len(t, audit.entries, 1) {
+		assert.Equal(t, models.AuditActionUserLogin, audit.entries[0].Action)
+		assert.Equal(t, &user.ID, audit.entries[0].ActorID)
+		assert.Equal(t, role, audit.entries[0].ActorRole)
+	}
 
 	mockRepo.AssertExpectations(t)
 	mockAuth.AssertExpectations(t)
+	mockTokens.AssertExpectations(t)
 }
 
 func TestLoginUser_WrongPassword(t *testing.T) {
@@ -126,52 +214,279 @@ func TestLoginUser_WrongPassword(t *testing.T) {
 	password := "wrong"
 	hashed := "hashed"
 
-	user := &models.User{PasswordHash: hashed}
+	user := &models.User{ID: uuid.New(), PasswordHash: hashed}
 
 	mockRepo.On("GetUserByEmail", mock.Anything, email).Return(user, nil)
 	mockAuth.On("CheckPassword", hashed, password).Return(false)
 
-	svc := services.NewUserService(mockRepo, "secret", mockAuth)
+	audit := &fakeAuditRepo{}
+	svc := services.NewUserService(mockRepo, nil, newFakeLoginThrottleRepo(), nil, fakeTxManager{}, audit, mockAuth, nil, false)
 
-	token, err := svc.LoginUser(context.Background(), email, password)
-	assert.Empty(t, token)
+	tokens, err := svc.LoginUser(context.Background(), email, password, "10.0.0.1")
+	assert.Nil(t, tokens)
 	assert.EqualError(t, err, "неверный пароль")
+
+	if assert.Len(t, audit.entries, 1) {
+		assert.Equal(t, models.AuditOutcomeFailure, audit.entries[0].Outcome)
+		assert.Equal(t, &user.ID, audit.entries[0].Target.UserID)
+		assert.Nil(t, audit.entries[0].ActorID)
+	}
 }
 
 func TestLoginUser_NotFound(t *testing.T) {
 	mockRepo := new(mockUserRepo)
 	mockAuth := new(mockAuthUtil)
 
-	mockRepo.On("GetUserByEmail", mock.Anything, "user404@mail.ru").Return(nil, errors.New("not found"))
+	mockRepo.On("GetUserByEmail", mock.Anything, "user404@mail.ru").Return(nil, repos.ErrUserNotFound)
 
-	svc := services.NewUserService(mockRepo, "secret", mockAuth)
+	svc := services.NewUserService(mockRepo, nil, newFakeLoginThrottleRepo(), nil, fakeTxManager{}, &fakeAuditRepo{}, mockAuth, nil, false)
 
-	token, err := svc.LoginUser(context.Background(), "user404@mail.ru", "any")
-	assert.Empty(t, token)
+	tokens, err := svc.LoginUser(context.Background(), "user404@mail.ru", "any", "10.0.0.1")
+	assert.Nil(t, tokens)
 	assert.EqualError(t, err, "пользователь с таким email не найден")
 }
 
+func TestLoginUser_Deactivated(t *testing.T) {
+	mockRepo := new(mockUserRepo)
+	mockAuth := new(mockAuthUtil)
+
+	deactivatedAt := time.Now().Add(-time.Hour)
+	user := &models.User{ID: uuid.New(), PasswordHash: "hashed", DeactivatedAt: &deactivatedAt}
+
+	mockRepo.On("GetUserByEmail", mock.Anything, "gone@mail.ru").Return(user, nil)
+	mockAuth.On("CheckPassword", "hashed", "password").Return(true)
+
+	svc := services.NewUserService(mockRepo, nil, newFakeLoginThrottleRepo(), nil, fakeTxManager{}, &fakeAuditRepo{}, mockAuth, nil, false)
+
+	tokens, err := svc.LoginUser(context.Background(), "gone@mail.ru", "password", "10.0.0.1")
+	assert.Nil(t, tokens)
+	assert.ErrorIs(t, err, services.ErrUserDeactivated)
+	mockAuth.AssertNotCalled(t, "GenerateAccessToken", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestLoginUser_PasswordResetRequired(t *testing.T) {
+	mockRepo := new(mockUserRepo)
+	mockAuth := new(mockAuthUtil)
+
+	user := &models.User{ID: uuid.New(), PasswordHash: "hashed", PasswordResetRequired: true}
+
+	mockRepo.On("GetUserByEmail", mock.Anything, "reset@mail.ru").Return(user, nil)
+	mockAuth.On("CheckPassword", "hashed", "password").Return(true)
+
+	svc := services.NewUserService(mockRepo, nil, newFakeLoginThrottleRepo(), nil, fakeTxManager{}, &fakeAuditRepo{}, mockAuth, nil, false)
+
+	tokens, err := svc.LoginUser(context.Background(), "reset@mail.ru", "password", "10.0.0.1")
+	assert.Nil(t, tokens)
+	assert.ErrorIs(t, err, services.ErrPasswordResetRequired)
+}
+
 // DummyLogin
 func TestDummyLogin_Success(t *testing.T) {
 	mockRepo := new(mockUserRepo)
 	mockAuth := new(mockAuthUtil)
 
-	mockAuth.On("GenerateAccessToken", "employee", "secret").Return("dummy_token", nil)
+	dummy := services.DummyUser("employee")
+	mockAuth.On("GenerateAccessToken", dummy.ID.String(), "employee@dummy.local", "employee").Return("dummy_token", nil)
 
-	svc := services.NewUserService(mockRepo, "secret", mockAuth)
+	svc := services.NewUserService(mockRepo, nil, newFakeLoginThrottleRepo(), nil, fakeTxManager{}, &fakeAuditRepo{}, mockAuth, nil, false)
 
 	token, err := svc.DummyLogin(context.Background(), "employee")
 	assert.NoError(t, err)
 	assert.Equal(t, "dummy_token", token)
 }
 
+func TestDummyLogin_Admin(t *testing.T) {
+	mockAuth := new(mockAuthUtil)
+
+	dummy := services.DummyUser(models.RoleAdmin)
+	mockAuth.On("GenerateAccessToken", dummy.ID.String(), "admin@dummy.local", "admin").Return("admin_token", nil)
+
+	svc := services.NewUserService(new(mockUserRepo), nil, newFakeLoginThrottleRepo(), nil, fakeTxManager{}, &fakeAuditRepo{}, mockAuth, nil, false)
+
+	token, err := svc.DummyLogin(context.Background(), "admin")
+	assert.NoError(t, err)
+	assert.Equal(t, "admin_token", token)
+}
+
 func TestDummyLogin_InvalidRole(t *testing.T) {
 	mockRepo := new(mockUserRepo)
 	mockAuth := new(mockAuthUtil)
 
-	svc := services.NewUserService(mockRepo, "secret", mockAuth)
+	svc := services.NewUserService(mockRepo, nil, newFakeLoginThrottleRepo(), nil, fakeTxManager{}, &fakeAuditRepo{}, mockAuth, nil, false)
 
-	token, err := svc.DummyLogin(context.Background(), "admin")
+	token, err := svc.DummyLogin(context.Background(), "superuser")
 	assert.Empty(t, token)
 	assert.EqualError(t, err, "неверная роль пользователя")
 }
+
+// RefreshTokens
+func TestRefreshTokens_Rotates(t *testing.T) {
+	mockRepo := new(mockUserRepo)
+	mockTokens := new(mockRefreshTokenRepo)
+	mockAuth := new(mockAuthUtil)
+
+	user := &models.User{ID: uuid.New(), Email: "worker@mail.ru", Role: "employee"}
+	stored := &models.RefreshToken{
+		ID:        uuid.New(),
+		UserID:    user.ID,
+		FamilyID:  uuid.New(),
+		ExpiresAt: time.Now().Add(time.Hour),
+	}
+
+	mockTokens.On("LockRefreshToken", mock.Anything, utils.HashRefreshToken("old-token")).Return(stored, nil)
+	mockRepo.On("GetUserByID", mock.Anything, user.ID).Return(user, nil)
+	mockTokens.On("RevokeRefreshToken", mock.Anything, stored.ID).Return(nil)
+	mockAuth.On("GenerateAccessToken", user.ID.String(), user.Email, "employee").Return("new-access", nil)
+	mockTokens.On("CreateRefreshToken", mock.Anything, mock.MatchedBy(func(token *models.RefreshToken) bool {
+		return token.FamilyID == stored.FamilyID && token.TokenHash != stored.TokenHash
+	})).Return(nil)
+
+	svc := services.NewUserService(mockRepo, mockTokens, newFakeLoginThrottleRepo(), nil, fakeTxManager{}, &fakeAuditRepo{}, mockAuth, nil, false)
+
+	tokens, err := svc.RefreshTokens(context.Background(), "old-token")
+	assert.NoError(t, err)
+	assert.Equal(t, "new-access", tokens.AccessToken)
+	assert.NotEqual(t, "old-token", tokens.RefreshToken)
+	mockTokens.AssertExpectations(t)
+}
+
+func TestRefreshTokens_ReuseRevokesFamily(t *testing.T) {
+	mockTokens := new(mockRefreshTokenRepo)
+
+	revokedAt := time.Now().Add(-time.Minute)
+	stored := &models.RefreshToken{
+		ID:        uuid.New(),
+		FamilyID:  uuid.New(),
+		ExpiresAt: time.Now().Add(time.Hour),
+		RevokedAt: &revokedAt,
+	}
+
+	mockTokens.On("LockRefreshToken", mock.Anything, utils.HashRefreshToken("rotated-token")).Return(stored, nil)
+	mockTokens.On("RevokeRefreshTokenFamily", mock.Anything, stored.FamilyID).Return(nil)
+
+	svc := services.NewUserService(new(mockUserRepo), mockTokens, newFakeLoginThrottleRepo(), nil, fakeTxManager{}, &fakeAuditRepo{}, new(mockAuthUtil), nil, false)
+
+	tokens, err := svc.RefreshTokens(context.Background(), "rotated-token")
+	assert.Nil(t, tokens)
+	assert.ErrorIs(t, err, services.ErrRefreshTokenReused)
+	mockTokens.AssertExpectations(t)
+}
+
+func TestRefreshTokens_Expired(t *testing.T) {
+	mockTokens := new(mockRefreshTokenRepo)
+
+	stored := &models.RefreshToken{
+		ID:        uuid.New(),
+		FamilyID:  uuid.New(),
+		ExpiresAt: time.Now().Add(-time.Hour),
+	}
+	mockTokens.On("LockRefreshToken", mock.Anything, utils.HashRefreshToken("expired-token")).Return(stored, nil)
+
+	svc := services.NewUserService(new(mockUserRepo), mockTokens, newFakeLoginThrottleRepo(), nil, fakeTxManager{}, &fakeAuditRepo{}, new(mockAuthUtil), nil, false)
+
+	tokens, err := svc.RefreshTokens(context.Background(), "expired-token")
+	assert.Nil(t, tokens)
+	assert.ErrorIs(t, err, services.ErrInvalidRefreshToken)
+	mockTokens.AssertNotCalled(t, "CreateRefreshToken", mock.Anything, mock.Anything)
+}
+
+func TestRefreshTokens_Unknown(t *testing.T) {
+	mockTokens := new(mockRefreshTokenRepo)
+	mockTokens.On("LockRefreshToken", mock.Anything, utils.HashRefreshToken("unknown")).Return(nil, nil)
+
+	svc := services.NewUserService(new(mockUserRepo), mockTokens, newFakeLoginThrottleRepo(), nil, fakeTxManager{}, &fakeAuditRepo{}, new(mockAuthUtil), nil, false)
+
+	tokens, err := svc.RefreshTokens(context.Background(), "unknown")
+	assert.Nil(t, tokens)
+	assert.ErrorIs(t, err, services.ErrInvalidRefreshToken)
+}
+
+// Logout
+func TestLogout_RevokesFamily(t *testing.T) {
+	mockTokens := new(mockRefreshTokenRepo)
+
+	stored := &models.RefreshToken{ID: uuid.New(), FamilyID: uuid.New()}
+	mockTokens.On("LockRefreshToken", mock.Anything, utils.HashRefreshToken("token")).Return(stored, nil)
+	mockTokens.On("RevokeRefreshTokenFamily", mock.Anything, stored.FamilyID).Return(nil)
+
+	svc := services.NewUserService(new(mockUserRepo), mockTokens, newFakeLoginThrottleRepo(), nil, fakeTxManager{}, &fakeAuditRepo{}, new(mockAuthUtil), nil, false)
+
+	err := svc.Logout(context.Background(), "token")
+	assert.NoError(t, err)
+	mockTokens.AssertExpectations(t)
+}
+
+func TestRefreshTokens_DeactivatedUser(t *testing.T) {
+	mockRepo := new(mockUserRepo)
+	mockTokens := new(mockRefreshTokenRepo)
+
+	deactivatedAt := time.Now()
+	user := &models.User{ID: uuid.New(), Role: "employee", DeactivatedAt: &deactivatedAt}
+	stored := &models.RefreshToken{ID: uuid.New(), UserID: user.ID, FamilyID: uuid.New(), ExpiresAt: time.Now().Add(time.Hour)}
+
+	mockTokens.On("LockRefreshToken", mock.Anything, utils.HashRefreshToken("token")).Return(stored, nil)
+	mockRepo.On("GetUserByID", mock.Anything, user.ID).Return(user, nil)
+
+	svc := services.NewUserService(mockRepo, mockTokens, newFakeLoginThrottleRepo(), nil, fakeTxManager{}, &fakeAuditRepo{}, new(mockAuthUtil), nil, false)
+
+	tokens, err := svc.RefreshTokens(context.Background(), "token")
+	assert.Nil(t, tokens)
+	assert.ErrorIs(t, err, services.ErrInvalidRefreshToken)
+	mockTokens.AssertNotCalled(t, "CreateRefreshToken", mock.Anything, mock.Anything)
+}
+
+// CheckAccess
+func TestCheckAccess(t *testing.T) {
+	deactivatedAt := time.Now()
+	active := &models.User{ID: uuid.New(), Role: "employee"}
+	deactivated := &models.User{ID: uuid.New(), Role: "employee", DeactivatedAt: &deactivatedAt}
+	resetRequired := &models.User{ID: uuid.New(), Role: "employee", PasswordResetRequired: true}
+	missing := uuid.New()
+
+	mockRepo := new(mockUserRepo)
+	for _, user := range []*models.User{active, deactivated, resetRequired} {
+		mockRepo.On("GetUserByID", mock.Anything, user.ID).Return(user, nil)
+	}
+	mockRepo.On("GetUserByID", mock.Anything, missing).Return(nil, repos.ErrUserNotFound)
+
+	svc := services.NewUserService(mockRepo, nil, newFakeLoginThrottleRepo(), nil, fakeTxManager{}, &fakeAuditRepo{}, new(mockAuthUtil), nil, true)
+
+	tests := []struct {
+		name    string
+		userID  string
+		role    string
+		wantErr error
+	}{
+		{name: "активный пользователь", userID: active.ID.String(), role: "employee"},
+		{name: "тестовый пользователь", userID: services.DummyUser("moderator").ID.String(), role: "moderator"},
+		{name: "роль изменилась", userID: active.ID.String(), role: "moderator", wantErr: services.ErrAccessRevoked},
+		{name: "деактивирован", userID: deactivated.ID.String(), role: "employee", wantErr: services.ErrAccessRevoked},
+		{name: "требуется сброс пароля", userID: resetRequired.ID.String(), role: "employee", wantErr: services.ErrAccessRevoked},
+		{name: "пользователь удален", userID: missing.String(), role: "employee", wantErr: services.ErrAccessRevoked},
+		{name: "id не uuid", userID: "user-1", role: "employee", wantErr: services.ErrAccessRevoked},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := svc.CheckAccess(context.Background(), tt.userID, tt.role)
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCheckAccess_DummyUserOutsideDevelopment(t *testing.T) {
+	dummy := services.DummyUser("moderator")
+	mockRepo := new(mockUserRepo)
+	mockRepo.On("GetUserByID", mock.Anything, dummy.ID).Return(nil, repos.ErrUserNotFound)
+
+	svc := services.NewUserService(mockRepo, nil, newFakeLoginThrottleRepo(), nil, fakeTxManager{}, &fakeAuditRepo{}, new(mockAuthUtil), nil, false)
+
+	// токен тестового пользователя, подписанный утекшим ключом, вне development не должен проходить
+	err := svc.CheckAccess(context.Background(), dummy.ID.String(), dummy.Role)
+	assert.ErrorIs(t, err, services.ErrAccessRevoked)
+	mockRepo.AssertExpectations(t)
+}
@@ -2,15 +2,17 @@ package utils
 
 type AuthUtil interface {
 	CheckPassword(hashed, plain string) bool
-	GenerateAccessToken(role, secret string) (string, error)
+	GenerateAccessToken(userID, email, role string) (string, error)
 }
 
-type DefaultAuthUtil struct{}
+type DefaultAuthUtil struct {
+	Keys *KeySet
+}
 
 func (d DefaultAuthUtil) CheckPassword(hashed, plain string) bool {
 	return CheckPassword(hashed, plain)
 }
 
-func (d DefaultAuthUtil) GenerateAccessToken(role string, secret string) (string, error) {
-	return GenerateAccessToken(role, secret)
+func (d DefaultAuthUtil) GenerateAccessToken(userID, email, role string) (string, error) {
+	return GenerateAccessToken(d.Keys, userID, email, role)
 }
@@ -0,0 +1,168 @@
+package utils
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// SigningKey - ключ подписи access токенов. У выведенного из оборота ключа нет приватной части:
+// им только проверяются уже выданные токены, пока они не истекут
+type SigningKey struct {
+	ID         string
+	Method     jwt.SigningMethod
+	PrivateKey any
+	PublicKey  any
+}
+
+// KeySet хранит активный ключ подписи и все ключи, по которым еще проверяются токены.
+// Токены подписываются активным ключом, а ключ для проверки выбирается по заголовку kid
+type KeySet struct {
+	active *SigningKey
+	keys   map[string]*SigningKey
+}
+
+// NewHMACKeySet возвращает набор из одного общего секрета HS256, как до появления асимметричных ключей.
+// Такие токены выпускаются без kid
+func NewHMACKeySet(secret string) *KeySet {
+	key := &SigningKey{
+		Method:     jwt.SigningMethodHS256,
+		PrivateKey: []byte(secret),
+		PublicKey:  []byte(secret),
+	}
+	return &KeySet{
+		active: key,
+		keys:   map[string]*SigningKey{"": key},
+	}
+}
+
+// LoadKeySet читает ключи из PEM файлов каталога dir, kid ключа - имя файла без расширения .pem.
+// Файл с приватным ключом (PKCS#8 или PKCS#1) дает ключ для подписи, файл только с публичным (PKIX) -
+// выведенный из оборота ключ для проверки. Поддерживаются RSA (RS256) и Ed25519 (EdDSA)
+func LoadKeySet(dir, activeKID string) (*KeySet, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.pem"))
+	if err != nil {
+		return nil, fmt.Errorf("не удалось прочитать каталог ключей: %v", err)
+	}
+
+	ks := &KeySet{keys: make(map[string]*SigningKey, len(paths))}
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("не удалось прочитать ключ %s: %v", path, err)
+		}
+
+		kid := strings.TrimSuffix(filepath.Base(path), ".pem")
+		key, err := ParseSigningKey(kid, data)
+		if err != nil {
+			return nil, fmt.Errorf("не удалось разобрать ключ %s: %v", path, err)
+		}
+		ks.keys[kid] = key
+	}
+
+	active, ok := ks.keys[activeKID]
+	if !ok {
+		return nil, fmt.Errorf("активный ключ %q не найден в %s", activeKID, dir)
+	}
+	if active.PrivateKey == nil {
+		return nil, fmt.Errorf("для активного ключа %q нет приватной части", activeKID)
+	}
+	ks.active = active
+
+	return ks, nil
+}
+
+// ParseSigningKey разбирает один PEM блок с приватным или публичным ключом
+func ParseSigningKey(kid string, data []byte) (*SigningKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("файл не содержит PEM блок")
+	}
+
+	var (
+		parsed any
+		err    error
+	)
+	switch block.Type {
+	case "PRIVATE KEY":
+		parsed, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "RSA PRIVATE KEY":
+		parsed, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "PUBLIC KEY":
+		parsed, err = x509.ParsePKIXPublicKey(block.Bytes)
+	default:
+		return nil, fmt.Errorf("неподдерживаемый тип PEM блока %q", block.Type)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	key := &SigningKey{ID: kid}
+	if signer, ok := parsed.(crypto.Signer); ok {
+		key.PrivateKey = signer
+		parsed = signer.Public()
+	}
+
+	switch public := parsed.(type) {
+	case *rsa.PublicKey:
+		key.Method = jwt.SigningMethodRS256
+		key.PublicKey = public
+	case ed25519.PublicKey:
+		key.Method = jwt.SigningMethodEdDSA
+		key.PublicKey = public
+	default:
+		return nil, fmt.Errorf("неподдерживаемый тип ключа %T", parsed)
+	}
+
+	return key, nil
+}
+
+// Sign подписывает claims активным ключом
+func (ks *KeySet) Sign(claims jwt.Claims) (string, error) {
+	if secret, ok := ks.active.PrivateKey.([]byte); ok && len(secret) == 0 {
+		return "", errors.New("секрет JWT не может быть пустым")
+	}
+
+	token := jwt.NewWithClaims(ks.active.Method, claims)
+	if ks.active.ID != "" {
+		token.Header["kid"] = ks.active.ID
+	}
+	return token.SignedString(ks.active.PrivateKey)
+}
+
+// Keyfunc выбирает ключ проверки по kid из заголовка токена.
+// Алгоритм токена должен совпадать с алгоритмом ключа, иначе публичный ключ можно подсунуть как секрет HMAC
+func (ks *KeySet) Keyfunc(token *jwt.Token) (any, error) {
+	kid, _ := token.Header["kid"].(string)
+	key, ok := ks.keys[kid]
+	if !ok {
+		return nil, fmt.Errorf("неизвестный ключ подписи %q", kid)
+	}
+	if token.Method.Alg() != key.Method.Alg() {
+		return nil, jwt.ErrSignatureInvalid
+	}
+	if secret, ok := key.PublicKey.([]byte); ok && len(secret) == 0 {
+		return nil, errors.New("секрет JWT не может быть пустым")
+	}
+	return key.PublicKey, nil
+}
+
+// VerificationKeys возвращает все ключи проверки, включая выведенные из оборота, в порядке kid
+func (ks *KeySet) VerificationKeys() []*SigningKey {
+	keys := make([]*SigningKey, 0, len(ks.keys))
+	for _, key := range ks.keys {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i].ID < keys[j].ID })
+	return keys
+}
@@ -0,0 +1,124 @@
+package utils_test
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/forzeyy/avito-internship-spring-service/internal/utils"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writePrivateKey(t *testing.T, dir, kid string, key any) {
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+	data := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})
+	require.NoError(t, os.WriteFile(filepath.Join(dir, kid+".pem"), data, 0o600))
+}
+
+func writePublicKey(t *testing.T, dir, kid string, key any) {
+	der, err := x509.MarshalPKIXPublicKey(key)
+	require.NoError(t, err)
+	data := pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})
+	require.NoError(t, os.WriteFile(filepath.Join(dir, kid+".pem"), data, 0o600))
+}
+
+func TestKeySet_RotationKeepsRetiredKeys(t *testing.T) {
+	dir := t.TempDir()
+	oldKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	_, newKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	// токен, выпущенный до ротации старым ключом
+	writePrivateKey(t, dir, "old", oldKey)
+	before, err := utils.LoadKeySet(dir, "old")
+	require.NoError(t, err)
+	oldToken, err := utils.GenerateAccessToken(before, "user-id", "a@b.c", "employee")
+	require.NoError(t, err)
+
+	// после ротации от старого ключа остается только публичная часть
+	writePublicKey(t, dir, "old", &oldKey.PublicKey)
+	writePrivateKey(t, dir, "new", newKey)
+	after, err := utils.LoadKeySet(dir, "new")
+	require.NoError(t, err)
+
+	role, err := utils.VerifyAccessToken(oldToken, after)
+	require.NoError(t, err)
+	assert.Equal(t, "employee", *role)
+
+	newToken, err := utils.GenerateAccessToken(after, "user-id", "a@b.c", "moderator")
+	require.NoError(t, err)
+	parsed, err := jwt.Parse(newToken, after.Keyfunc)
+	require.NoError(t, err)
+	assert.Equal(t, "new", parsed.Header["kid"])
+	assert.Equal(t, "EdDSA", parsed.Method.Alg())
+
+	keys := after.VerificationKeys()
+	require.Len(t, keys, 2)
+	assert.Equal(t, "new", keys[0].ID)
+	assert.Equal(t, "old", keys[1].ID)
+}
+
+func TestLoadKeySet_ActiveKeyWithoutPrivatePart(t *testing.T) {
+	dir := t.TempDir()
+	public, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	writePublicKey(t, dir, "retired", public)
+
+	_, err = utils.LoadKeySet(dir, "retired")
+	assert.EqualError(t, err, `для активного ключа "retired" нет приватной части`)
+
+	_, err = utils.LoadKeySet(dir, "missing")
+	assert.ErrorContains(t, err, `активный ключ "missing" не найден`)
+}
+
+func TestKeySet_RejectsUnknownKidAndAlgorithmSwap(t *testing.T) {
+	dir := t.TempDir()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	writePrivateKey(t, dir, "k1", key)
+	keys, err := utils.LoadKeySet(dir, "k1")
+	require.NoError(t, err)
+
+	claims := jwt.MapClaims{"role": "moderator", "exp": time.Now().Add(time.Minute).Unix()}
+
+	unknown := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	unknown.Header["kid"] = "k2"
+	signed, err := unknown.SignedString(key)
+	require.NoError(t, err)
+	_, err = utils.VerifyAccessToken(signed, keys)
+	assert.Error(t, err)
+
+	// публичный ключ RSA, использованный как секрет HS256, не должен проходить проверку
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	require.NoError(t, err)
+	swapped := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	swapped.Header["kid"] = "k1"
+	signed, err = swapped.SignedString(der)
+	require.NoError(t, err)
+	_, err = utils.VerifyAccessToken(signed, keys)
+	assert.ErrorIs(t, err, jwt.ErrSignatureInvalid)
+}
+
+func TestHMACKeySet(t *testing.T) {
+	keys := utils.NewHMACKeySet("secrettt")
+
+	token, err := utils.GenerateAccessToken(keys, "user-id", "a@b.c", "employee")
+	require.NoError(t, err)
+
+	parsed, err := jwt.Parse(token, keys.Keyfunc)
+	require.NoError(t, err)
+	assert.NotContains(t, parsed.Header, "kid")
+
+	_, err = utils.GenerateAccessToken(utils.NewHMACKeySet(""), "user-id", "a@b.c", "employee")
+	assert.EqualError(t, err, "секрет JWT не может быть пустым")
+}
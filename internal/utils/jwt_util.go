@@ -1,7 +1,6 @@
 package utils
 
 import (
-	"errors"
 	"net/http"
 	"time"
 
@@ -9,31 +8,20 @@ import (
 	"github.com/labstack/echo/v4"
 )
 
-func GenerateAccessToken(role, secret string) (string, error) {
-	if len(secret) == 0 {
-		return "", errors.New("секрет JWT не может быть пустым")
-	}
+// AccessTokenTTL короткий, чтобы отзыв refresh токена отключал доступ в течение нескольких минут
+const AccessTokenTTL = 15 * time.Minute
 
-	accessToken := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
-		"role": role,
-		"exp":  time.Now().Add(time.Hour * 24).Unix(),
+func GenerateAccessToken(keys *KeySet, userID, email, role string) (string, error) {
+	return keys.Sign(jwt.MapClaims{
+		"sub":   userID,
+		"email": email,
+		"role":  role,
+		"exp":   time.Now().Add(AccessTokenTTL).Unix(),
 	})
-
-	return accessToken.SignedString([]byte(secret))
 }
 
-func VerifyAccessToken(tokenString string, secret string) (*string, error) {
-	if len(secret) == 0 {
-		return nil, errors.New("секрет JWT не может быть пустым")
-	}
-
-	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, jwt.ErrSignatureInvalid
-		}
-		return []byte(secret), nil
-	})
-
+func VerifyAccessToken(tokenString string, keys *KeySet) (*string, error) {
+	token, err := jwt.Parse(tokenString, keys.Keyfunc)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,33 @@
+package utils
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+)
+
+// GenerateRefreshToken возвращает случайный непрозрачный токен для клиента и его хеш для хранения в базе
+func GenerateRefreshToken() (string, string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", "", err
+	}
+
+	token := base64.RawURLEncoding.EncodeToString(buf)
+	return token, HashRefreshToken(token), nil
+}
+
+func HashRefreshToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// GeneratePasswordResetToken устроен так же, как refresh токен: клиент получает токен в письме, в базе хранится только хеш
+func GeneratePasswordResetToken() (string, string, error) {
+	return GenerateRefreshToken()
+}
+
+func HashPasswordResetToken(token string) string {
+	return HashRefreshToken(token)
+}
@@ -0,0 +1,58 @@
+// Package worker содержит фоновые задачи, которые выполняются внутри сервиса по расписанию
+package worker
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/forzeyy/avito-internship-spring-service/internal/models"
+)
+
+type StaleReceptionCloser interface {
+	CloseStaleReceptions(ctx context.Context, inactiveFor time.Duration) ([]models.Reception, error)
+}
+
+// ReceptionCloser раз в interval закрывает приемки, в которые дольше inactiveFor не добавляли товары.
+// Может работать на нескольких экземплярах сервиса одновременно: репозиторий пропускает заблокированные приемки
+type ReceptionCloser struct {
+	closer      StaleReceptionCloser
+	inactiveFor time.Duration
+	interval    time.Duration
+}
+
+func NewReceptionCloser(closer StaleReceptionCloser, inactiveFor, interval time.Duration) *ReceptionCloser {
+	return &ReceptionCloser{
+		closer:      closer,
+		inactiveFor: inactiveFor,
+		interval:    interval,
+	}
+}
+
+// Run работает до отмены ctx. Ошибка одного прохода только логируется, следующий проход будет по расписанию
+func (rc *ReceptionCloser) Run(ctx context.Context) {
+	log.Printf("автозакрытие приемок включено: без товаров дольше %s, проверка раз в %s", rc.inactiveFor, rc.interval)
+
+	ticker := time.NewTicker(rc.interval)
+	defer ticker.Stop()
+
+	for {
+		rc.closeStale(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (rc *ReceptionCloser) closeStale(ctx context.Context) {
+	closed, err := rc.closer.CloseStaleReceptions(ctx, rc.inactiveFor)
+	if len(closed) > 0 {
+		log.Printf("автоматически закрыто приемок: %d", len(closed))
+	}
+	if err != nil && ctx.Err() == nil {
+		log.Printf("не удалось закрыть неактивные приемки: %v", err)
+	}
+}
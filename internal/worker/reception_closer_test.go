@@ -0,0 +1,57 @@
+package worker_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/forzeyy/avito-internship-spring-service/internal/models"
+	"github.com/forzeyy/avito-internship-spring-service/internal/worker"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeStaleReceptionCloser struct {
+	mu          sync.Mutex
+	calls       int
+	inactiveFor time.Duration
+	err         error
+}
+
+func (f *fakeStaleReceptionCloser) CloseStaleReceptions(ctx context.Context, inactiveFor time.Duration) ([]models.Reception, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls++
+	f.inactiveFor = inactiveFor
+	return nil, f.err
+}
+
+func (f *fakeStaleReceptionCloser) callCount() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.calls
+}
+
+func TestReceptionCloser_RunsUntilCancelled(t *testing.T) {
+	// ошибка прохода не останавливает задачу
+	closer := &fakeStaleReceptionCloser{err: errors.New("db error")}
+	rc := worker.NewReceptionCloser(closer, 12*time.Hour, 10*time.Millisecond)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		rc.Run(ctx)
+		close(done)
+	}()
+
+	assert.Eventually(t, func() bool { return closer.callCount() >= 3 }, time.Second, 5*time.Millisecond)
+	cancel()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("задача не остановилась после отмены контекста")
+	}
+	assert.Equal(t, 12*time.Hour, closer.inactiveFor)
+}
@@ -0,0 +1,2 @@
+-- +migrate Down
+DROP INDEX IF EXISTS idx_pvzs_reg_date_id;
\ No newline at end of file
@@ -0,0 +1,2 @@
+-- +migrate Up
+CREATE INDEX IF NOT EXISTS idx_pvzs_reg_date_id ON pvzs (reg_date DESC, id DESC);
\ No newline at end of file
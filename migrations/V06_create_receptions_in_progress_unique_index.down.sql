@@ -0,0 +1,2 @@
+-- +migrate Down
+DROP INDEX IF EXISTS uniq_receptions_pvz_in_progress;
\ No newline at end of file
@@ -0,0 +1,15 @@
+-- +migrate Up
+-- закрываем лишние открытые приемки, оставляя по одной самой свежей на ПВЗ
+UPDATE receptions
+SET status = 'close', closed_at = NOW()
+WHERE status = 'in_progress'
+  AND id NOT IN (
+    SELECT DISTINCT ON (pvz_id) id
+    FROM receptions
+    WHERE status = 'in_progress'
+    ORDER BY pvz_id, created_at DESC
+  );
+
+CREATE UNIQUE INDEX IF NOT EXISTS uniq_receptions_pvz_in_progress
+    ON receptions (pvz_id)
+    WHERE status = 'in_progress';
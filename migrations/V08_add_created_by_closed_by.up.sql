@@ -0,0 +1,8 @@
+-- +migrate Up
+-- без внешних ключей: пользователи /dummyLogin не хранятся в таблице users
+ALTER TABLE receptions
+    ADD COLUMN IF NOT EXISTS created_by UUID NULL,
+    ADD COLUMN IF NOT EXISTS closed_by UUID NULL;
+
+ALTER TABLE products
+    ADD COLUMN IF NOT EXISTS created_by UUID NULL;
@@ -0,0 +1,19 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS audit_log (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    actor_id UUID NULL,
+    actor_role VARCHAR(50) NOT NULL DEFAULT '',
+    action VARCHAR(50) NOT NULL,
+    pvz_id UUID NULL,
+    reception_id UUID NULL,
+    product_id UUID NULL,
+    user_id UUID NULL,
+    request_id VARCHAR(64) NOT NULL DEFAULT '',
+    outcome VARCHAR(20) NOT NULL CHECK (outcome IN ('success', 'failure')),
+    error_message TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_audit_log_created_at ON audit_log (created_at DESC, id DESC);
+CREATE INDEX IF NOT EXISTS idx_audit_log_actor_id ON audit_log (actor_id);
+CREATE INDEX IF NOT EXISTS idx_audit_log_pvz_id ON audit_log (pvz_id);
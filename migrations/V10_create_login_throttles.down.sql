@@ -0,0 +1,2 @@
+-- +migrate Down
+DROP TABLE IF EXISTS login_throttles;
\ No newline at end of file
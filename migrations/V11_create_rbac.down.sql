@@ -0,0 +1,5 @@
+-- +migrate Down
+ALTER TABLE IF EXISTS users DROP CONSTRAINT IF EXISTS users_role_fkey;
+DROP TABLE IF EXISTS role_permissions;
+DROP TABLE IF EXISTS permissions;
+DROP TABLE IF EXISTS roles;
\ No newline at end of file
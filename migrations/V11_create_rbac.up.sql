@@ -0,0 +1,74 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS roles (
+    name VARCHAR(50) PRIMARY KEY,
+    description TEXT NOT NULL DEFAULT ''
+);
+
+CREATE TABLE IF NOT EXISTS permissions (
+    name VARCHAR(50) PRIMARY KEY,
+    description TEXT NOT NULL DEFAULT ''
+);
+
+CREATE TABLE IF NOT EXISTS role_permissions (
+    role VARCHAR(50) NOT NULL REFERENCES roles (name) ON DELETE CASCADE,
+    permission VARCHAR(50) NOT NULL REFERENCES permissions (name) ON DELETE CASCADE,
+    PRIMARY KEY (role, permission)
+);
+
+INSERT INTO roles (name, description) VALUES
+    ('employee', 'Сотрудник ПВЗ'),
+    ('senior_employee', 'Старший сотрудник ПВЗ'),
+    ('moderator', 'Модератор'),
+    ('regional_manager', 'Региональный менеджер'),
+    ('auditor', 'Аудитор'),
+    ('admin', 'Администратор')
+ON CONFLICT (name) DO NOTHING;
+
+INSERT INTO permissions (name, description) VALUES
+    ('pvz:read', 'Просмотр ПВЗ'),
+    ('pvz:create', 'Создание ПВЗ'),
+    ('reception:read', 'Просмотр приемок'),
+    ('reception:create', 'Открытие приемки'),
+    ('reception:close', 'Закрытие приемки'),
+    ('product:add', 'Добавление товара'),
+    ('product:delete', 'Удаление товара'),
+    ('audit:read', 'Просмотр журнала аудита'),
+    ('user:unlock', 'Просмотр и снятие блокировок входа'),
+    ('role:manage', 'Изменение прав ролей')
+ON CONFLICT (name) DO NOTHING;
+
+-- employee и moderator получают ровно то, что им было доступно до появления прав
+INSERT INTO role_permissions (role, permission) VALUES
+    ('employee', 'pvz:read'),
+    ('employee', 'reception:create'),
+    ('employee', 'reception:close'),
+    ('employee', 'product:add'),
+    ('employee', 'product:delete'),
+    ('senior_employee', 'pvz:read'),
+    ('senior_employee', 'reception:read'),
+    ('senior_employee', 'reception:create'),
+    ('senior_employee', 'reception:close'),
+    ('senior_employee', 'product:add'),
+    ('senior_employee', 'product:delete'),
+    ('moderator', 'pvz:read'),
+    ('moderator', 'pvz:create'),
+    ('moderator', 'reception:read'),
+    ('moderator', 'audit:read'),
+    ('moderator', 'user:unlock'),
+    ('regional_manager', 'pvz:read'),
+    ('regional_manager', 'pvz:create'),
+    ('regional_manager', 'reception:read'),
+    ('regional_manager', 'audit:read'),
+    ('auditor', 'pvz:read'),
+    ('auditor', 'reception:read'),
+    ('auditor', 'audit:read')
+ON CONFLICT DO NOTHING;
+
+INSERT INTO role_permissions (role, permission)
+SELECT 'admin', name FROM permissions
+ON CONFLICT DO NOTHING;
+
+-- роль пользователя теперь ссылается на таблицу ролей вместо CHECK на две роли
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_role_check;
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_role_fkey;
+ALTER TABLE users ADD CONSTRAINT users_role_fkey FOREIGN KEY (role) REFERENCES roles (name);
@@ -0,0 +1,6 @@
+-- +migrate Down
+DELETE FROM role_permissions WHERE permission = 'user:manage';
+DELETE FROM permissions WHERE name = 'user:manage';
+
+ALTER TABLE IF EXISTS users DROP COLUMN IF EXISTS password_reset_required;
+ALTER TABLE IF EXISTS users DROP COLUMN IF EXISTS deactivated_at;
\ No newline at end of file
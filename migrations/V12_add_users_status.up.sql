@@ -0,0 +1,11 @@
+-- +migrate Up
+ALTER TABLE users ADD COLUMN IF NOT EXISTS deactivated_at TIMESTAMP NULL;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS password_reset_required BOOLEAN NOT NULL DEFAULT FALSE;
+
+INSERT INTO permissions (name, description) VALUES
+    ('user:manage', 'Управление пользователями')
+ON CONFLICT (name) DO NOTHING;
+
+INSERT INTO role_permissions (role, permission) VALUES
+    ('admin', 'user:manage')
+ON CONFLICT DO NOTHING;
\ No newline at end of file
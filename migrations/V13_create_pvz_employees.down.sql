@@ -0,0 +1,5 @@
+-- +migrate Down
+DELETE FROM role_permissions WHERE permission = 'pvz:assign';
+DELETE FROM permissions WHERE name = 'pvz:assign';
+
+DROP TABLE IF EXISTS pvz_employees;
\ No newline at end of file
@@ -0,0 +1,20 @@
+-- +migrate Up
+-- сотрудник открывает приемки и работает с товарами только в ПВЗ, за которыми закреплен
+CREATE TABLE IF NOT EXISTS pvz_employees (
+    pvz_id UUID NOT NULL REFERENCES pvzs(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    assigned_by UUID NULL,
+    assigned_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (pvz_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_pvz_employees_user_id ON pvz_employees (user_id);
+
+INSERT INTO permissions (name, description) VALUES
+    ('pvz:assign', 'Закрепление сотрудников за ПВЗ')
+ON CONFLICT (name) DO NOTHING;
+
+INSERT INTO role_permissions (role, permission) VALUES
+    ('moderator', 'pvz:assign'),
+    ('admin', 'pvz:assign')
+ON CONFLICT DO NOTHING;
\ No newline at end of file
@@ -0,0 +1,2 @@
+-- +migrate Down
+DROP TABLE IF EXISTS password_reset_tokens;
\ No newline at end of file
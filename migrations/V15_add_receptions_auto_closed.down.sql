@@ -0,0 +1,5 @@
+-- +migrate Down
+DROP INDEX IF EXISTS idx_products_reception_id_received_at;
+
+ALTER TABLE IF EXISTS receptions
+    DROP COLUMN IF EXISTS auto_closed;
\ No newline at end of file
@@ -0,0 +1,7 @@
+-- +migrate Up
+-- приемку, в которую долго не добавляли товары, закрывает фоновая задача; такие приемки помечаются отдельно
+ALTER TABLE receptions
+    ADD COLUMN IF NOT EXISTS auto_closed BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- время последнего товара приемки для поиска неактивных приемок
+CREATE INDEX IF NOT EXISTS idx_products_reception_id_received_at ON products (reception_id, received_at);
\ No newline at end of file
@@ -0,0 +1,6 @@
+-- +migrate Down
+ALTER TABLE IF EXISTS receptions
+    DROP COLUMN IF EXISTS last_scan_at,
+    DROP COLUMN IF EXISTS first_scan_at,
+    DROP COLUMN IF EXISTS products_by_type,
+    DROP COLUMN IF EXISTS product_count;
\ No newline at end of file
@@ -0,0 +1,28 @@
+-- +migrate Up
+-- итог приемки считается один раз при закрытии
+ALTER TABLE receptions
+    ADD COLUMN IF NOT EXISTS product_count INTEGER NULL,
+    ADD COLUMN IF NOT EXISTS products_by_type JSONB NULL,
+    ADD COLUMN IF NOT EXISTS first_scan_at TIMESTAMP NULL,
+    ADD COLUMN IF NOT EXISTS last_scan_at TIMESTAMP NULL;
+
+-- итоги для приемок, закрытых до появления колонок
+UPDATE receptions r
+SET product_count = s.product_count,
+    products_by_type = s.products_by_type,
+    first_scan_at = s.first_scan_at,
+    last_scan_at = s.last_scan_at
+FROM receptions c,
+LATERAL (
+    SELECT COALESCE(SUM(t.cnt), 0)::INTEGER AS product_count,
+           COALESCE(jsonb_object_agg(t.type, t.cnt), '{}'::JSONB) AS products_by_type,
+           MIN(t.first_at) AS first_scan_at,
+           MAX(t.last_at) AS last_scan_at
+    FROM (
+        SELECT type, COUNT(*) AS cnt, MIN(received_at) AS first_at, MAX(received_at) AS last_at
+        FROM products
+        WHERE reception_id = c.id
+        GROUP BY type
+    ) t
+) s
+WHERE r.id = c.id AND c.status = 'close' AND c.product_count IS NULL;
\ No newline at end of file
@@ -0,0 +1,6 @@
+-- +migrate Down
+DROP INDEX IF EXISTS idx_products_barcode;
+DROP INDEX IF EXISTS uniq_products_reception_id_barcode;
+
+ALTER TABLE products
+    DROP COLUMN IF EXISTS barcode;
\ No newline at end of file
@@ -0,0 +1,10 @@
+-- +migrate Up
+-- штрихкод посылки; у товаров, принятых до его появления, он не заполнен
+ALTER TABLE products
+    ADD COLUMN IF NOT EXISTS barcode VARCHAR(64) NULL;
+
+-- внутри приемки штрихкод уникален всегда; глобальная уникальность включается настройкой BARCODE_UNIQUENESS и проверяется сервисом
+CREATE UNIQUE INDEX IF NOT EXISTS uniq_products_reception_id_barcode ON products (reception_id, barcode) WHERE barcode IS NOT NULL;
+
+-- поиск посылки по штрихкоду
+CREATE INDEX IF NOT EXISTS idx_products_barcode ON products (barcode) WHERE barcode IS NOT NULL;
\ No newline at end of file
@@ -0,0 +1,5 @@
+-- +migrate Down
+DELETE FROM login_throttles WHERE scope IN ('reset_email', 'reset_ip');
+ALTER TABLE login_throttles DROP CONSTRAINT IF EXISTS login_throttles_scope_check;
+ALTER TABLE login_throttles ADD CONSTRAINT login_throttles_scope_check CHECK (scope IN ('email', 'ip'));
+ALTER TABLE login_throttles ALTER COLUMN scope TYPE VARCHAR(10);
\ No newline at end of file
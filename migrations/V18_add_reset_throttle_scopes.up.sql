@@ -0,0 +1,5 @@
+-- +migrate Up
+-- запросы сброса пароля ограничиваются теми же счетчиками, что и попытки входа
+ALTER TABLE login_throttles ALTER COLUMN scope TYPE VARCHAR(20);
+ALTER TABLE login_throttles DROP CONSTRAINT IF EXISTS login_throttles_scope_check;
+ALTER TABLE login_throttles ADD CONSTRAINT login_throttles_scope_check CHECK (scope IN ('email', 'ip', 'reset_email', 'reset_ip'));
\ No newline at end of file
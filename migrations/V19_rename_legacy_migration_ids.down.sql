@@ -0,0 +1,2 @@
+-- +migrate Down
+-- старые id не восстанавливаются: файлов миграций под ними больше нет
\ No newline at end of file
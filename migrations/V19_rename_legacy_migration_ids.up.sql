@@ -0,0 +1,11 @@
+-- +migrate Up
+-- миграции V0..V9 переименованы в V00..V09: записи под старыми id удаляются, если миграция уже записана под новым id
+-- (идемпотентные V00..V09 применяются повторно перед V19), иначе переименовываются; после этого ignoreunknown
+-- в migrate.yaml нужен только для первого запуска на старой базе
+UPDATE gorp_migrations m
+SET id = regexp_replace(m.id, '^V([0-9])_', 'V0\1_')
+WHERE m.id ~ '^V[0-9]_'
+    AND NOT EXISTS (
+        SELECT 1 FROM gorp_migrations n WHERE n.id = regexp_replace(m.id, '^V([0-9])_', 'V0\1_')
+    );
+DELETE FROM gorp_migrations WHERE id ~ '^V[0-9]_';
\ No newline at end of file
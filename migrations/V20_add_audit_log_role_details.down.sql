@@ -0,0 +1,4 @@
+-- +migrate Down
+ALTER TABLE IF EXISTS audit_log
+    DROP COLUMN IF EXISTS details,
+    DROP COLUMN IF EXISTS role;
\ No newline at end of file
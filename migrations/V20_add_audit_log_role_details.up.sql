@@ -0,0 +1,5 @@
+-- +migrate Up
+-- изменение прав роли записывается с именем роли и новым набором прав
+ALTER TABLE audit_log
+    ADD COLUMN IF NOT EXISTS role VARCHAR(50) NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS details JSONB NULL;
\ No newline at end of file